@@ -1,70 +1,16 @@
 package main
 
 import (
-	"image"
-	"image/png"
-	"os"
 	"testing"
-	"time"
 
-	"github.com/zodimo/go-compose/compose"
-	"github.com/zodimo/go-compose/internal/screenshot"
-	"github.com/zodimo/go-compose/runtime"
-	"github.com/zodimo/go-compose/state"
-	"github.com/zodimo/go-compose/store"
-	"github.com/zodimo/go-compose/theme"
-
-	"gioui.org/io/system"
-	"gioui.org/layout"
-	"gioui.org/op"
-	"gioui.org/unit"
+	"github.com/zodimo/go-compose/composetest"
+	"github.com/zodimo/go-compose/screenshot/golden"
 )
 
-// TestScreenshot renders the Bottom Sheet UI and saves a screenshot.
+// TestScreenshot renders the Bottom Sheet UI and compares it with its golden
+// image.
 func TestScreenshot(t *testing.T) {
-	// 1. Setup Context
-	var ops op.Ops
-	gtx := layout.Context{
-		Ops: &ops,
-		Metric: unit.Metric{
-			PxPerDp: 1.0,
-			PxPerSp: 1.0,
-		},
-		Constraints: layout.Exact(image.Point{X: 400, Y: 800}),
-		Now:         time.Now(),
-		Locale:      system.Locale{Language: "en", Direction: system.LTR},
-	}
-
-	// 2. Init Dependencies
-	themeManager := theme.GetThemeManager()
-	gtx = themeManager.Material3ThemeInit(gtx)
-
-	store := store.NewPersistentState(map[string]state.MutableValue{})
-	rt := runtime.NewRuntime()
-
-	// 3. Compose UI
-	composer := compose.NewComposer(store)
-	layoutNode := UI(composer)
-
-	// 4. Run Layout
-	callOp := rt.Run(gtx, layoutNode)
-
-	// 5. Take Screenshot
-	// Note: TakeScreenshot helper logic might differ.
-	// Assuming internal/screenshot/screenshot.go exists and has TakeScreenshot(width, height, drawOp)
-	img := screenshot.TakeScreenshot(400, 800, callOp)
-
-	// 6. Save to Artifacts
-	artifactPath := "/home/jaco/.gemini/antigravity/brain/fa658ae2-03ba-48b4-af8d-2fa37f49a5fb/bottomsheet_demo.png"
-	f, err := os.Create(artifactPath)
-	if err != nil {
-		t.Fatalf("Failed to create screenshot file: %v", err)
-	}
-	defer f.Close()
-
-	if err := png.Encode(f, img); err != nil {
-		t.Fatalf("Failed to encode screenshot: %v", err)
-	}
-
-	t.Logf("Screenshot saved to %s", artifactPath)
+	rule := composetest.NewComposeTestRule(t, composetest.WithSize(400, 800))
+	rule.SetContent(UI)
+	golden.Assert(t, "bottomsheet", rule.CaptureToImage())
 }
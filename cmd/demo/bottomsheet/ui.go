@@ -12,7 +12,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// State for managing sheet visibility
 	showSheet := c.State("showSheet", func() any { return false })
 	isOpen := showSheet.Get().(bool)
@@ -63,5 +63,5 @@ func UI(c api.Composer) api.LayoutNode {
 		bottomsheet.WithOnDismissRequest(func() {
 			showSheet.Set(false)
 		}),
-	)(c).Build()
+	)(c)
 }
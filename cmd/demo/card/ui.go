@@ -28,7 +28,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// State for interactive card demo
 	inputValue := c.State("card_input", func() any { return "" })
 
@@ -274,7 +274,7 @@ func UI(c api.Composer) api.LayoutNode {
 		lazy.WithModifier(padding.All(24).Then(size.FillMax())),
 	)(c)
 
-	return c.Build()
+	return c
 }
 
 // SectionTitle creates a section heading
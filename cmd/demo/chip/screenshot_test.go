@@ -1,63 +1,14 @@
 package main
 
 import (
-	"image"
-	"image/png"
-	"os"
 	"testing"
-	"time"
 
-	"github.com/zodimo/go-compose/compose"
-	"github.com/zodimo/go-compose/internal/screenshot"
-	"github.com/zodimo/go-compose/runtime"
-	"github.com/zodimo/go-compose/state"
-	"github.com/zodimo/go-compose/store"
-	"github.com/zodimo/go-compose/theme"
-
-	"gioui.org/io/system"
-	"gioui.org/layout"
-	"gioui.org/op"
-	"gioui.org/unit"
+	"github.com/zodimo/go-compose/composetest"
+	"github.com/zodimo/go-compose/screenshot/golden"
 )
 
 func TestScreenshot(t *testing.T) {
-	// Setup
-	var ops op.Ops
-	gtx := layout.Context{
-		Ops: &ops,
-		Metric: unit.Metric{
-			PxPerDp: 1.0,
-			PxPerSp: 1.0,
-		},
-		Constraints: layout.Exact(image.Point{X: 600, Y: 800}),
-		Now:         time.Now(),
-		Locale:      system.Locale{Language: "en", Direction: system.LTR},
-	}
-
-	themeManager := theme.GetThemeManager()
-	gtx = themeManager.Material3ThemeInit(gtx)
-
-	store := store.NewPersistentState(map[string]state.MutableValue{})
-	rt := runtime.NewRuntime()
-
-	composer := compose.NewComposer(store)
-	rootComposer := UI()(composer)
-	layoutNode := rootComposer.Build()
-
-	callOp := rt.Run(gtx, layoutNode)
-
-	img := screenshot.TakeScreenshot(600, 800, callOp)
-
-	artifactPath := "/home/jaco/.gemini/antigravity/brain/3a943c11-ca09-4fa3-86ab-f678a10f26cc/chip_screenshot.png"
-	f, err := os.Create(artifactPath)
-	if err != nil {
-		t.Fatalf("Failed to create screenshot file: %v", err)
-	}
-	defer f.Close()
-
-	if err := png.Encode(f, img); err != nil {
-		t.Fatalf("Failed to encode screenshot: %v", err)
-	}
-
-	t.Logf("Screenshot saved to %s", artifactPath)
+	rule := composetest.NewComposeTestRule(t, composetest.WithSize(600, 800))
+	rule.SetContent(UI())
+	golden.Assert(t, "chip", rule.CaptureToImage())
 }
@@ -14,7 +14,7 @@ import (
 // Define a CompositionLocal
 var LocalString = compose.CompositionLocalOf(func() string { return "Default (Root)" })
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// Top Level
 	root := column.Column(
 		func(c api.Composer) api.Composer {
@@ -58,5 +58,5 @@ func UI(c api.Composer) api.LayoutNode {
 		},
 	)
 
-	return root(c).Build()
+	return root(c)
 }
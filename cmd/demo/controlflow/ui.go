@@ -15,7 +15,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// State for toggle
 	showDetails := c.State("show_details", func() any { return false })
 
@@ -75,5 +75,5 @@ func UI(c api.Composer) api.LayoutNode {
 		column.WithModifier(size.FillMax().Then(padding.All(24))),
 	)(c)
 
-	return c.Build()
+	return c
 }
@@ -3,16 +3,12 @@ package main
 import (
 	"context"
 	"fmt"
-	"log"
-	"os"
 	"time"
 
-	"gioui.org/app"
-	"gioui.org/op"
 	"gioui.org/unit"
 
-	"github.com/zodimo/go-compose/compose"
 	"github.com/zodimo/go-compose/compose/effect"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
 	"github.com/zodimo/go-compose/compose/foundation/text"
@@ -20,54 +16,15 @@ import (
 	"github.com/zodimo/go-compose/modifiers/padding"
 	"github.com/zodimo/go-compose/pkg/api"
 	"github.com/zodimo/go-compose/runtime"
-	"github.com/zodimo/go-compose/state"
-	"github.com/zodimo/go-compose/store"
-	"github.com/zodimo/go-compose/theme"
 )
 
 func main() {
-	go func() {
-		w := new(app.Window)
-		w.Option(
-			app.Title("LaunchedEffect Demo"),
-			app.Size(unit.Dp(800), unit.Dp(600)),
-		)
-		if err := Run(w); err != nil {
-			log.Fatal(err)
-		}
-		os.Exit(0)
-	}()
-	app.Main()
+	runtime.NewApplication().
+		Window(UI, runtime.WithTitle("LaunchedEffect Demo"), runtime.WithSize(unit.Dp(800), unit.Dp(600))).
+		Run()
 }
 
-func Run(window *app.Window) error {
-	var ops op.Ops
-	store := store.NewPersistentState(map[string]state.MutableValue{})
-	rt := runtime.NewRuntime()
-	themeManager := theme.GetThemeManager()
-
-	for {
-		switch frameEvent := window.Event().(type) {
-		case app.DestroyEvent:
-			return frameEvent.Err
-		case app.FrameEvent:
-			gtx := app.NewContext(&ops, frameEvent)
-
-			// Initialize Theme (M3)
-			gtx = themeManager.Material3ThemeInit(gtx)
-
-			composer := compose.NewComposer(store)
-			layoutNode := UI(composer)
-
-			callOp := rt.Run(gtx, layoutNode)
-			callOp.Add(gtx.Ops)
-			frameEvent.Frame(gtx.Ops)
-			window.Invalidate()
-		}
-	}
-}
-
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	counter := c.State("counter", func() any { return 0 })
 	effectStatus := c.State("effect_status", func() any { return "Waiting..." })
 
@@ -81,23 +38,13 @@ func UI(c api.Composer) api.LayoutNode {
 				effectStatus.Set(fmt.Sprintf("Effect STARTED for %d", currentCount))
 				fmt.Printf("Effect STARTED for %d\n", currentCount)
 
-				select {
-				case <-time.After(2 * time.Second):
-					if ctx.Err() == nil {
-						effectStatus.Set(fmt.Sprintf("Effect FINISHED for %d", currentCount))
-						fmt.Printf("Effect FINISHED for %d\n", currentCount)
-					}
-				case <-ctx.Done():
-					// This might effectively be overwritten by the next effect starting immediately
-					// but we should see it in logs at least.
-					// Note: If we set state here, it might be racey with the new effect setting "STARTED".
-					// But cancel() is called synchronously before next effect starts?
-					// No, cancel() is sync, but the goroutine might take a microsecond to wake up and print.
-					// The NEXT effect starts immediately after cancel().
-					// So "STARTED for N+1" might overwrite "CANCELLED for N".
-					// We'll log to console to verify cancellation.
+				// Delay waits on the frame clock, and is cancelled with ctx.
+				if err := frameclock.Delay(ctx, 2*time.Second); err != nil {
 					fmt.Printf("Effect CANCELLED for %d\n", currentCount)
+					return
 				}
+				effectStatus.Set(fmt.Sprintf("Effect FINISHED for %d", currentCount))
+				fmt.Printf("Effect FINISHED for %d\n", currentCount)
 			}, counter.Get()),
 
 			column.Column(
@@ -114,5 +61,5 @@ func UI(c api.Composer) api.LayoutNode {
 		),
 	)(c)
 
-	return c.Build()
+	return c
 }
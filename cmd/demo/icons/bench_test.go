@@ -22,7 +22,7 @@ func BenchmarkUI_Composition(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		UI(composer)
+		UI(composer).Build()
 	}
 }
 
@@ -34,7 +34,7 @@ func BenchmarkUI_Layout(b *testing.B) {
 	themeManager := theme.GetThemeManager()
 
 	// Initial composition to get the tree
-	layoutNode := UI(composer)
+	layoutNode := UI(composer).Build()
 
 	// Prepare context
 	var ops op.Ops
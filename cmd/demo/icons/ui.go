@@ -14,7 +14,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	var rows []api.Composable
 	chunkSize := 40
 
@@ -77,5 +77,5 @@ func UI(c api.Composer) api.LayoutNode {
 		c.Sequence(rows...),
 	)(c)
 
-	return c.Build()
+	return c
 }
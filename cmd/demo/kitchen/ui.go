@@ -27,7 +27,7 @@ const (
 	CategoryTypography = 4
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// Navigation state
 	selectedCategory := c.State("nav_category", func() any { return CategoryActions })
 	currentCategory := selectedCategory.Get().(int)
@@ -123,7 +123,7 @@ func UI(c api.Composer) api.LayoutNode {
 		},
 	)(c)
 
-	return c.Build()
+	return c
 }
 
 // SectionTitle is a helper for section headers
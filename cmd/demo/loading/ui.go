@@ -10,7 +10,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	return scaffold.Scaffold(
 		func(c api.Composer) api.Composer {
 			return column.Column(
@@ -29,5 +29,5 @@ func UI(c api.Composer) api.LayoutNode {
 				text.TextWithStyle("Loading Demo", text.TypestyleTitleMedium),
 			),
 		),
-	)(c).Build()
+	)(c)
 }
@@ -1,16 +1,13 @@
 package main
 
 import (
-	"log"
+	"fmt"
 	"os"
+	"path/filepath"
 
-	"gioui.org/app"
-	"gioui.org/io/system"
 	"gioui.org/layout"
-	"gioui.org/op"
 	"gioui.org/unit"
 
-	"github.com/zodimo/go-compose/compose"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
 	"github.com/zodimo/go-compose/compose/material3/button"
 	"github.com/zodimo/go-compose/compose/material3/scaffold"
@@ -18,55 +15,17 @@ import (
 	"github.com/zodimo/go-compose/compose/navigation"
 	"github.com/zodimo/go-compose/pkg/api"
 	"github.com/zodimo/go-compose/runtime"
-	"github.com/zodimo/go-compose/state"
-	"github.com/zodimo/go-compose/store"
-	"github.com/zodimo/go-compose/theme"
 )
 
 func main() {
-	go func() {
-		w := new(app.Window)
-		w.Option(app.Title("Navigation Demo"))
-		w.Option(app.Size(unit.Dp(800), unit.Dp(600)))
-
-		if err := Run(w); err != nil {
-			log.Fatal(err)
-		}
-		os.Exit(0)
-	}()
-
-	app.Main()
-}
-
-func Run(window *app.Window) error {
-	enLocale := system.Locale{Language: "en", Direction: system.LTR}
-	var ops op.Ops
-
-	store := store.NewPersistentState(map[string]state.MutableValue{})
-	store.SetOnStateChange(func() {
-		window.Invalidate()
-	})
-	runtime := runtime.NewRuntime()
-	themeManager := theme.GetThemeManager()
-
-	for {
-		switch frameEvent := window.Event().(type) {
-		case app.DestroyEvent:
-			return frameEvent.Err
-		case app.FrameEvent:
-			gtx := app.NewContext(&ops, frameEvent)
-			gtx.Locale = enLocale
-			gtx = themeManager.Material3ThemeInit(gtx)
-
-			composer := compose.NewComposer(store)
-			composer = DemoUI()(composer)
-			layoutNode := composer.Build()
-
-			callOp := runtime.Run(gtx, layoutNode)
-			callOp.Add(gtx.Ops)
-			frameEvent.Frame(gtx.Ops)
-		}
-	}
+	runtime.NewApplication().
+		Window(DemoUI(),
+			runtime.WithTitle("Navigation Demo"),
+			runtime.WithSize(unit.Dp(800), unit.Dp(600)),
+			// Restore the back stack of the previous run.
+			runtime.WithSavedStatePath(filepath.Join(os.TempDir(), "go-compose-navigation-demo.json")),
+		).
+		Run()
 }
 
 func DemoUI() api.Composable {
@@ -78,7 +37,10 @@ func DemoUI() api.Composable {
 				// Content
 				return navigation.NavHost(navController, "home", func(b *navigation.NavGraphBuilder) {
 					b.Composable("home", HomeScreen(navController))
-					b.Composable("details", DetailsScreen(navController))
+					b.ComposableWithEntry("details/{id}", func(entry navigation.BackStackEntry) api.Composable {
+						id, _ := entry.Arguments.Int("id")
+						return DetailsScreen(navController, id)
+					}, navigation.WithArguments(navigation.NavArgument{Name: "id", Type: navigation.NavTypeInt}))
 				})(c)
 			},
 		)(c)
@@ -92,7 +54,7 @@ func HomeScreen(navController *navigation.NavController) api.Composable {
 				text.TextWithStyle("Home Screen", text.TypestyleBodyLarge),
 				button.Filled(
 					func() {
-						navController.Navigate("details")
+						navController.Navigate("details/42")
 					},
 					"Go to Details",
 				),
@@ -103,11 +65,11 @@ func HomeScreen(navController *navigation.NavController) api.Composable {
 	}
 }
 
-func DetailsScreen(navController *navigation.NavController) api.Composable {
+func DetailsScreen(navController *navigation.NavController, id int) api.Composable {
 	return func(c api.Composer) api.Composer {
 		return column.Column(
 			c.Sequence(
-				text.TextWithStyle("Details Screen", text.TypestyleBodyLarge),
+				text.TextWithStyle(fmt.Sprintf("Details Screen %d", id), text.TypestyleBodyLarge),
 				button.Filled(
 					func() {
 						navController.PopBackStack()
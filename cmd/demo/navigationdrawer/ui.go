@@ -20,21 +20,24 @@ import (
 func UI() api.Composable {
 	return func(c api.Composer) api.Composer {
 		// State for drawer type selection
-		drawerType := c.State("drawerType", func() any { return "Modal" }).Get().(string)
+		drawerTypeValue := c.State("drawerType", func() any { return "Modal" })
+		drawerType := drawerTypeValue.Get().(string)
 		setDrawerType := func(t string) {
-			c.State("drawerType", nil).Set(t)
+			drawerTypeValue.Set(t)
 		}
 
 		// State for drawer open/closed
-		isOpen := c.State("drawerOpen", func() any { return false }).Get().(bool)
+		isOpenValue := c.State("drawerOpen", func() any { return false })
+		isOpen := isOpenValue.Get().(bool)
 		setIsOpen := func(o bool) {
-			c.State("drawerOpen", nil).Set(o)
+			isOpenValue.Set(o)
 		}
 
 		// Selected item state
-		selectedItem := c.State("selectedItem", func() any { return "Inbox" }).Get().(string)
+		selectedItemValue := c.State("selectedItem", func() any { return "Inbox" })
+		selectedItem := selectedItemValue.Get().(string)
 		setSelectedItem := func(i string) {
-			c.State("selectedItem", nil).Set(i)
+			selectedItemValue.Set(i)
 		}
 
 		// Drawer Content
@@ -35,14 +35,8 @@ func UI() api.Composable {
 	return func(c api.Composer) api.Composer {
 
 		// State
-		key := c.GenerateID()
-		path := c.GetPath()
-		// Manual unique key usage
-		selKey := key.String() + "/" + path.String() + "/selected"
-		drawerKey := key.String() + "/" + path.String() + "/drawer"
-
-		selectedValue := c.State(selKey, func() any { return 0 })
-		drawerOpen := c.State(drawerKey, func() any { return false })
+		selectedValue := c.State("selected", func() any { return 0 })
+		drawerOpen := c.State("drawer", func() any { return false })
 
 		selectedIndex := selectedValue.Get().(int)
 		isDrawerOpen := drawerOpen.Get().(bool)
@@ -12,7 +12,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 
 	c = column.Column(
 		c.Sequence(
@@ -37,6 +37,6 @@ func UI(c api.Composer) api.LayoutNode {
 		column.WithAlignment(column.Middle),
 	)(c)
 
-	return c.Build()
+	return c
 
 }
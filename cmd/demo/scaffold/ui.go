@@ -17,7 +17,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 
 	// Create snackbar host state outside the loop to persist state
 	snackbarHostState := c.State("snackbarHostState", func() any { return snackbar.NewSnackbarHostState() }).Get().(*snackbar.SnackbarHostState)
@@ -82,5 +82,5 @@ func UI(c api.Composer) api.LayoutNode {
 			)(c)
 		}),
 		scaffold.WithSnackbarHost(snackbar.SnackbarHost(snackbarHostState)),
-	)(c).Build()
+	)(c)
 }
@@ -10,7 +10,7 @@ import (
 	"golang.org/x/exp/shiny/materialdesign/icons"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	password := c.State("password", func() any { return "" })
 	passwordVal := password.Get().(string)
 
@@ -85,5 +85,5 @@ func UI(c api.Composer) api.LayoutNode {
 		),
 	)
 
-	return root(c).Build()
+	return root(c)
 }
@@ -21,7 +21,7 @@ import (
 	"github.com/zodimo/go-compose/compose/ui/unit"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	// State
 	activeState := c.State("elevation_active", func() any {
 		return false
@@ -71,7 +71,7 @@ func UI(c api.Composer) api.LayoutNode {
 		column.WithModifier(size.FillMax().Then(padding.All(8))),
 	)(c)
 
-	return c.Build()
+	return c
 }
 
 // RecursiveSurface generates nested surfaces with alternating colors and shapes
@@ -2,8 +2,6 @@ package main
 
 import (
 	"fmt"
-	"log"
-	"os"
 
 	"github.com/zodimo/go-compose/compose"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
@@ -13,66 +11,18 @@ import (
 	"github.com/zodimo/go-compose/compose/material3/scaffold"
 	mswitch "github.com/zodimo/go-compose/compose/material3/switch"
 	"github.com/zodimo/go-compose/compose/material3/text"
-	"github.com/zodimo/go-compose/pkg/api"
 	"github.com/zodimo/go-compose/runtime"
-	"github.com/zodimo/go-compose/state"
-	"github.com/zodimo/go-compose/store"
-	"github.com/zodimo/go-compose/theme"
 
-	"gioui.org/app"
-	"gioui.org/op"
 	"gioui.org/unit"
 )
 
 func main() {
-	go func() {
-		w := new(app.Window)
-		w.Option(
-			app.Title("Switch Demo"),
-			app.Size(unit.Dp(400), unit.Dp(700)),
-		)
-
-		if err := Run(w); err != nil {
-			log.Fatal(err)
-		}
-		os.Exit(0)
-	}()
-
-	app.Main()
-}
-
-func Run(window *app.Window) error {
-	var ops op.Ops
-
-	store := store.NewPersistentState(map[string]state.MutableValue{})
-	store.SetOnStateChange(func() {
-		window.Invalidate()
-	})
-	runtime := runtime.NewRuntime()
-
-	themeManager := theme.GetThemeManager()
-
-	for {
-		switch frameEvent := window.Event().(type) {
-		case app.DestroyEvent:
-			return frameEvent.Err
-		case app.FrameEvent:
-			gtx := app.NewContext(&ops, frameEvent)
-
-			// Init Theme
-			gtx = themeManager.Material3ThemeInit(gtx)
-
-			composer := compose.NewComposer(store)
-			layoutNode := UI(composer)
-
-			callOp := runtime.Run(gtx, layoutNode)
-			callOp.Add(gtx.Ops)
-			frameEvent.Frame(gtx.Ops)
-		}
-	}
+	runtime.NewApplication().
+		Window(UI, runtime.WithTitle("Switch Demo"), runtime.WithSize(unit.Dp(400), unit.Dp(700))).
+		Run()
 }
 
-func UI(c compose.Composer) api.LayoutNode {
+func UI(c compose.Composer) compose.Composer {
 	checked1 := c.State("switch_state_1", func() any { return false })
 	checked2 := c.State("switch_state_2", func() any { return false })
 	c = scaffold.Scaffold(
@@ -125,5 +75,5 @@ func UI(c compose.Composer) api.LayoutNode {
 			),
 		),
 	)(c)
-	return c.Build()
+	return c
 }
@@ -8,7 +8,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	root := column.Column(
 		c.Sequence(
 			fText.Text(
@@ -24,5 +24,5 @@ func UI(c api.Composer) api.LayoutNode {
 		),
 	)
 
-	return root(c).Build()
+	return root(c)
 }
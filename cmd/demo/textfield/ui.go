@@ -11,7 +11,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 	filledText := c.State("filled_text", func() any { return "" })
 	outlinedText := c.State("outlined_text", func() any { return "" })
 
@@ -39,5 +39,5 @@ func UI(c api.Composer) api.LayoutNode {
 		),
 	)
 
-	return root(c).Build()
+	return root(c)
 }
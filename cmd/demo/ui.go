@@ -25,7 +25,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 
 	counterCell := c.State("counter", func() any { return 0 })
 
@@ -121,6 +121,6 @@ func UI(c api.Composer) api.LayoutNode {
 		column.WithAlignment(column.Middle),
 	)(c)
 
-	return c.Build()
+	return c
 
 }
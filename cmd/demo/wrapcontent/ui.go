@@ -13,7 +13,7 @@ import (
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
-func UI(c api.Composer) api.LayoutNode {
+func UI(c api.Composer) api.Composer {
 
 	c = column.Column(
 		c.Sequence(
@@ -126,5 +126,5 @@ func UI(c api.Composer) api.LayoutNode {
 		),
 	)(c)
 
-	return c.Build()
+	return c
 }
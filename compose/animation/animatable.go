@@ -0,0 +1,205 @@
+package animation
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/state"
+)
+
+// Animatable is a value animated by the goroutines of effects:
+//
+//	offset := state.RememberUnsafe(c, "offset", func() animation.Animatable[float32] {
+//		return animation.NewAnimatable(float32(0), animation.Float32VectorConverter)
+//	})
+//	effect.LaunchedEffect(func(ctx context.Context) {
+//		offset.AnimateTo(ctx, 100, animation.Spring())
+//	}, target)
+//
+// Its value is state: reading it while composing recomposes as it changes.
+// Starting an animation interrupts the running one and keeps its velocity.
+type Animatable[T any] interface {
+	// Value returns the current value.
+	Value() T
+	// TargetValue returns the target of the running animation, or the value
+	// when none runs.
+	TargetValue() T
+	// Velocity returns the current velocity, in units per second.
+	Velocity() T
+	// IsRunning reports whether an animation runs.
+	IsRunning() bool
+	// AnimateTo animates the value to target with spec, on the frame clock of
+	// ctx, from the current value and velocity. It returns once the value
+	// reaches target, or with context.Canceled when the animation is
+	// interrupted, or the error of ctx when ctx is done.
+	AnimateTo(ctx context.Context, target T, spec AnimationSpec) error
+	// SnapTo sets the value to target, interrupting the running animation.
+	SnapTo(target T)
+	// Stop interrupts the running animation, leaving the value where it is.
+	Stop()
+}
+
+var _ Animatable[float32] = (*animatable[float32])(nil)
+
+type animatable[T any] struct {
+	converter TwoWayConverter[T]
+	value     state.MutableState[T]
+	running   state.MutableState[bool]
+
+	mu       sync.Mutex
+	vector   AnimationVector
+	velocity AnimationVector
+	target   AnimationVector
+	// run identifies the running animation, and cancel interrupts it.
+	run    int
+	cancel context.CancelFunc
+}
+
+// NewAnimatable returns an Animatable of initial, converted to vectors by
+// converter.
+func NewAnimatable[T any](initial T, converter TwoWayConverter[T]) Animatable[T] {
+	vector := converter.ConvertToVector(initial)
+	return &animatable[T]{
+		converter: converter,
+		value:     state.NewMutableState(initial, nil),
+		running:   state.NewMutableState(false, nil),
+		vector:    vector,
+		velocity:  make(AnimationVector, len(vector)),
+		target:    vector,
+	}
+}
+
+func (a *animatable[T]) Value() T {
+	return a.value.Get()
+}
+
+func (a *animatable[T]) TargetValue() T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.converter.ConvertFromVector(a.target)
+}
+
+func (a *animatable[T]) Velocity() T {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.converter.ConvertFromVector(a.velocity)
+}
+
+func (a *animatable[T]) IsRunning() bool {
+	return a.running.Get()
+}
+
+func (a *animatable[T]) AnimateTo(ctx context.Context, target T, spec AnimationSpec) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	a.mu.Lock()
+	a.interrupt()
+	a.run++
+	run := a.run
+	a.cancel = cancel
+	initial, initialVelocity := a.vector, a.velocity
+	a.target = a.converter.ConvertToVector(target)
+	goal := a.target
+	a.mu.Unlock()
+	a.running.Set(true)
+
+	duration := spec.Duration(initial, goal, initialVelocity)
+	withFrame := frameclock.WithFrameNanos
+	if duration == Infinite {
+		withFrame = frameclock.WithInfiniteAnimationFrameNanos
+	}
+	start := int64(-1)
+	for {
+		var playTime time.Duration
+		err := withFrame(ctx, func(frameTimeNanos int64) {
+			if start < 0 {
+				start = frameTimeNanos
+			}
+			playTime = time.Duration(frameTimeNanos - start)
+			if playTime >= duration {
+				a.update(run, goal, make(AnimationVector, len(goal)), true)
+				return
+			}
+			a.update(run,
+				spec.ValueAt(playTime, initial, goal, initialVelocity),
+				spec.VelocityAt(playTime, initial, goal, initialVelocity),
+				false,
+			)
+		})
+		if err != nil {
+			a.end(run)
+			return err
+		}
+		if playTime >= duration {
+			return nil
+		}
+	}
+}
+
+// update sets the value and velocity of the animation run, unless another
+// animation interrupted it.
+func (a *animatable[T]) update(run int, vector, velocity AnimationVector, done bool) {
+	a.mu.Lock()
+	if run != a.run {
+		a.mu.Unlock()
+		return
+	}
+	a.vector, a.velocity = vector, velocity
+	if done {
+		a.cancel = nil
+	}
+	a.mu.Unlock()
+
+	a.value.Set(a.converter.ConvertFromVector(vector))
+	if done {
+		a.running.Set(false)
+	}
+}
+
+// end marks the animation run as no longer running, unless another animation
+// interrupted it.
+func (a *animatable[T]) end(run int) {
+	a.mu.Lock()
+	current := run == a.run
+	if current {
+		a.cancel = nil
+	}
+	a.mu.Unlock()
+	if current {
+		a.running.Set(false)
+	}
+}
+
+func (a *animatable[T]) SnapTo(target T) {
+	vector := a.converter.ConvertToVector(target)
+	a.mu.Lock()
+	a.interrupt()
+	a.run++
+	a.vector, a.target = vector, vector
+	a.velocity = make(AnimationVector, len(vector))
+	a.mu.Unlock()
+
+	a.value.Set(target)
+	a.running.Set(false)
+}
+
+func (a *animatable[T]) Stop() {
+	a.mu.Lock()
+	a.interrupt()
+	a.run++
+	a.target = a.vector
+	a.velocity = make(AnimationVector, len(a.vector))
+	a.mu.Unlock()
+	a.running.Set(false)
+}
+
+// interrupt cancels the running animation. The lock must be held.
+func (a *animatable[T]) interrupt() {
+	if a.cancel != nil {
+		a.cancel()
+		a.cancel = nil
+	}
+}
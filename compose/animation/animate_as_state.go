@@ -0,0 +1,143 @@
+package animation
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/compose/ui/geometry"
+	"github.com/zodimo/go-compose/compose/ui/graphics"
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+)
+
+// AnimateAsStateOption is a functional option for configuring
+// AnimateValueAsState and its siblings.
+type AnimateAsStateOption func(*AnimateAsStateOptions)
+
+type AnimateAsStateOptions struct {
+	// AnimationSpec animates the value to each new target. It is a Spring by
+	// default.
+	AnimationSpec AnimationSpec
+	// FinishedListener is called once the value reaches its target, unless
+	// the target changed first.
+	FinishedListener func()
+}
+
+func DefaultAnimateAsStateOptions() AnimateAsStateOptions {
+	return AnimateAsStateOptions{
+		AnimationSpec: Spring(),
+	}
+}
+
+func WithAnimationSpec(spec AnimationSpec) AnimateAsStateOption {
+	return func(o *AnimateAsStateOptions) {
+		o.AnimationSpec = spec
+	}
+}
+
+func WithFinishedListener(listener func()) AnimateAsStateOption {
+	return func(o *AnimateAsStateOptions) {
+		o.FinishedListener = listener
+	}
+}
+
+// AnimateValueAsState returns a state holding target, animated to each new
+// target composed. The first target is held as is; when a later composition
+// changes it, the value animates from where it is, keeping its velocity.
+// The animation runs on the frame clock of the composition, and stops when the
+// caller leaves the composition.
+func AnimateValueAsState[T any](c api.Composer, target T, converter TwoWayConverter[T], options ...AnimateAsStateOption) state.TypedValue[T] {
+	opts := DefaultAnimateAsStateOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+
+	animated := state.RememberUnsafe(c, "animate_as_state", func() *animateAsState[T] {
+		return newAnimateAsState(target, converter)
+	})
+	clock := frameclock.LocalFrameClock.Current(c)
+	c.SideEffect(func() {
+		animated.animateTo(clock, target, opts)
+	})
+	return animated
+}
+
+// AnimateFloatAsState animates a float as AnimateValueAsState.
+func AnimateFloatAsState(c api.Composer, target float32, options ...AnimateAsStateOption) state.TypedValue[float32] {
+	return AnimateValueAsState(c, target, Float32VectorConverter, options...)
+}
+
+// AnimateDpAsState animates a Dp as AnimateValueAsState.
+func AnimateDpAsState(c api.Composer, target unit.Dp, options ...AnimateAsStateOption) state.TypedValue[unit.Dp] {
+	return AnimateValueAsState(c, target, DpVectorConverter, options...)
+}
+
+// AnimateColorAsState animates a color as AnimateValueAsState, through the
+// Oklab color space so that the colors in between keep their lightness.
+func AnimateColorAsState(c api.Composer, target graphics.Color, options ...AnimateAsStateOption) state.TypedValue[graphics.Color] {
+	return AnimateValueAsState(c, target, ColorVectorConverter, options...)
+}
+
+// AnimateOffsetAsState animates an offset as AnimateValueAsState.
+func AnimateOffsetAsState(c api.Composer, target geometry.Offset, options ...AnimateAsStateOption) state.TypedValue[geometry.Offset] {
+	return AnimateValueAsState(c, target, OffsetVectorConverter, options...)
+}
+
+// AnimateSizeAsState animates a size as AnimateValueAsState.
+func AnimateSizeAsState(c api.Composer, target geometry.Size, options ...AnimateAsStateOption) state.TypedValue[geometry.Size] {
+	return AnimateValueAsState(c, target, SizeVectorConverter, options...)
+}
+
+var _ state.TypedValue[float32] = (*animateAsState[float32])(nil)
+var _ state.RememberObserver = (*animateAsState[float32])(nil)
+
+type animateAsState[T any] struct {
+	animatable Animatable[T]
+	ctx        context.Context
+	cancel     context.CancelFunc
+
+	mu     sync.Mutex
+	target T
+}
+
+func newAnimateAsState[T any](initial T, converter TwoWayConverter[T]) *animateAsState[T] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &animateAsState[T]{
+		animatable: NewAnimatable(initial, converter),
+		ctx:        ctx,
+		cancel:     cancel,
+		target:     initial,
+	}
+}
+
+func (s *animateAsState[T]) Get() T {
+	return s.animatable.Value()
+}
+
+// animateTo starts animating to target, unless it is the target already.
+func (s *animateAsState[T]) animateTo(clock frameclock.MonotonicFrameClock, target T, opts AnimateAsStateOptions) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if reflect.DeepEqual(s.target, target) || s.ctx.Err() != nil {
+		return
+	}
+	s.target = target
+	frameclock.Go(s.ctx, clock, func(ctx context.Context) {
+		err := s.animatable.AnimateTo(ctx, target, opts.AnimationSpec)
+		if err == nil && opts.FinishedListener != nil {
+			opts.FinishedListener()
+		}
+	})
+}
+
+func (s *animateAsState[T]) OnRemembered() {}
+
+func (s *animateAsState[T]) OnForgotten() {
+	s.cancel()
+}
@@ -0,0 +1,116 @@
+package animation_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/animation"
+	"github.com/zodimo/go-compose/compose/effect"
+	"github.com/zodimo/go-compose/compose/foundation/layout/column"
+	"github.com/zodimo/go-compose/compose/foundation/text"
+	"github.com/zodimo/go-compose/composetest"
+	"github.com/zodimo/go-compose/modifiers/semantics"
+	"github.com/zodimo/go-compose/state"
+)
+
+func animatedFloat(target state.MutableState[float32], finished *int) compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		value := animation.AnimateFloatAsState(c, target.Get(),
+			animation.WithAnimationSpec(animation.Tween(100*time.Millisecond, animation.WithEasing(animation.LinearEasing))),
+			animation.WithFinishedListener(func() { *finished++ }),
+		)
+		return text.Text(fmt.Sprintf("%.0f", value.Get()), text.WithModifier(semantics.TestTag("value")))(c)
+	}
+}
+
+func TestAnimateFloatAsState(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	clock := rule.MainClock()
+	target := state.NewMutableState(float32(0), nil)
+	var finished int
+	rule.SetContent(animatedFloat(target, &finished))
+
+	rule.OnNodeWithTag("value").AssertTextEquals("0")
+
+	clock.SetAutoAdvance(false)
+	target.Set(100)
+	rule.WaitForIdle()
+	// The animation starts on the first frame after the target changed.
+	clock.AdvanceTimeByFrame()
+	clock.AdvanceTimeBy(50 * time.Millisecond)
+	rule.OnNodeWithTag("value").AssertTextEquals("50")
+
+	clock.SetAutoAdvance(true)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("value").AssertTextEquals("100")
+	if finished != 1 {
+		t.Errorf("finished %d times, want once", finished)
+	}
+}
+
+func TestAnimatable(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	var running []bool
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		offset := state.RememberUnsafe(c, "offset", func() animation.Animatable[float32] {
+			return animation.NewAnimatable(float32(0), animation.Float32VectorConverter)
+		})
+		running = append(running, offset.IsRunning())
+		return column.Column(c.Sequence(
+			effect.LaunchedEffect(func(ctx context.Context) {
+				offset.AnimateTo(ctx, 10, animation.Spring(animation.WithDampingRatio(animation.DampingRatioMediumBouncy)))
+			}),
+			text.Text(fmt.Sprintf("%.2f", offset.Value()), text.WithModifier(semantics.TestTag("offset"))),
+		))(c)
+	})
+
+	rule.OnNodeWithTag("offset").AssertTextEquals("10.00")
+	if len(running) < 3 || !running[1] || running[len(running)-1] {
+		t.Errorf("running = %v, want running then stopped", running)
+	}
+}
+
+func TestAnimatableInterrupt(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	anim := animation.NewAnimatable(float32(0), animation.Float32VectorConverter)
+	scope := make(chan effect.EffectScope, 1)
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		s := effect.RememberEffectScope(c)
+		select {
+		case scope <- s:
+		default:
+		}
+		return text.Text("")(c)
+	})
+
+	s := <-scope
+	rule.MainClock().SetAutoAdvance(false)
+	first := make(chan error, 1)
+	s.Launch(func(ctx context.Context) {
+		first <- anim.AnimateTo(ctx, 100, animation.Tween(time.Second))
+	})
+	rule.WaitForIdle()
+	rule.MainClock().AdvanceTimeBy(500 * time.Millisecond)
+
+	s.Launch(func(ctx context.Context) {
+		anim.AnimateTo(ctx, 0, animation.Tween(time.Second))
+	})
+	rule.WaitForIdle()
+	if err := <-first; err != context.Canceled {
+		t.Errorf("interrupted animation returned %v, want context.Canceled", err)
+	}
+	if v := anim.Velocity(); v <= 0 {
+		t.Errorf("velocity = %v, want the velocity of the interrupted animation", v)
+	}
+	if got := anim.TargetValue(); got != 0 {
+		t.Errorf("target = %v, want 0", got)
+	}
+
+	anim.SnapTo(42)
+	if anim.Value() != 42 || anim.IsRunning() {
+		t.Errorf("after SnapTo, value = %v and running = %v", anim.Value(), anim.IsRunning())
+	}
+}
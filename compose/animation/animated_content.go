@@ -0,0 +1,230 @@
+package animation
+
+import (
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/internal/modifier"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+)
+
+// AnimatedContentOption is a functional option for configuring
+// AnimatedContent.
+type AnimatedContentOption[T any] func(*AnimatedContentOptions[T])
+
+type AnimatedContentOptions[T any] struct {
+	Modifier modifier.Modifier
+	// TransitionSpec returns how the content of target replaces the content of
+	// initial. By default the new content fades in and scales up slightly once
+	// the old one faded out.
+	TransitionSpec func(initial, target T) ContentTransform
+}
+
+func DefaultAnimatedContentOptions[T any]() AnimatedContentOptions[T] {
+	return AnimatedContentOptions[T]{
+		Modifier: modifier.EmptyModifier,
+		TransitionSpec: func(T, T) ContentTransform {
+			return defaultContentTransform
+		},
+	}
+}
+
+// WithContentModifier sets the modifier of AnimatedContent. The type of the
+// content cannot be inferred, and is given as in
+// animation.WithContentModifier[int](m).
+func WithContentModifier[T any](m modifier.Modifier) AnimatedContentOption[T] {
+	return func(o *AnimatedContentOptions[T]) {
+		o.Modifier = m
+	}
+}
+
+func WithContentTransitionSpec[T any](spec func(initial, target T) ContentTransform) AnimatedContentOption[T] {
+	return func(o *AnimatedContentOptions[T]) {
+		o.TransitionSpec = spec
+	}
+}
+
+var defaultContentTransform = FadeIn(
+	WithTransitionSpec(Tween(220*time.Millisecond, WithDelay(90*time.Millisecond))),
+).Plus(ScaleIn(
+	WithScale(0.92),
+	WithTransitionSpec(Tween(220*time.Millisecond, WithDelay(90*time.Millisecond))),
+)).TogetherWith(FadeOut(
+	WithTransitionSpec(Tween(90 * time.Millisecond)),
+))
+
+// AnimatedContent composes the content of targetState and, when a later
+// composition changes targetState, replaces it with the content of the new
+// state through the ContentTransform returned by the TransitionSpec option.
+// The contents are stacked, the newest on top, and the old one stays composed
+// until it finished exiting. States are compared with reflect.DeepEqual:
+//
+//	animation.AnimatedContent(count, func(count int) api.Composable {
+//		return text.Text(fmt.Sprint(count))
+//	})
+func AnimatedContent[T any](targetState T, content func(T) api.Composable, options ...AnimatedContentOption[T]) api.Composable {
+	opts := DefaultAnimatedContentOptions[T]()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return func(c api.Composer) api.Composer {
+		animated := state.RememberUnsafe(c, "animated_content", func() *animatedContent[T] {
+			return newAnimatedContent(targetState)
+		})
+		entries := animated.update(targetState, opts.TransitionSpec)
+
+		children := make([]api.Composable, 0, len(entries))
+		for _, entry := range entries {
+			children = append(children, c.Key(entry.id, animatedVisibility(
+				entry.transition,
+				entry.visible,
+				AnimatedVisibilityOptions{Modifier: modifier.EmptyModifier, Enter: entry.enter, Exit: entry.exit},
+				content(entry.state),
+			)))
+		}
+		return box.Box(c.Sequence(children...), box.WithModifier(opts.Modifier))(c)
+	}
+}
+
+// CrossfadeOption is a functional option for configuring Crossfade.
+type CrossfadeOption func(*CrossfadeOptions)
+
+type CrossfadeOptions struct {
+	Modifier modifier.Modifier
+	// AnimationSpec animates both contents. It is a Tween of DefaultDuration by
+	// default.
+	AnimationSpec AnimationSpec
+}
+
+func DefaultCrossfadeOptions() CrossfadeOptions {
+	return CrossfadeOptions{
+		Modifier:      modifier.EmptyModifier,
+		AnimationSpec: Tween(DefaultDuration),
+	}
+}
+
+func WithCrossfadeModifier(m modifier.Modifier) CrossfadeOption {
+	return func(o *CrossfadeOptions) {
+		o.Modifier = m
+	}
+}
+
+func WithCrossfadeSpec(spec AnimationSpec) CrossfadeOption {
+	return func(o *CrossfadeOptions) {
+		o.AnimationSpec = spec
+	}
+}
+
+// Crossfade replaces the content of targetState with the content of each new
+// state by fading the new content in as the old one fades out, as
+// AnimatedContent.
+func Crossfade[T any](targetState T, content func(T) api.Composable, options ...CrossfadeOption) api.Composable {
+	opts := DefaultCrossfadeOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	transform := FadeIn(WithTransitionSpec(opts.AnimationSpec)).TogetherWith(FadeOut(WithTransitionSpec(opts.AnimationSpec)))
+	return AnimatedContent(targetState, content,
+		WithContentModifier[T](opts.Modifier),
+		WithContentTransitionSpec(func(T, T) ContentTransform { return transform }),
+	)
+}
+
+var _ state.RememberObserver = (*animatedContent[any])(nil)
+
+// animatedContent holds the contents of AnimatedContent: the one of the
+// target state, and the ones still exiting.
+type animatedContent[T any] struct {
+	mu      sync.Mutex
+	entries []*contentEntry[T]
+	nextID  int
+}
+
+type contentEntry[T any] struct {
+	id         int
+	state      T
+	visible    bool
+	enter      EnterTransition
+	exit       ExitTransition
+	transition *visibilityTransition
+}
+
+func newAnimatedContent[T any](initial T) *animatedContent[T] {
+	return &animatedContent[T]{
+		entries: []*contentEntry[T]{{
+			state:      initial,
+			visible:    true,
+			transition: newVisibilityTransition(true),
+		}},
+		nextID: 1,
+	}
+}
+
+// update drops the contents that finished exiting and, when target changed,
+// makes its content enter as the others exit. It returns the contents to
+// compose.
+func (a *animatedContent[T]) update(target T, transitionSpec func(initial, target T) ContentTransform) []*contentEntry[T] {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	entries := a.entries[:0]
+	for _, entry := range a.entries {
+		if !entry.visible && !entry.transition.shown.Get() {
+			entry.transition.stop()
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	a.entries = entries
+
+	current := a.entries[len(a.entries)-1]
+	if reflect.DeepEqual(current.state, target) {
+		return a.entries
+	}
+
+	transform := transitionSpec(current.state, target)
+	var next *contentEntry[T]
+	exiting := make([]*contentEntry[T], 0, len(a.entries))
+	for _, entry := range a.entries {
+		if next == nil && reflect.DeepEqual(entry.state, target) {
+			// The content of target has not finished exiting: it enters again
+			// from where it is, on top.
+			next = entry
+			continue
+		}
+		entry.visible = false
+		entry.exit = transform.Exit
+		exiting = append(exiting, entry)
+	}
+	if next == nil {
+		next = &contentEntry[T]{
+			id:         a.nextID,
+			state:      target,
+			transition: newVisibilityTransition(false),
+		}
+		a.nextID++
+	}
+	next.visible = true
+	next.enter = transform.Enter
+	a.entries = append(exiting, next)
+	return a.entries
+}
+
+func (a *animatedContent[T]) OnRemembered() {}
+
+func (a *animatedContent[T]) OnForgotten() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, entry := range a.entries {
+		entry.transition.stop()
+	}
+}
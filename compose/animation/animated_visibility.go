@@ -0,0 +1,264 @@
+package animation
+
+import (
+	"context"
+	"image"
+	"sync"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+// AnimatedVisibilityOption is a functional option for configuring
+// AnimatedVisibility.
+type AnimatedVisibilityOption func(*AnimatedVisibilityOptions)
+
+type AnimatedVisibilityOptions struct {
+	Modifier modifier.Modifier
+	// Enter is how the content appears. It fades in and expands by default.
+	Enter EnterTransition
+	// Exit is how the content disappears. It shrinks and fades out by default.
+	Exit ExitTransition
+}
+
+func DefaultAnimatedVisibilityOptions() AnimatedVisibilityOptions {
+	return AnimatedVisibilityOptions{
+		Modifier: modifier.EmptyModifier,
+		Enter:    FadeIn().Plus(ExpandIn()),
+		Exit:     ShrinkOut().Plus(FadeOut()),
+	}
+}
+
+func WithModifier(m modifier.Modifier) AnimatedVisibilityOption {
+	return func(o *AnimatedVisibilityOptions) {
+		o.Modifier = m
+	}
+}
+
+func WithEnter(enter EnterTransition) AnimatedVisibilityOption {
+	return func(o *AnimatedVisibilityOptions) {
+		o.Enter = enter
+	}
+}
+
+func WithExit(exit ExitTransition) AnimatedVisibilityOption {
+	return func(o *AnimatedVisibilityOptions) {
+		o.Exit = exit
+	}
+}
+
+// AnimatedVisibility shows content with the enter transition when visible
+// becomes true, and hides it with the exit transition when it becomes false.
+// Content that is visible when AnimatedVisibility enters the composition is
+// shown at once.
+//
+// Content stays composed, keeping its state and effects, until its exit
+// transition finishes; it leaves the composition then.
+func AnimatedVisibility(visible bool, content api.Composable, options ...AnimatedVisibilityOption) api.Composable {
+	opts := DefaultAnimatedVisibilityOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return func(c api.Composer) api.Composer {
+		transition := state.RememberUnsafe(c, "animated_visibility", func() *visibilityTransition {
+			return newVisibilityTransition(visible)
+		})
+		return animatedVisibility(transition, visible, opts, content)(c)
+	}
+}
+
+// animatedVisibility composes content while transition shows it, animating
+// it to visible.
+func animatedVisibility(transition *visibilityTransition, visible bool, opts AnimatedVisibilityOptions, content api.Composable) api.Composable {
+	return func(c api.Composer) api.Composer {
+		c.StartBlock("AnimatedVisibility")
+		c.Modifier(func(modifier modifier.Modifier) modifier.Modifier {
+			return modifier.Then(opts.Modifier)
+		})
+
+		transition.update(visible, opts.Enter.data, opts.Exit.data)
+		clock := frameclock.LocalFrameClock.Current(c)
+		c.SideEffect(func() {
+			transition.animate(clock)
+		})
+
+		c.WithComposable(c.When(visible || transition.shown.Get(), content))
+		c.SetWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+			return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+				return transition.layout(gtx, node)
+			}
+		}))
+		return c.EndBlock()
+	}
+}
+
+var _ state.RememberObserver = (*visibilityTransition)(nil)
+
+// visibilityTransition animates content between hidden and visible. Its
+// progress holds, for each property a transition may animate, 0 when the
+// content is hidden and 1 when it is visible.
+type visibilityTransition struct {
+	progress Animatable[AnimationVector]
+	// shown is whether the content is composed: from the time it starts
+	// entering until it finishes exiting.
+	shown  state.MutableState[bool]
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu          sync.Mutex
+	visible     bool
+	enter, exit transitionData
+	// animating is the visibility the progress animates to.
+	animating bool
+}
+
+// vectorConverter converts vectors to themselves.
+var vectorConverter = NewTwoWayConverter(
+	func(v AnimationVector) AnimationVector { return v },
+	func(v AnimationVector) AnimationVector { return v },
+)
+
+func newVisibilityTransition(visible bool) *visibilityTransition {
+	progress := make(AnimationVector, transitionProperties)
+	if visible {
+		for i := range progress {
+			progress[i] = 1
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &visibilityTransition{
+		progress:  NewAnimatable(progress, vectorConverter),
+		shown:     state.NewMutableState(visible, nil),
+		ctx:       ctx,
+		cancel:    cancel,
+		visible:   visible,
+		animating: visible,
+	}
+}
+
+// update sets the visibility the content goes to, and the transitions it takes.
+func (t *visibilityTransition) update(visible bool, enter, exit transitionData) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.visible, t.enter, t.exit = visible, enter, exit
+}
+
+// animate starts animating the progress to the visibility set by update,
+// unless it does already.
+func (t *visibilityTransition) animate(clock frameclock.MonotonicFrameClock) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.animating == t.visible || t.ctx.Err() != nil {
+		return
+	}
+	t.animating = t.visible
+
+	target, specs := AnimationVector{1, 1, 1, 1}, t.enter.specs()
+	if t.visible {
+		if !t.shown.Get() {
+			// Content that finished exiting enters from the start.
+			t.progress.SnapTo(make(AnimationVector, transitionProperties))
+			t.shown.Set(true)
+		}
+	} else {
+		target, specs = t.exit.hidden(), t.exit.specs()
+	}
+	frameclock.Go(t.ctx, clock, func(ctx context.Context) {
+		if t.progress.AnimateTo(ctx, target, specs) != nil {
+			return
+		}
+		t.mu.Lock()
+		hidden := !t.visible
+		t.mu.Unlock()
+		if hidden {
+			t.shown.Set(false)
+		}
+	})
+}
+
+// stop cancels the animation, for good.
+func (t *visibilityTransition) stop() {
+	t.cancel()
+}
+
+func (t *visibilityTransition) OnRemembered() {}
+
+func (t *visibilityTransition) OnForgotten() {
+	t.stop()
+}
+
+// layout lays out the children of node stacked, with the properties of the
+// transition at its current progress. It takes the enter transition while the
+// content is visible, and the exit one while it is hidden.
+func (t *visibilityTransition) layout(gtx layoutnode.LayoutContext, node layoutnode.LayoutNode) layoutnode.LayoutDimensions {
+	t.mu.Lock()
+	transition := t.exit
+	if t.visible {
+		transition = t.enter
+	}
+	t.mu.Unlock()
+	progress := t.progress.Value()
+
+	childGtx := gtx
+	childGtx.Constraints.Min = image.Point{}
+	macro := op.Record(gtx.Ops)
+	var full image.Point
+	for _, child := range node.Children() {
+		dims := child.(layoutnode.NodeCoordinator).Layout(childGtx)
+		full.X = max(full.X, dims.Size.X)
+		full.Y = max(full.Y, dims.Size.Y)
+	}
+	call := macro.Stop()
+
+	size := full
+	if transition.slide != nil {
+		offset := transition.slide.offset(full)
+		fraction := 1 - progress[slideProgress]
+		defer op.Offset(image.Pt(
+			int(float32(offset.X)*fraction),
+			int(float32(offset.Y)*fraction),
+		)).Push(gtx.Ops).Pop()
+	}
+	if transition.fade != nil {
+		alpha := lerp(transition.fade.alpha, 1, progress[fadeProgress])
+		defer paint.PushOpacity(gtx.Ops, clamp(alpha, 0, 1)).Pop()
+	}
+	if changeSize := transition.changeSize; changeSize != nil {
+		from := changeSize.size(full)
+		fraction := progress[changeSizeProgress]
+		size = image.Pt(
+			max(int(lerp(float32(from.X), float32(full.X), fraction)), 0),
+			max(int(lerp(float32(from.Y), float32(full.Y), fraction)), 0),
+		)
+		if changeSize.clip && size != full {
+			defer clip.Rect{Max: size}.Push(gtx.Ops).Pop()
+		}
+		defer op.Offset(image.Pt(
+			int(float32(size.X-full.X)*changeSize.alignment[0]),
+			int(float32(size.Y-full.Y)*changeSize.alignment[1]),
+		)).Push(gtx.Ops).Pop()
+	}
+	if transition.scale != nil {
+		scale := lerp(transition.scale.scale, 1, progress[scaleProgress])
+		center := f32.Pt(float32(full.X)/2, float32(full.Y)/2)
+		defer op.Affine(f32.AffineId().Scale(center, f32.Pt(scale, scale))).Push(gtx.Ops).Pop()
+	}
+	call.Add(gtx.Ops)
+
+	return layoutnode.LayoutDimensions{Size: gtx.Constraints.Constrain(size)}
+}
+
+func lerp(from, to, fraction float32) float32 {
+	return from + (to-from)*fraction
+}
@@ -0,0 +1,139 @@
+package animation_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/animation"
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/compose/foundation/layout/column"
+	"github.com/zodimo/go-compose/compose/foundation/text"
+	"github.com/zodimo/go-compose/composetest"
+	"github.com/zodimo/go-compose/modifiers/semantics"
+	"github.com/zodimo/go-compose/modifiers/size"
+	"github.com/zodimo/go-compose/state"
+)
+
+var linear100 = animation.WithTransitionSpec(animation.Tween(100*time.Millisecond, animation.WithEasing(animation.LinearEasing)))
+
+func TestAnimatedVisibility(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	clock := rule.MainClock()
+	visible := state.NewMutableState(true, nil)
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		return column.Column(c.Sequence(
+			animation.AnimatedVisibility(visible.Get(),
+				box.Box(compose.Id(), box.WithModifier(size.Size(40, 40).Then(semantics.TestTag("content")))),
+				animation.WithEnter(animation.ExpandVertically(linear100)),
+				animation.WithExit(animation.ShrinkVertically(linear100).Plus(animation.FadeOut(linear100))),
+			),
+			box.Box(compose.Id(), box.WithModifier(size.Size(40, 40).Then(semantics.TestTag("below")))),
+		))(c)
+	})
+
+	rule.OnNodeWithTag("content").AssertIsDisplayed()
+
+	clock.SetAutoAdvance(false)
+	visible.Set(false)
+	rule.WaitForIdle()
+	clock.AdvanceTimeByFrame()
+	clock.AdvanceTimeBy(50 * time.Millisecond)
+	// The content stays composed while it exits.
+	rule.OnNodeWithTag("content").AssertExists()
+	if got := rule.OnNodeWithTag("below").FetchSemanticsNode().Bounds.Min.Y; got != 20 {
+		t.Errorf("height while shrinking = %d, want 20", got)
+	}
+
+	clock.AdvanceTimeBy(50 * time.Millisecond)
+	rule.OnNodeWithTag("content").AssertDoesNotExist()
+
+	visible.Set(true)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("content").AssertExists()
+	clock.AdvanceTimeByFrame()
+	clock.AdvanceTimeBy(25 * time.Millisecond)
+	if got := rule.OnNodeWithTag("below").FetchSemanticsNode().Bounds.Min.Y; got != 10 {
+		t.Errorf("height while expanding = %d, want 10", got)
+	}
+
+	clock.SetAutoAdvance(true)
+	rule.WaitForIdle()
+	if got := rule.OnNodeWithTag("below").FetchSemanticsNode().Bounds.Min.Y; got != 40 {
+		t.Errorf("height once visible = %d, want 40", got)
+	}
+}
+
+func TestAnimatedVisibilityInitiallyHidden(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	visible := state.NewMutableState(false, nil)
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		return animation.AnimatedVisibility(visible.Get(), text.Text("Hello", text.WithModifier(semantics.TestTag("content"))))(c)
+	})
+
+	rule.OnNodeWithTag("content").AssertDoesNotExist()
+	visible.Set(true)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("content").AssertIsDisplayed()
+}
+
+func TestAnimatedContent(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	clock := rule.MainClock()
+	count := state.NewMutableState(0, nil)
+	var transitions []string
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		return animation.AnimatedContent(count.Get(), func(count int) compose.Composable {
+			return text.Text(fmt.Sprint(count), text.WithModifier(semantics.TestTag(fmt.Sprint("count", count))))
+		}, animation.WithContentTransitionSpec(func(initial, target int) animation.ContentTransform {
+			transitions = append(transitions, fmt.Sprintf("%d->%d", initial, target))
+			return animation.FadeIn(linear100).TogetherWith(animation.FadeOut(linear100))
+		}))(c)
+	})
+
+	rule.OnNodeWithTag("count0").AssertIsDisplayed()
+
+	clock.SetAutoAdvance(false)
+	count.Set(1)
+	rule.WaitForIdle()
+	clock.AdvanceTimeBy(50 * time.Millisecond)
+	rule.OnNodeWithTag("count0").AssertExists()
+	rule.OnNodeWithTag("count1").AssertExists()
+
+	clock.SetAutoAdvance(true)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("count0").AssertDoesNotExist()
+	rule.OnNodeWithTag("count1").AssertIsDisplayed()
+	if len(transitions) != 1 || transitions[0] != "0->1" {
+		t.Errorf("transitions = %v, want [0->1]", transitions)
+	}
+}
+
+func TestCrossfade(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	page := state.NewMutableState("a", nil)
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		return animation.Crossfade(page.Get(), func(page string) compose.Composable {
+			return text.Text(page, text.WithModifier(semantics.TestTag(page)))
+		})(c)
+	})
+
+	rule.OnNodeWithTag("a").AssertIsDisplayed()
+	page.Set("b")
+	page.Set("c")
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("a").AssertDoesNotExist()
+	rule.OnNodeWithTag("c").AssertIsDisplayed()
+
+	// Going back while the old content exits brings it back.
+	rule.MainClock().SetAutoAdvance(false)
+	page.Set("a")
+	rule.WaitForIdle()
+	rule.MainClock().AdvanceTimeBy(100 * time.Millisecond)
+	page.Set("c")
+	rule.MainClock().SetAutoAdvance(true)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("a").AssertDoesNotExist()
+	rule.OnNodeWithTag("c").AssertIsDisplayed()
+}
@@ -0,0 +1,236 @@
+// Package animation animates values on the frame clock of a composition, as
+// the animation-core library of Compose.
+//
+// An AnimationSpec describes how a value goes from an initial value to a
+// target: a Tween over a duration, a Spring, Keyframes, or a repetition of
+// them. Values of any type are animated as vectors of floats, converted by a
+// TwoWayConverter. An Animatable animates a value in an effect, and
+// AnimateFloatAsState and its siblings animate to a target set by composition:
+//
+//	alpha := animation.AnimateFloatAsState(c, target, animation.WithAnimationSpec(
+//		animation.Tween(300*time.Millisecond),
+//	))
+//	// Read alpha.Get() while composing.
+//
+// AnimatedVisibility shows and hides content with enter and exit transitions,
+// and AnimatedContent and Crossfade animate between the contents of a state.
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// DefaultDuration is the duration of animations when none is given.
+const DefaultDuration = 300 * time.Millisecond
+
+// Infinite is the duration of animations that do not end.
+const Infinite time.Duration = math.MaxInt64
+
+// AnimationSpec describes an animation from an initial value to a target, in
+// vectors. The play time of an animation is the time since it started.
+type AnimationSpec interface {
+	// ValueAt returns the value of the animation at playTime.
+	ValueAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector
+	// VelocityAt returns the velocity of the animation at playTime, in units
+	// per second.
+	VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector
+	// Duration returns the play time at which the animation reaches target, or
+	// Infinite.
+	Duration(initial, target, initialVelocity AnimationVector) time.Duration
+}
+
+// TweenOption is a functional option for configuring Tween.
+type TweenOption func(*TweenOptions)
+
+type TweenOptions struct {
+	// Delay is the time the animation waits before starting.
+	Delay  time.Duration
+	Easing Easing
+}
+
+func DefaultTweenOptions() TweenOptions {
+	return TweenOptions{
+		Easing: FastOutSlowInEasing,
+	}
+}
+
+func WithDelay(delay time.Duration) TweenOption {
+	return func(o *TweenOptions) {
+		o.Delay = delay
+	}
+}
+
+func WithEasing(easing Easing) TweenOption {
+	return func(o *TweenOptions) {
+		o.Easing = easing
+	}
+}
+
+// Tween animates from the initial value to the target over duration, along
+// an easing curve, FastOutSlowInEasing by default.
+func Tween(duration time.Duration, options ...TweenOption) AnimationSpec {
+	opts := DefaultTweenOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return &tweenSpec{duration: duration, opts: opts}
+}
+
+var _ AnimationSpec = (*tweenSpec)(nil)
+
+type tweenSpec struct {
+	duration time.Duration
+	opts     TweenOptions
+}
+
+func (s *tweenSpec) ValueAt(playTime time.Duration, initial, target, _ AnimationVector) AnimationVector {
+	fraction := float32(1)
+	if s.duration > 0 {
+		fraction = clamp(float32(playTime-s.opts.Delay)/float32(s.duration), 0, 1)
+	} else if playTime < s.opts.Delay {
+		fraction = 0
+	}
+	return initial.lerp(target, s.opts.Easing.Transform(fraction))
+}
+
+func (s *tweenSpec) VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	return velocityFromValues(s, playTime, initial, target, initialVelocity)
+}
+
+func (s *tweenSpec) Duration(_, _, _ AnimationVector) time.Duration {
+	return s.opts.Delay + s.duration
+}
+
+// velocityFromValues returns the velocity of spec at playTime, from its values
+// a millisecond apart.
+func velocityFromValues(spec AnimationSpec, playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	const step = time.Millisecond
+	before := max(playTime-step, 0)
+	if before == playTime {
+		return make(AnimationVector, len(initial))
+	}
+	from := spec.ValueAt(before, initial, target, initialVelocity)
+	to := spec.ValueAt(playTime, initial, target, initialVelocity)
+	velocity := make(AnimationVector, len(initial))
+	for i := range velocity {
+		velocity[i] = (to[i] - from[i]) / float32((playTime - before).Seconds())
+	}
+	return velocity
+}
+
+// Damping ratios of springs: the lower, the more a spring bounces around its
+// target before settling.
+const (
+	DampingRatioHighBouncy   float32 = 0.2
+	DampingRatioMediumBouncy float32 = 0.5
+	DampingRatioLowBouncy    float32 = 0.75
+	DampingRatioNoBouncy     float32 = 1
+)
+
+// Stiffnesses of springs: the higher, the faster a spring reaches its target.
+const (
+	StiffnessHigh      float32 = 10_000
+	StiffnessMedium    float32 = 1500
+	StiffnessMediumLow float32 = 400
+	StiffnessLow       float32 = 200
+	StiffnessVeryLow   float32 = 50
+)
+
+// DefaultVisibilityThreshold is the distance to its target at which a spring
+// is considered settled, when none is given.
+const DefaultVisibilityThreshold float32 = 0.01
+
+// SpringOption is a functional option for configuring Spring.
+type SpringOption func(*SpringOptions)
+
+type SpringOptions struct {
+	DampingRatio float32
+	Stiffness    float32
+	// VisibilityThreshold is the distance to its target, in each dimension, at
+	// which the spring is considered settled and snaps to it.
+	VisibilityThreshold float32
+}
+
+func DefaultSpringOptions() SpringOptions {
+	return SpringOptions{
+		DampingRatio:        DampingRatioNoBouncy,
+		Stiffness:           StiffnessMedium,
+		VisibilityThreshold: DefaultVisibilityThreshold,
+	}
+}
+
+func WithDampingRatio(dampingRatio float32) SpringOption {
+	return func(o *SpringOptions) {
+		o.DampingRatio = dampingRatio
+	}
+}
+
+func WithStiffness(stiffness float32) SpringOption {
+	return func(o *SpringOptions) {
+		o.Stiffness = stiffness
+	}
+}
+
+func WithVisibilityThreshold(threshold float32) SpringOption {
+	return func(o *SpringOptions) {
+		o.VisibilityThreshold = threshold
+	}
+}
+
+// Spring animates with the physics of a spring, which keeps the velocity of
+// the value when its target changes. It does not bounce by default.
+func Spring(options ...SpringOption) AnimationSpec {
+	opts := DefaultSpringOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return &springSpec{opts: opts}
+}
+
+var _ AnimationSpec = (*springSpec)(nil)
+
+type springSpec struct {
+	opts SpringOptions
+}
+
+func (s *springSpec) ValueAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	value := make(AnimationVector, len(initial))
+	for i := range value {
+		x, _ := s.spring(initial[i]-target[i], initialVelocity[i]).at(playTime.Seconds())
+		value[i] = target[i] + float32(x)
+	}
+	return value
+}
+
+func (s *springSpec) VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	velocity := make(AnimationVector, len(initial))
+	for i := range velocity {
+		_, v := s.spring(initial[i]-target[i], initialVelocity[i]).at(playTime.Seconds())
+		velocity[i] = float32(v)
+	}
+	return velocity
+}
+
+func (s *springSpec) Duration(initial, target, initialVelocity AnimationVector) time.Duration {
+	var duration time.Duration
+	for i := range initial {
+		duration = max(duration, s.spring(initial[i]-target[i], initialVelocity[i]).settle(float64(s.opts.VisibilityThreshold)))
+	}
+	return duration
+}
+
+func (s *springSpec) spring(displacement, velocity float32) spring {
+	return spring{
+		dampingRatio: float64(s.opts.DampingRatio),
+		stiffness:    float64(s.opts.Stiffness),
+		x0:           float64(displacement),
+		v0:           float64(velocity),
+	}
+}
@@ -0,0 +1,121 @@
+package animation
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-3
+}
+
+func TestTween(t *testing.T) {
+	spec := Tween(100*time.Millisecond, WithEasing(LinearEasing), WithDelay(50*time.Millisecond))
+	from, to, v0 := AnimationVector{0}, AnimationVector{10}, AnimationVector{0}
+
+	if got := spec.Duration(from, to, v0); got != 150*time.Millisecond {
+		t.Errorf("duration = %v, want 150ms", got)
+	}
+	for _, tc := range []struct {
+		playTime time.Duration
+		want     float32
+	}{
+		{0, 0},
+		{50 * time.Millisecond, 0},
+		{100 * time.Millisecond, 5},
+		{150 * time.Millisecond, 10},
+		{time.Second, 10},
+	} {
+		if got := spec.ValueAt(tc.playTime, from, to, v0)[0]; !near(got, tc.want) {
+			t.Errorf("value at %v = %v, want %v", tc.playTime, got, tc.want)
+		}
+	}
+	if got := spec.VelocityAt(100*time.Millisecond, from, to, v0)[0]; !near(got, 100) {
+		t.Errorf("velocity = %v, want 100 per second", got)
+	}
+}
+
+func TestSpring(t *testing.T) {
+	from, to, v0 := AnimationVector{0}, AnimationVector{1}, AnimationVector{0}
+	for _, dampingRatio := range []float32{DampingRatioHighBouncy, DampingRatioNoBouncy, 2} {
+		spec := Spring(WithDampingRatio(dampingRatio), WithStiffness(StiffnessMedium))
+		duration := spec.Duration(from, to, v0)
+		if duration <= 0 || duration >= maxSettleTime {
+			t.Fatalf("damping %v: duration = %v", dampingRatio, duration)
+		}
+		if got := spec.ValueAt(duration, from, to, v0)[0]; math.Abs(float64(got-1)) > float64(DefaultVisibilityThreshold) {
+			t.Errorf("damping %v: value at the end = %v, want 1", dampingRatio, got)
+		}
+
+		var peak float32
+		for p := time.Duration(0); p < duration; p += time.Millisecond {
+			peak = max(peak, spec.ValueAt(p, from, to, v0)[0])
+		}
+		if bouncy := dampingRatio < 1; bouncy != (peak > 1.01) {
+			t.Errorf("damping %v: peak = %v", dampingRatio, peak)
+		}
+	}
+}
+
+func TestSpringKeepsVelocity(t *testing.T) {
+	spec := Spring()
+	from, to := AnimationVector{0}, AnimationVector{0}
+	if got := spec.VelocityAt(0, from, to, AnimationVector{5})[0]; !near(got, 5) {
+		t.Errorf("initial velocity = %v, want 5", got)
+	}
+	if got := spec.ValueAt(10*time.Millisecond, from, to, AnimationVector{5})[0]; got <= 0 {
+		t.Errorf("value = %v, want it to move along the initial velocity", got)
+	}
+}
+
+func TestKeyframes(t *testing.T) {
+	spec := Keyframes(Float32VectorConverter, 400*time.Millisecond,
+		KeyframeAt(100*time.Millisecond, float32(20)),
+		KeyframeAt(300*time.Millisecond, float32(0)).WithEasing(LinearEasing),
+	)
+	from, to, v0 := AnimationVector{0}, AnimationVector{10}, AnimationVector{0}
+	for _, tc := range []struct {
+		playTime time.Duration
+		want     float32
+	}{
+		{0, 0},
+		{50 * time.Millisecond, 10},
+		{100 * time.Millisecond, 20},
+		{200 * time.Millisecond, 10},
+		{350 * time.Millisecond, 5},
+		{400 * time.Millisecond, 10},
+	} {
+		if got := spec.ValueAt(tc.playTime, from, to, v0)[0]; !near(got, tc.want) {
+			t.Errorf("value at %v = %v, want %v", tc.playTime, got, tc.want)
+		}
+	}
+}
+
+func TestRepeatable(t *testing.T) {
+	tween := Tween(100*time.Millisecond, WithEasing(LinearEasing))
+	spec := Repeatable(3, tween, WithRepeatMode(RepeatModeReverse))
+	from, to, v0 := AnimationVector{0}, AnimationVector{10}, AnimationVector{0}
+
+	if got := spec.Duration(from, to, v0); got != 300*time.Millisecond {
+		t.Errorf("duration = %v, want 300ms", got)
+	}
+	for _, tc := range []struct {
+		playTime time.Duration
+		want     float32
+	}{
+		{50 * time.Millisecond, 5},
+		{125 * time.Millisecond, 7.5},
+		{250 * time.Millisecond, 5},
+		{300 * time.Millisecond, 10},
+		{time.Second, 10},
+	} {
+		if got := spec.ValueAt(tc.playTime, from, to, v0)[0]; !near(got, tc.want) {
+			t.Errorf("value at %v = %v, want %v", tc.playTime, got, tc.want)
+		}
+	}
+
+	if got := InfiniteRepeatable(tween).Duration(from, to, v0); got != Infinite {
+		t.Errorf("infinite duration = %v", got)
+	}
+}
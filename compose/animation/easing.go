@@ -0,0 +1,112 @@
+package animation
+
+// Easing is a way to adjust an animation's fraction. Easing allows transitioning
+// elements to speed up and slow down, rather than moving at a constant rate.
+//
+// Fraction is a value between 0 and 1.0 indicating the current point in the
+// animation where 0 represents the start and 1.0 represents the end.
+type Easing interface {
+	Transform(fraction float32) float32
+}
+
+// CubicBezierEasing is a cubic polynomial easing implementing third-order Bézier curves.
+//
+// This is equivalent to Android's PathInterpolator when a single cubic Bézier curve
+// is specified.
+//
+// Parameters:
+//   - A: The x coordinate of the first control point
+//   - B: The y coordinate of the first control point
+//   - C: The x coordinate of the second control point
+//   - D: The y coordinate of the second control point
+type CubicBezierEasing struct {
+	A, B, C, D float32
+}
+
+// NewCubicBezierEasing creates a new CubicBezierEasing with the given control points.
+func NewCubicBezierEasing(a, b, c, d float32) *CubicBezierEasing {
+	return &CubicBezierEasing{A: a, B: b, C: c, D: d}
+}
+
+// Transform transforms the specified fraction (0..1) by this cubic Bézier curve.
+func (e *CubicBezierEasing) Transform(fraction float32) float32 {
+	if fraction <= 0 {
+		return 0
+	}
+	if fraction >= 1 {
+		return 1
+	}
+
+	// Find t for the given x (fraction) using Newton-Raphson method
+	t := fraction
+	for i := 0; i < 8; i++ {
+		x := e.evaluateX(t) - fraction
+		if abs32(x) < 1e-6 {
+			break
+		}
+		dx := e.evaluateDX(t)
+		if abs32(dx) < 1e-6 {
+			break
+		}
+		t -= x / dx
+	}
+
+	// Clamp t to [0, 1]
+	if t < 0 {
+		t = 0
+	} else if t > 1 {
+		t = 1
+	}
+
+	return e.evaluateY(t)
+}
+
+// evaluateX evaluates the x coordinate of the Bézier curve at parameter t
+func (e *CubicBezierEasing) evaluateX(t float32) float32 {
+	// B(t) = (1-t)³*0 + 3*(1-t)²*t*a + 3*(1-t)*t²*c + t³*1
+	oneMinusT := 1 - t
+	return 3*oneMinusT*oneMinusT*t*e.A + 3*oneMinusT*t*t*e.C + t*t*t
+}
+
+// evaluateDX evaluates the derivative of x with respect to t
+func (e *CubicBezierEasing) evaluateDX(t float32) float32 {
+	// dB(t)/dt = 3*(1-t)²*a + 6*(1-t)*t*(c-a) + 3*t²*(1-c)
+	oneMinusT := 1 - t
+	return 3*oneMinusT*oneMinusT*e.A + 6*oneMinusT*t*(e.C-e.A) + 3*t*t*(1-e.C)
+}
+
+// evaluateY evaluates the y coordinate of the Bézier curve at parameter t
+func (e *CubicBezierEasing) evaluateY(t float32) float32 {
+	// B(t) = (1-t)³*0 + 3*(1-t)²*t*b + 3*(1-t)*t²*d + t³*1
+	oneMinusT := 1 - t
+	return 3*oneMinusT*oneMinusT*t*e.B + 3*oneMinusT*t*t*e.D + t*t*t
+}
+
+func abs32(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// EasingFunc is an Easing defined by a function.
+type EasingFunc func(fraction float32) float32
+
+func (f EasingFunc) Transform(fraction float32) float32 {
+	return f(fraction)
+}
+
+// FastOutSlowInEasing speeds up quickly and slows down gradually, in order to
+// emphasize the end of the transition.
+var FastOutSlowInEasing Easing = NewCubicBezierEasing(0.4, 0.0, 0.2, 1.0)
+
+// LinearOutSlowInEasing starts at peak velocity and slows down, for elements
+// entering the screen.
+var LinearOutSlowInEasing Easing = NewCubicBezierEasing(0.0, 0.0, 0.2, 1.0)
+
+// FastOutLinearInEasing speeds up and ends at peak velocity, for elements
+// exiting the screen.
+var FastOutLinearInEasing Easing = NewCubicBezierEasing(0.4, 0.0, 1.0, 1.0)
+
+// LinearEasing returns the fraction unmodified.
+var LinearEasing Easing = EasingFunc(func(fraction float32) float32 { return fraction })
@@ -0,0 +1,93 @@
+package animation
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Keyframe is the value of an animation at a time of Keyframes, and the easing
+// of the animation from it to the next keyframe.
+type Keyframe[T any] struct {
+	At     time.Duration
+	Value  T
+	Easing Easing
+}
+
+// KeyframeAt returns the keyframe of value at, eased linearly to the next one.
+func KeyframeAt[T any](at time.Duration, value T) Keyframe[T] {
+	return Keyframe[T]{At: at, Value: value}
+}
+
+// WithEasing returns k eased to the next keyframe by easing.
+func (k Keyframe[T]) WithEasing(easing Easing) Keyframe[T] {
+	k.Easing = easing
+	return k
+}
+
+// Keyframes animates through the values of keyframes over duration. The
+// animation starts at the initial value and ends at the target, unless
+// keyframes at 0 and at duration set them:
+//
+//	animation.Keyframes(animation.Float32VectorConverter, 400*time.Millisecond,
+//		animation.KeyframeAt(100*time.Millisecond, float32(1.2)).WithEasing(animation.LinearOutSlowInEasing),
+//		animation.KeyframeAt(250*time.Millisecond, float32(0.9)),
+//	)
+func Keyframes[T any](converter TwoWayConverter[T], duration time.Duration, keyframes ...Keyframe[T]) AnimationSpec {
+	spec := &keyframesSpec{duration: duration}
+	for _, k := range keyframes {
+		spec.keyframes = append(spec.keyframes, keyframe{
+			at:     k.At,
+			value:  converter.ConvertToVector(k.Value),
+			easing: k.Easing,
+		})
+	}
+	slices.SortStableFunc(spec.keyframes, func(a, b keyframe) int {
+		return cmp.Compare(a.at, b.at)
+	})
+	return spec
+}
+
+var _ AnimationSpec = (*keyframesSpec)(nil)
+
+type keyframesSpec struct {
+	duration  time.Duration
+	keyframes []keyframe
+}
+
+type keyframe struct {
+	at     time.Duration
+	value  AnimationVector
+	easing Easing
+}
+
+func (s *keyframesSpec) ValueAt(playTime time.Duration, initial, target, _ AnimationVector) AnimationVector {
+	playTime = min(max(playTime, 0), s.duration)
+	from := keyframe{at: 0, value: initial}
+	to := keyframe{at: s.duration, value: target}
+	for _, k := range s.keyframes {
+		if k.at <= playTime {
+			from = k
+			continue
+		}
+		to = k
+		break
+	}
+	if to.at <= from.at {
+		return from.value
+	}
+	easing := from.easing
+	if easing == nil {
+		easing = LinearEasing
+	}
+	fraction := float32(playTime-from.at) / float32(to.at-from.at)
+	return from.value.lerp(to.value, easing.Transform(fraction))
+}
+
+func (s *keyframesSpec) VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	return velocityFromValues(s, playTime, initial, target, initialVelocity)
+}
+
+func (s *keyframesSpec) Duration(_, _, _ AnimationVector) time.Duration {
+	return s.duration
+}
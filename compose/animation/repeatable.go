@@ -0,0 +1,124 @@
+package animation
+
+import "time"
+
+// RepeatMode is how a repeated animation starts its next iteration.
+type RepeatMode int
+
+const (
+	// RepeatModeRestart starts each iteration from the initial value.
+	RepeatModeRestart RepeatMode = iota
+	// RepeatModeReverse plays every other iteration backwards, from the target
+	// to the initial value.
+	RepeatModeReverse
+)
+
+func (m RepeatMode) String() string {
+	switch m {
+	case RepeatModeRestart:
+		return "restart"
+	case RepeatModeReverse:
+		return "reverse"
+	default:
+		return "invalid RepeatMode"
+	}
+}
+
+// RepeatableOption is a functional option for configuring Repeatable and
+// InfiniteRepeatable.
+type RepeatableOption func(*RepeatableOptions)
+
+type RepeatableOptions struct {
+	RepeatMode RepeatMode
+	// InitialStartOffset is the play time of the repeated animation at which
+	// the first iteration starts.
+	InitialStartOffset time.Duration
+}
+
+func DefaultRepeatableOptions() RepeatableOptions {
+	return RepeatableOptions{
+		RepeatMode: RepeatModeRestart,
+	}
+}
+
+func WithRepeatMode(mode RepeatMode) RepeatableOption {
+	return func(o *RepeatableOptions) {
+		o.RepeatMode = mode
+	}
+}
+
+func WithInitialStartOffset(offset time.Duration) RepeatableOption {
+	return func(o *RepeatableOptions) {
+		o.InitialStartOffset = offset
+	}
+}
+
+// Repeatable plays animation iterations times. The animation must have a
+// duration of its own, as a Tween or Keyframes do.
+func Repeatable(iterations int, animation AnimationSpec, options ...RepeatableOption) AnimationSpec {
+	return newRepeatableSpec(iterations, animation, options)
+}
+
+// InfiniteRepeatable plays animation until it is cancelled. The animation must
+// have a duration of its own, as a Tween or Keyframes do.
+func InfiniteRepeatable(animation AnimationSpec, options ...RepeatableOption) AnimationSpec {
+	return newRepeatableSpec(-1, animation, options)
+}
+
+func newRepeatableSpec(iterations int, animation AnimationSpec, options []RepeatableOption) *repeatableSpec {
+	opts := DefaultRepeatableOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return &repeatableSpec{iterations: iterations, animation: animation, opts: opts}
+}
+
+var _ AnimationSpec = (*repeatableSpec)(nil)
+
+type repeatableSpec struct {
+	// iterations is negative for InfiniteRepeatable.
+	iterations int
+	animation  AnimationSpec
+	opts       RepeatableOptions
+}
+
+// iterationTime returns the play time of the animation in the iteration at
+// playTime.
+func (s *repeatableSpec) iterationTime(playTime, duration time.Duration) time.Duration {
+	if duration <= 0 {
+		return 0
+	}
+	playTime += s.opts.InitialStartOffset
+	iteration := playTime / duration
+	if s.iterations >= 0 && iteration >= time.Duration(s.iterations) {
+		// The animation stays at the end of its last iteration.
+		iteration = time.Duration(max(s.iterations-1, 0))
+		playTime = (iteration + 1) * duration
+	}
+	t := playTime - iteration*duration
+	if s.opts.RepeatMode == RepeatModeReverse && iteration%2 == 1 {
+		t = duration - t
+	}
+	return t
+}
+
+func (s *repeatableSpec) ValueAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	duration := s.animation.Duration(initial, target, initialVelocity)
+	return s.animation.ValueAt(s.iterationTime(playTime, duration), initial, target, initialVelocity)
+}
+
+func (s *repeatableSpec) VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	duration := s.animation.Duration(initial, target, initialVelocity)
+	return s.animation.VelocityAt(s.iterationTime(playTime, duration), initial, target, initialVelocity)
+}
+
+func (s *repeatableSpec) Duration(initial, target, initialVelocity AnimationVector) time.Duration {
+	if s.iterations < 0 {
+		return Infinite
+	}
+	duration := s.animation.Duration(initial, target, initialVelocity)
+	return max(time.Duration(s.iterations)*duration-s.opts.InitialStartOffset, 0)
+}
@@ -0,0 +1,82 @@
+package animation
+
+import (
+	"math"
+	"time"
+)
+
+// spring is a damped spring of unit mass, displaced by x0 from its rest
+// position with velocity v0.
+type spring struct {
+	dampingRatio float64
+	stiffness    float64
+	x0, v0       float64
+}
+
+// at returns the displacement and velocity of the spring after t seconds.
+func (s spring) at(t float64) (x, v float64) {
+	w0 := math.Sqrt(s.stiffness)
+	z := s.dampingRatio
+	switch {
+	case z < 1:
+		// Underdamped: the spring oscillates around its rest position.
+		wd := w0 * math.Sqrt(1-z*z)
+		a, b := s.x0, (s.v0+z*w0*s.x0)/wd
+		decay := math.Exp(-z * w0 * t)
+		cos, sin := math.Cos(wd*t), math.Sin(wd*t)
+		x = decay * (a*cos + b*sin)
+		v = decay * ((b*wd-z*w0*a)*cos - (a*wd+z*w0*b)*sin)
+	case z == 1:
+		// Critically damped: the spring returns as fast as it can without
+		// oscillating.
+		a, b := s.x0, s.v0+w0*s.x0
+		decay := math.Exp(-w0 * t)
+		x = (a + b*t) * decay
+		v = (b - w0*(a+b*t)) * decay
+	default:
+		// Overdamped: the spring returns slowly.
+		root := w0 * math.Sqrt(z*z-1)
+		r1, r2 := -z*w0-root, -z*w0+root
+		c2 := (s.v0 - r1*s.x0) / (r2 - r1)
+		c1 := s.x0 - c2
+		e1, e2 := math.Exp(r1*t), math.Exp(r2*t)
+		x = c1*e1 + c2*e2
+		v = c1*r1*e1 + c2*r2*e2
+	}
+	return x, v
+}
+
+// maxSettleTime bounds the time a spring is simulated for, for springs too
+// weak to settle.
+const maxSettleTime = time.Minute
+
+// settle returns the time after which the spring stays within threshold of its
+// rest position. Undamped springs never settle, and last maxSettleTime.
+func (s spring) settle(threshold float64) time.Duration {
+	w0 := math.Sqrt(s.stiffness)
+	z := s.dampingRatio
+	if z <= 0 {
+		return maxSettleTime
+	}
+	if z < 1 {
+		// The oscillations decay within an exponential envelope.
+		wd := w0 * math.Sqrt(1-z*z)
+		amplitude := math.Hypot(s.x0, (s.v0+z*w0*s.x0)/wd)
+		if amplitude <= threshold {
+			return 0
+		}
+		t := math.Log(amplitude/threshold) / (z * w0)
+		return min(time.Duration(t*float64(time.Second)), maxSettleTime)
+	}
+	// Without oscillations, the spring stays once within the threshold, and
+	// slow enough, as Compose considers it at equilibrium.
+	velocityThreshold := threshold * 62.5
+	const step = time.Millisecond
+	for t := time.Duration(0); t < maxSettleTime; t += step {
+		x, v := s.at(t.Seconds())
+		if math.Abs(x) < threshold && math.Abs(v) < velocityThreshold {
+			return t
+		}
+	}
+	return maxSettleTime
+}
@@ -0,0 +1,369 @@
+package animation
+
+import (
+	"image"
+	"time"
+)
+
+// EnterTransition describes how content appears in AnimatedVisibility and
+// AnimatedContent. Transitions combine with Plus; when both set the same
+// property, as two fades, the first one wins:
+//
+//	animation.FadeIn().Plus(animation.ExpandVertically())
+type EnterTransition struct {
+	data transitionData
+}
+
+// ExitTransition describes how content disappears from AnimatedVisibility and
+// AnimatedContent. Transitions combine with Plus.
+type ExitTransition struct {
+	data transitionData
+}
+
+// EnterNone and ExitNone show and hide content at once.
+var (
+	EnterNone = EnterTransition{}
+	ExitNone  = ExitTransition{}
+)
+
+// Plus returns the transition running e and other together.
+func (e EnterTransition) Plus(other EnterTransition) EnterTransition {
+	return EnterTransition{data: e.data.plus(other.data)}
+}
+
+// Plus returns the transition running e and other together.
+func (e ExitTransition) Plus(other ExitTransition) ExitTransition {
+	return ExitTransition{data: e.data.plus(other.data)}
+}
+
+// transitionData holds the properties a transition animates. Each is nil when
+// the transition leaves it alone.
+type transitionData struct {
+	fade       *fadeTransition
+	slide      *slideTransition
+	changeSize *changeSizeTransition
+	scale      *scaleTransition
+}
+
+func (d transitionData) plus(other transitionData) transitionData {
+	if d.fade == nil {
+		d.fade = other.fade
+	}
+	if d.slide == nil {
+		d.slide = other.slide
+	}
+	if d.changeSize == nil {
+		d.changeSize = other.changeSize
+	}
+	if d.scale == nil {
+		d.scale = other.scale
+	}
+	return d
+}
+
+// fadeTransition fades content from or to alpha.
+type fadeTransition struct {
+	alpha float32
+	spec  AnimationSpec
+}
+
+// slideTransition moves content from or to offset, a function of its size.
+type slideTransition struct {
+	offset func(fullSize image.Point) image.Point
+	spec   AnimationSpec
+}
+
+// changeSizeTransition clips content to a size growing from, or shrinking to,
+// size. The content is aligned in the clip by alignment, as fractions of the
+// clipped away width and height.
+type changeSizeTransition struct {
+	size      func(fullSize image.Point) image.Point
+	alignment [2]float32
+	clip      bool
+	spec      AnimationSpec
+}
+
+// scaleTransition scales content from or to scale, around its center.
+type scaleTransition struct {
+	scale float32
+	spec  AnimationSpec
+}
+
+// TransitionOption is a functional option for configuring the enter and exit
+// transitions.
+type TransitionOption func(*TransitionOptions)
+
+type TransitionOptions struct {
+	// AnimationSpec animates the transition, from 0 when hidden to 1 when
+	// visible. It is a spring of StiffnessMediumLow by default.
+	AnimationSpec AnimationSpec
+	// Alpha is the alpha FadeIn starts from, and FadeOut ends at.
+	Alpha float32
+	// Scale is the scale ScaleIn starts from, and ScaleOut ends at.
+	Scale float32
+	// Clip clips the content to its animated size in ExpandIn, ShrinkOut and
+	// their siblings.
+	Clip bool
+}
+
+func DefaultTransitionOptions() TransitionOptions {
+	return TransitionOptions{
+		AnimationSpec: Spring(WithStiffness(StiffnessMediumLow), WithVisibilityThreshold(0.001)),
+		Clip:          true,
+	}
+}
+
+func WithTransitionSpec(spec AnimationSpec) TransitionOption {
+	return func(o *TransitionOptions) {
+		o.AnimationSpec = spec
+	}
+}
+
+func WithAlpha(alpha float32) TransitionOption {
+	return func(o *TransitionOptions) {
+		o.Alpha = alpha
+	}
+}
+
+func WithScale(scale float32) TransitionOption {
+	return func(o *TransitionOptions) {
+		o.Scale = scale
+	}
+}
+
+func WithClip(clip bool) TransitionOption {
+	return func(o *TransitionOptions) {
+		o.Clip = clip
+	}
+}
+
+func transitionOptions(options []TransitionOption) TransitionOptions {
+	opts := DefaultTransitionOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return opts
+}
+
+// FadeIn fades content in, from transparent.
+func FadeIn(options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{fade: &fadeTransition{alpha: opts.Alpha, spec: opts.AnimationSpec}}}
+}
+
+// FadeOut fades content out, to transparent.
+func FadeOut(options ...TransitionOption) ExitTransition {
+	opts := transitionOptions(options)
+	return ExitTransition{data: transitionData{fade: &fadeTransition{alpha: opts.Alpha, spec: opts.AnimationSpec}}}
+}
+
+// SlideIn slides content in from initialOffset, a function of the size of the
+// content.
+func SlideIn(initialOffset func(fullSize image.Point) image.Point, options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{slide: &slideTransition{offset: initialOffset, spec: opts.AnimationSpec}}}
+}
+
+// SlideOut slides content out to targetOffset, a function of the size of the
+// content.
+func SlideOut(targetOffset func(fullSize image.Point) image.Point, options ...TransitionOption) ExitTransition {
+	opts := transitionOptions(options)
+	return ExitTransition{data: transitionData{slide: &slideTransition{offset: targetOffset, spec: opts.AnimationSpec}}}
+}
+
+// SlideInHorizontally slides content in from initialOffsetX, a function of its
+// width. A nil initialOffsetX slides it in from half its width to the left.
+func SlideInHorizontally(initialOffsetX func(fullWidth int) int, options ...TransitionOption) EnterTransition {
+	return SlideIn(horizontalOffset(initialOffsetX), options...)
+}
+
+// SlideOutHorizontally slides content out to targetOffsetX, a function of its
+// width. A nil targetOffsetX slides it out to half its width to the left.
+func SlideOutHorizontally(targetOffsetX func(fullWidth int) int, options ...TransitionOption) ExitTransition {
+	return SlideOut(horizontalOffset(targetOffsetX), options...)
+}
+
+// SlideInVertically slides content in from initialOffsetY, a function of its
+// height. A nil initialOffsetY slides it in from half its height above.
+func SlideInVertically(initialOffsetY func(fullHeight int) int, options ...TransitionOption) EnterTransition {
+	return SlideIn(verticalOffset(initialOffsetY), options...)
+}
+
+// SlideOutVertically slides content out to targetOffsetY, a function of its
+// height. A nil targetOffsetY slides it out to half its height above.
+func SlideOutVertically(targetOffsetY func(fullHeight int) int, options ...TransitionOption) ExitTransition {
+	return SlideOut(verticalOffset(targetOffsetY), options...)
+}
+
+func horizontalOffset(offsetX func(fullWidth int) int) func(image.Point) image.Point {
+	if offsetX == nil {
+		offsetX = func(fullWidth int) int { return -fullWidth / 2 }
+	}
+	return func(fullSize image.Point) image.Point {
+		return image.Pt(offsetX(fullSize.X), 0)
+	}
+}
+
+func verticalOffset(offsetY func(fullHeight int) int) func(image.Point) image.Point {
+	if offsetY == nil {
+		offsetY = func(fullHeight int) int { return -fullHeight / 2 }
+	}
+	return func(fullSize image.Point) image.Point {
+		return image.Pt(0, offsetY(fullSize.Y))
+	}
+}
+
+// ExpandIn grows the bounds of content from nothing at its bottom end corner,
+// revealing it.
+func ExpandIn(options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{changeSize: &changeSizeTransition{
+		size:      func(image.Point) image.Point { return image.Point{} },
+		alignment: [2]float32{1, 1},
+		clip:      opts.Clip,
+		spec:      opts.AnimationSpec,
+	}}}
+}
+
+// ExpandHorizontally grows the width of content from nothing at its end.
+func ExpandHorizontally(options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{changeSize: &changeSizeTransition{
+		size:      func(full image.Point) image.Point { return image.Pt(0, full.Y) },
+		alignment: [2]float32{1, 0},
+		clip:      opts.Clip,
+		spec:      opts.AnimationSpec,
+	}}}
+}
+
+// ExpandVertically grows the height of content from nothing at its bottom.
+func ExpandVertically(options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{changeSize: &changeSizeTransition{
+		size:      func(full image.Point) image.Point { return image.Pt(full.X, 0) },
+		alignment: [2]float32{0, 1},
+		clip:      opts.Clip,
+		spec:      opts.AnimationSpec,
+	}}}
+}
+
+// ShrinkOut shrinks the bounds of content to nothing at its bottom end corner,
+// hiding it.
+func ShrinkOut(options ...TransitionOption) ExitTransition {
+	return ExitTransition{data: ExpandIn(options...).data}
+}
+
+// ShrinkHorizontally shrinks the width of content to nothing at its end.
+func ShrinkHorizontally(options ...TransitionOption) ExitTransition {
+	return ExitTransition{data: ExpandHorizontally(options...).data}
+}
+
+// ShrinkVertically shrinks the height of content to nothing at its bottom.
+func ShrinkVertically(options ...TransitionOption) ExitTransition {
+	return ExitTransition{data: ExpandVertically(options...).data}
+}
+
+// ScaleIn scales content up around its center, from nothing.
+func ScaleIn(options ...TransitionOption) EnterTransition {
+	opts := transitionOptions(options)
+	return EnterTransition{data: transitionData{scale: &scaleTransition{scale: opts.Scale, spec: opts.AnimationSpec}}}
+}
+
+// ScaleOut scales content down around its center, to nothing.
+func ScaleOut(options ...TransitionOption) ExitTransition {
+	opts := transitionOptions(options)
+	return ExitTransition{data: transitionData{scale: &scaleTransition{scale: opts.Scale, spec: opts.AnimationSpec}}}
+}
+
+// ContentTransform is how AnimatedContent replaces content: the new content
+// enters as the old one exits.
+type ContentTransform struct {
+	Enter EnterTransition
+	Exit  ExitTransition
+}
+
+// TogetherWith returns the ContentTransform of e and exit.
+func (e EnterTransition) TogetherWith(exit ExitTransition) ContentTransform {
+	return ContentTransform{Enter: e, Exit: exit}
+}
+
+// transition properties, as the dimensions of the progress of a transition.
+const (
+	fadeProgress = iota
+	slideProgress
+	changeSizeProgress
+	scaleProgress
+	transitionProperties
+)
+
+// snap moves a property not animated by a transition at once.
+var snap = Tween(0)
+
+// specs returns the specs animating each property of d, as a vector.
+func (d transitionData) specs() vectorSpec {
+	specs := vectorSpec{snap, snap, snap, snap}
+	if d.fade != nil {
+		specs[fadeProgress] = d.fade.spec
+	}
+	if d.slide != nil {
+		specs[slideProgress] = d.slide.spec
+	}
+	if d.changeSize != nil {
+		specs[changeSizeProgress] = d.changeSize.spec
+	}
+	if d.scale != nil {
+		specs[scaleProgress] = d.scale.spec
+	}
+	return specs
+}
+
+// hidden returns the progress of content hidden by d: 0 for the properties it
+// animates, and 1 for the others.
+func (d transitionData) hidden() AnimationVector {
+	hidden := AnimationVector{1, 1, 1, 1}
+	if d.fade != nil {
+		hidden[fadeProgress] = 0
+	}
+	if d.slide != nil {
+		hidden[slideProgress] = 0
+	}
+	if d.changeSize != nil {
+		hidden[changeSizeProgress] = 0
+	}
+	if d.scale != nil {
+		hidden[scaleProgress] = 0
+	}
+	return hidden
+}
+
+var _ AnimationSpec = (vectorSpec)(nil)
+
+// vectorSpec animates each dimension of a vector with its own spec.
+type vectorSpec []AnimationSpec
+
+func (s vectorSpec) ValueAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	value := make(AnimationVector, len(initial))
+	for i := range value {
+		value[i] = s[i].ValueAt(playTime, initial[i:i+1], target[i:i+1], initialVelocity[i:i+1])[0]
+	}
+	return value
+}
+
+func (s vectorSpec) VelocityAt(playTime time.Duration, initial, target, initialVelocity AnimationVector) AnimationVector {
+	velocity := make(AnimationVector, len(initial))
+	for i := range velocity {
+		velocity[i] = s[i].VelocityAt(playTime, initial[i:i+1], target[i:i+1], initialVelocity[i:i+1])[0]
+	}
+	return velocity
+}
+
+func (s vectorSpec) Duration(initial, target, initialVelocity AnimationVector) time.Duration {
+	var duration time.Duration
+	for i := range initial {
+		duration = max(duration, s[i].Duration(initial[i:i+1], target[i:i+1], initialVelocity[i:i+1]))
+	}
+	return duration
+}
@@ -0,0 +1,108 @@
+package animation
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/geometry"
+	"github.com/zodimo/go-compose/compose/ui/graphics"
+	"github.com/zodimo/go-compose/compose/ui/graphics/colorspace"
+	"github.com/zodimo/go-compose/compose/ui/unit"
+)
+
+// AnimationVector is a value being animated, as one float per dimension.
+// Animation specs animate each dimension on its own.
+type AnimationVector []float32
+
+// lerp returns the vector at fraction between v and w.
+func (v AnimationVector) lerp(w AnimationVector, fraction float32) AnimationVector {
+	out := make(AnimationVector, len(v))
+	for i := range v {
+		out[i] = v[i] + (w[i]-v[i])*fraction
+	}
+	return out
+}
+
+// equal reports whether v and w are the same vector.
+func (v AnimationVector) equal(w AnimationVector) bool {
+	if len(v) != len(w) {
+		return false
+	}
+	for i := range v {
+		if v[i] != w[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TwoWayConverter converts the values of type T to AnimationVector and back,
+// for them to be animated.
+type TwoWayConverter[T any] interface {
+	ConvertToVector(value T) AnimationVector
+	ConvertFromVector(vector AnimationVector) T
+}
+
+var _ TwoWayConverter[float32] = (*twoWayConverter[float32])(nil)
+
+type twoWayConverter[T any] struct {
+	toVector   func(T) AnimationVector
+	fromVector func(AnimationVector) T
+}
+
+// NewTwoWayConverter returns a converter made of the functions converting
+// values to vectors and back.
+func NewTwoWayConverter[T any](toVector func(T) AnimationVector, fromVector func(AnimationVector) T) TwoWayConverter[T] {
+	return &twoWayConverter[T]{toVector: toVector, fromVector: fromVector}
+}
+
+func (c *twoWayConverter[T]) ConvertToVector(value T) AnimationVector {
+	return c.toVector(value)
+}
+
+func (c *twoWayConverter[T]) ConvertFromVector(vector AnimationVector) T {
+	return c.fromVector(vector)
+}
+
+// Float32VectorConverter converts floats to vectors of one dimension.
+var Float32VectorConverter = NewTwoWayConverter(
+	func(value float32) AnimationVector { return AnimationVector{value} },
+	func(vector AnimationVector) float32 { return vector[0] },
+)
+
+// DpVectorConverter converts Dp to vectors of one dimension.
+var DpVectorConverter = NewTwoWayConverter(
+	func(value unit.Dp) AnimationVector { return AnimationVector{float32(value)} },
+	func(vector AnimationVector) unit.Dp { return unit.Dp(vector[0]) },
+)
+
+// OffsetVectorConverter converts offsets to vectors of their x and y.
+var OffsetVectorConverter = NewTwoWayConverter(
+	func(value geometry.Offset) AnimationVector { return AnimationVector{value.X(), value.Y()} },
+	func(vector AnimationVector) geometry.Offset { return geometry.NewOffset(vector[0], vector[1]) },
+)
+
+// SizeVectorConverter converts sizes to vectors of their width and height.
+var SizeVectorConverter = NewTwoWayConverter(
+	func(value geometry.Size) AnimationVector { return AnimationVector{value.Width(), value.Height()} },
+	func(vector AnimationVector) geometry.Size { return geometry.NewSize(vector[0], vector[1]) },
+)
+
+// ColorVectorConverter converts colors to vectors of their alpha and Oklab
+// components, in which colors change evenly to the eye. Colors are converted
+// back to sRGB.
+var ColorVectorConverter = NewTwoWayConverter(
+	func(value graphics.Color) AnimationVector {
+		oklab := value.Convert(colorspace.OklabInstance)
+		return AnimationVector{oklab.Alpha(), oklab.Red(), oklab.Green(), oklab.Blue()}
+	},
+	func(vector AnimationVector) graphics.Color {
+		// Springs overshoot: the components are brought back in range.
+		alpha := clamp(vector[0], 0, 1)
+		l := clamp(vector[1], 0, 1)
+		a := clamp(vector[2], -0.5, 0.5)
+		b := clamp(vector[3], -0.5, 0.5)
+		return graphics.UncheckedColor(l, a, b, alpha, colorspace.OklabInstance).Convert(colorspace.Srgb)
+	},
+)
+
+func clamp(v, lo, hi float32) float32 {
+	return min(max(v, lo), hi)
+}
@@ -9,11 +9,25 @@ import (
 
 type Composable = api.Composable
 type Composer = api.Composer
+type Subcomposition = api.Subcomposition
 
 func NewComposer(store state.PersistentState) Composer {
 	return zipper.NewComposer(store)
 }
 
+// Compose composes content as a frame of the composition kept by store and
+// returns the root of the nodes it emitted. Unlike NewComposer followed by
+// Build, it releases the snapshot of the composition when content panics.
+func Compose(store state.PersistentState, content Composable) api.LayoutNode {
+	return zipper.Compose(store, content)
+}
+
+// DisposeComposition removes the composition kept by store, forgetting its
+// remembered values and cancelling its effects. Hosts call it when a window closes.
+func DisposeComposition(store state.PersistentState) {
+	zipper.DisposeComposition(store)
+}
+
 // Use This Sequence When not inside of a composable but composing composables
 var Sequence = sequence.Sequence
 
@@ -0,0 +1,64 @@
+package effect
+
+import (
+	"context"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+)
+
+// EffectScope launches work that is bound to the lifetime of the composable
+// that remembered it.
+type EffectScope interface {
+	// Launch runs block in a new goroutine, with the frame clock of the
+	// composition in its context. The context is cancelled when the scope leaves
+	// the composition; after that Launch does nothing.
+	Launch(block func(ctx context.Context))
+}
+
+// RememberEffectScope returns an EffectScope for starting work from event
+// handlers, such as an OnClick that shows a sheet and then fetches data.
+// The same scope is returned across recompositions, and everything it launched
+// is cancelled when the calling composable leaves the composition.
+func RememberEffectScope(c api.Composer) EffectScope {
+	c.StartBlock("EffectScope")
+	clock := frameclock.LocalFrameClock.Current(c)
+	scope := c.Remember("effect_scope", func() any {
+		return newEffectScope(clock)
+	}).(*effectScope)
+	c.SetWidgetConstructor(emptyWidgetConstructor)
+	c.EndBlock()
+	return scope
+}
+
+var _ EffectScope = (*effectScope)(nil)
+var _ state.RememberObserver = (*effectScope)(nil)
+
+type effectScope struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	clock  frameclock.MonotonicFrameClock
+}
+
+func newEffectScope(clock frameclock.MonotonicFrameClock) *effectScope {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &effectScope{
+		ctx:    ctx,
+		cancel: cancel,
+		clock:  clock,
+	}
+}
+
+func (s *effectScope) Launch(block func(ctx context.Context)) {
+	if s.ctx.Err() != nil {
+		return
+	}
+	frameclock.Go(s.ctx, s.clock, block)
+}
+
+func (s *effectScope) OnRemembered() {}
+
+func (s *effectScope) OnForgotten() {
+	s.cancel()
+}
@@ -2,63 +2,156 @@ package effect
 
 import (
 	"context"
-	"fmt"
 	"reflect"
+	"sync"
 
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
 )
 
+// emptyWidgetConstructor lays out the node of an effect, which draws nothing,
+// with a zero size.
+var emptyWidgetConstructor = layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+	return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+		return layoutnode.LayoutDimensions{}
+	}
+})
+
 // LaunchedEffect runs a side-effect in a goroutine.
-// The effect is restarted if any of the keys change.
+// The effect is restarted if any of the keys change, and its context is
+// cancelled when the LaunchedEffect leaves the composition.
+//
+// The context carries the frame clock of the composition: effects wait with
+// frameclock.Delay and frameclock.WithFrameNanos rather than on the time of the
+// system, so that tests control them.
 func LaunchedEffect(block func(context.Context), keys ...any) api.Composable {
 	return func(c api.Composer) api.Composer {
 		c.StartBlock("LaunchedEffect")
 
-		// Key for the persistent state, ensuring uniqueness per node using its ID.
-		// Since State() might be global in this implementation, we need to scope it manually.
-		stateKey := fmt.Sprintf("launched_effect_%d", c.GetID().Value())
+		effect := c.Remember("launched_effect", func() any {
+			return &launchedEffect{}
+		}).(*launchedEffect)
+
+		// Copy keys to ensure we store a snapshot (though variadic slice is usually fresh)
+		keysCopy := make([]any, len(keys))
+		copy(keysCopy, keys)
+		clock := frameclock.LocalFrameClock.Current(c)
 
-		effectState := c.State(stateKey, func() any {
-			return &launchEffectState{}
+		// The goroutine is started once the composition has been applied, so an
+		// effect never runs for a composition that did not complete.
+		c.SideEffect(func() {
+			effect.restartIfNeeded(clock, block, keysCopy)
 		})
 
-		state := effectState.Get().(*launchEffectState)
-
-		// Check if keys changed
-		keysChanged := !reflect.DeepEqual(state.lastKeys, keys)
-
-		if keysChanged {
-			// Cancel previous
-			if state.cancel != nil {
-				state.cancel()
-			}
-
-			// Start new
-			ctx, cancel := context.WithCancel(context.Background())
-			state.cancel = cancel
-			// Copy keys to ensure we store a snapshot (though variadic slice is usually fresh)
-			keysCopy := make([]any, len(keys))
-			copy(keysCopy, keys)
-			state.lastKeys = keysCopy
-
-			go func() {
-				block(ctx)
-			}()
-		}
-
-		// Set a dummy widget constructor that does nothing (zero size)
-		c.SetWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
-			return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
-				return layoutnode.LayoutDimensions{}
-			}
-		}))
+		c.SetWidgetConstructor(emptyWidgetConstructor)
 
 		return c.EndBlock()
 	}
 }
 
-type launchEffectState struct {
+var _ state.RememberObserver = (*launchedEffect)(nil)
+
+type launchedEffect struct {
+	mu       sync.Mutex
 	cancel   context.CancelFunc
 	lastKeys []any
 }
+
+func (e *launchedEffect) restartIfNeeded(clock frameclock.MonotonicFrameClock, block func(context.Context), keys []any) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	// Check if keys changed
+	if e.cancel != nil && reflect.DeepEqual(e.lastKeys, keys) {
+		return
+	}
+
+	// Cancel previous
+	if e.cancel != nil {
+		e.cancel()
+	}
+
+	// Start new
+	ctx, cancel := context.WithCancel(context.Background())
+	e.cancel = cancel
+	e.lastKeys = keys
+
+	frameclock.Go(ctx, clock, block)
+}
+
+func (e *launchedEffect) OnRemembered() {}
+
+func (e *launchedEffect) OnForgotten() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+}
+
+// DisposableEffect runs effect once the composition has been applied and calls
+// the onDispose function it returns when the keys change, before running effect
+// again, or when the DisposableEffect leaves the composition.
+func DisposableEffect(effect func() (onDispose func()), keys ...any) api.Composable {
+	return func(c api.Composer) api.Composer {
+		c.StartBlock("DisposableEffect")
+
+		disposable := c.Remember("disposable_effect", func() any {
+			return &disposableEffect{}
+		}).(*disposableEffect)
+
+		keysCopy := make([]any, len(keys))
+		copy(keysCopy, keys)
+
+		c.SideEffect(func() {
+			disposable.runIfNeeded(effect, keysCopy)
+		})
+
+		c.SetWidgetConstructor(emptyWidgetConstructor)
+		return c.EndBlock()
+	}
+}
+
+var _ state.RememberObserver = (*disposableEffect)(nil)
+
+type disposableEffect struct {
+	started   bool
+	lastKeys  []any
+	onDispose func()
+}
+
+func (e *disposableEffect) runIfNeeded(effect func() func(), keys []any) {
+	if e.started && reflect.DeepEqual(e.lastKeys, keys) {
+		return
+	}
+	e.dispose()
+	e.started = true
+	e.lastKeys = keys
+	e.onDispose = effect()
+}
+
+func (e *disposableEffect) dispose() {
+	if e.onDispose != nil {
+		e.onDispose()
+	}
+	e.onDispose = nil
+	e.started = false
+}
+
+func (e *disposableEffect) OnRemembered() {}
+
+func (e *disposableEffect) OnForgotten() {
+	e.dispose()
+}
+
+// SideEffect runs effect after every composition that includes it has been
+// applied. Use it to publish composition state to objects not managed by it.
+func SideEffect(effect func()) api.Composable {
+	return func(c api.Composer) api.Composer {
+		c.SideEffect(effect)
+		return c
+	}
+}
@@ -0,0 +1,165 @@
+package effect_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/effect"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+func TestDisposableEffectLifecycle(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	var events []string
+	frame := func(show bool, key int) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		c.When(show, effect.DisposableEffect(func() func() {
+			events = append(events, "effect")
+			return func() { events = append(events, "dispose") }
+		}, key))(c)
+		effect.SideEffect(func() { events = append(events, "side") })(c)
+		c.EndBlock()
+		c.Build()
+	}
+
+	expect := func(want ...string) {
+		t.Helper()
+		if len(events) != len(want) {
+			t.Fatalf("Expected events %v, got %v", want, events)
+		}
+		for i := range want {
+			if events[i] != want[i] {
+				t.Fatalf("Expected events %v, got %v", want, events)
+			}
+		}
+		events = nil
+	}
+
+	frame(true, 1)
+	expect("effect", "side")
+	frame(true, 1)
+	expect("side")
+	frame(true, 2)
+	expect("dispose", "effect", "side")
+	frame(false, 2)
+	expect("dispose", "side")
+}
+
+func TestLaunchedEffectIsCancelledWhenRemoved(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	done := make(chan struct{})
+	frame := func(show bool) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		c.When(show, effect.LaunchedEffect(func(ctx context.Context) {
+			<-ctx.Done()
+			close(done)
+		}))(c)
+		c.EndBlock()
+		c.Build()
+	}
+
+	frame(true)
+	frame(true)
+	select {
+	case <-done:
+		t.Fatal("Expected effect to keep running while in the composition")
+	default:
+	}
+
+	frame(false)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected effect to be cancelled after leaving the composition")
+	}
+}
+
+func TestEffectScopeIsCancelledWhenRemoved(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	var scopes []effect.EffectScope
+	frame := func(show bool) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		c.When(show, func(c compose.Composer) compose.Composer {
+			scopes = append(scopes, effect.RememberEffectScope(c))
+			return c
+		})(c)
+		c.EndBlock()
+		c.Build()
+	}
+
+	frame(true)
+	frame(true)
+	if scopes[0] != scopes[1] {
+		t.Fatal("Expected the effect scope to survive recomposition")
+	}
+
+	done := make(chan struct{})
+	scopes[0].Launch(func(ctx context.Context) {
+		<-ctx.Done()
+		close(done)
+	})
+	frame(false)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected launched work to be cancelled after leaving the composition")
+	}
+
+	scopes[0].Launch(func(ctx context.Context) {
+		t.Error("Expected Launch on a cancelled scope to do nothing")
+	})
+}
+
+func TestEffectsRememberInTheirOwnGroup(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	// The caller remembers values under the keys the effects use; the effects
+	// showing up before them must not take them over.
+	frame := func(show bool) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		if show {
+			effect.DisposableEffect(func() func() { return func() {} })(c)
+			effect.RememberEffectScope(c)
+		}
+		if got := c.Remember("disposable_effect", func() any { return "mine" }); got != "mine" {
+			t.Errorf("remembered %v under disposable_effect, want mine", got)
+		}
+		if got := c.Remember("effect_scope", func() any { return "mine" }); got != "mine" {
+			t.Errorf("remembered %v under effect_scope, want mine", got)
+		}
+		c.EndBlock()
+		c.Build()
+	}
+
+	frame(false)
+	frame(true)
+	frame(true)
+}
+
+func TestDisposeCompositionCancelsEffects(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	disposed := false
+	c := compose.NewComposer(mockStore)
+	c.StartBlock("Root")
+	effect.DisposableEffect(func() func() {
+		return func() { disposed = true }
+	})(c)
+	c.EndBlock()
+	c.Build()
+
+	compose.DisposeComposition(mockStore)
+	if !disposed {
+		t.Error("Expected disposing the composition to dispose its effects")
+	}
+}
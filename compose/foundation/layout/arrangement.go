@@ -0,0 +1,57 @@
+package layout
+
+import "github.com/zodimo/go-compose/compose/ui/unit"
+
+// ArrangementAlignment is where children that do not fill the main axis of a
+// layout are placed, as a group, along it.
+type ArrangementAlignment int
+
+const (
+	// ArrangeStart places the children at the start of the layout.
+	ArrangeStart ArrangementAlignment = iota
+	// ArrangeCenter places the children in the middle of the layout.
+	ArrangeCenter
+	// ArrangeEnd places the children at the end of the layout.
+	ArrangeEnd
+)
+
+// Arrangement describes how the children of a layout are placed along its
+// main axis: the space between each of them, and where they are placed when
+// they do not fill the layout.
+type Arrangement struct {
+	Spacing   unit.Dp
+	Alignment ArrangementAlignment
+}
+
+// ArrangementStart places children next to each other from the start.
+var ArrangementStart = Arrangement{Alignment: ArrangeStart}
+
+// ArrangementCenter places children next to each other in the middle.
+var ArrangementCenter = Arrangement{Alignment: ArrangeCenter}
+
+// ArrangementEnd places children next to each other at the end.
+var ArrangementEnd = Arrangement{Alignment: ArrangeEnd}
+
+// SpacedBy places children from the start, space apart.
+func SpacedBy(space unit.Dp) Arrangement {
+	return Arrangement{Spacing: space, Alignment: ArrangeStart}
+}
+
+// SpacedBy returns a copy of a with space between the children.
+func (a Arrangement) SpacedBy(space unit.Dp) Arrangement {
+	a.Spacing = space
+	return a
+}
+
+// Offset returns the offset of children occupying size of a layout of
+// layoutSize along the main axis.
+func (a Arrangement) Offset(size, layoutSize int) int {
+	free := max(layoutSize-size, 0)
+	switch a.Alignment {
+	case ArrangeCenter:
+		return free / 2
+	case ArrangeEnd:
+		return free
+	}
+	return 0
+}
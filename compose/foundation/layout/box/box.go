@@ -27,7 +27,7 @@ func Box(content Composable, options ...BoxOption) Composable {
 }
 
 func boxWidgetConstructor(options BoxOptions) layoutnode.LayoutNodeWidgetConstructor {
-	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+	return layoutnode.NewIntrinsicWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
 
 			stackChildren := []StackChild{}
@@ -52,6 +52,35 @@ func boxWidgetConstructor(options BoxOptions) layoutnode.LayoutNodeWidgetConstru
 				Alignment: options.Alignment,
 			}.Layout(gtx, stackChildren...)
 		}
-	})
+	}), boxIntrinsics{})
 
 }
+
+var _ layoutnode.Intrinsics = (*boxIntrinsics)(nil)
+
+// boxIntrinsics sizes a box to its largest child.
+type boxIntrinsics struct{}
+
+func largest(measurables []layoutnode.IntrinsicMeasurable, size int, intrinsic func(layoutnode.IntrinsicMeasurable, int) int) int {
+	result := 0
+	for _, m := range measurables {
+		result = max(result, intrinsic(m, size))
+	}
+	return result
+}
+
+func (boxIntrinsics) MinIntrinsicWidth(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, height int) int {
+	return largest(measurables, height, layoutnode.IntrinsicMeasurable.MinIntrinsicWidth)
+}
+
+func (boxIntrinsics) MaxIntrinsicWidth(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, height int) int {
+	return largest(measurables, height, layoutnode.IntrinsicMeasurable.MaxIntrinsicWidth)
+}
+
+func (boxIntrinsics) MinIntrinsicHeight(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, width int) int {
+	return largest(measurables, width, layoutnode.IntrinsicMeasurable.MinIntrinsicHeight)
+}
+
+func (boxIntrinsics) MaxIntrinsicHeight(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, width int) int {
+	return largest(measurables, width, layoutnode.IntrinsicMeasurable.MaxIntrinsicHeight)
+}
@@ -0,0 +1,81 @@
+package box
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/layout"
+	"github.com/zodimo/go-compose/compose/ui/unit"
+)
+
+// BoxWithConstraintsScope gives the content of BoxWithConstraints the
+// constraints of the box.
+type BoxWithConstraintsScope interface {
+	// Constraints returns the constraints of the box, in pixels.
+	Constraints() unit.Constraints
+
+	// MinWidth returns the minimum width of the box.
+	MinWidth() unit.Dp
+	// MaxWidth returns the maximum width of the box, DpInfinity when unbounded.
+	MaxWidth() unit.Dp
+	// MinHeight returns the minimum height of the box.
+	MinHeight() unit.Dp
+	// MaxHeight returns the maximum height of the box, DpInfinity when unbounded.
+	MaxHeight() unit.Dp
+}
+
+// BoxWithConstraints is a Box whose content is composed once the constraints
+// of the box are known, so that it can branch on the available size:
+//
+//	box.BoxWithConstraints(func(scope box.BoxWithConstraintsScope) box.Composable {
+//		if scope.MaxWidth() < 600 {
+//			return Compact()
+//		}
+//		return Expanded()
+//	})
+func BoxWithConstraints(content func(scope BoxWithConstraintsScope) Composable, options ...BoxOption) Composable {
+	opts := DefaultBoxOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return layout.SubcomposeLayout(func(scope layout.SubcomposeMeasureScope, constraints layout.Constraints) layout.MeasureResult {
+		contentScope := boxWithConstraintsScope{constraints: constraints, density: scope.Density()}
+		measurables := scope.Subcompose("content", Box(content(contentScope), WithAlignment(opts.Alignment)))
+
+		width, height := constraints.MinWidth(), constraints.MinHeight()
+		placeables := make([]layout.Placeable, len(measurables))
+		for i, measurable := range measurables {
+			placeables[i] = measurable.Measure(constraints)
+			width = max(width, placeables[i].Width())
+			height = max(height, placeables[i].Height())
+		}
+		return scope.Layout(width, height, func(scope layout.PlacementScope) {
+			for _, placeable := range placeables {
+				scope.PlaceRelative(placeable, 0, 0)
+			}
+		})
+	}, layout.WithModifier(opts.Modifier))
+}
+
+var _ BoxWithConstraintsScope = (*boxWithConstraintsScope)(nil)
+
+type boxWithConstraintsScope struct {
+	constraints unit.Constraints
+	density     unit.Density
+}
+
+func (s boxWithConstraintsScope) Constraints() unit.Constraints {
+	return s.constraints
+}
+
+func (s boxWithConstraintsScope) toDp(px int) unit.Dp {
+	if px == unit.Infinity {
+		return unit.DpInfinity
+	}
+	return s.density.IntToDp(px)
+}
+
+func (s boxWithConstraintsScope) MinWidth() unit.Dp  { return s.toDp(s.constraints.MinWidth()) }
+func (s boxWithConstraintsScope) MaxWidth() unit.Dp  { return s.toDp(s.constraints.MaxWidth()) }
+func (s boxWithConstraintsScope) MinHeight() unit.Dp { return s.toDp(s.constraints.MinHeight()) }
+func (s boxWithConstraintsScope) MaxHeight() unit.Dp { return s.toDp(s.constraints.MaxHeight()) }
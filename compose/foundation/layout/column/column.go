@@ -1,6 +1,7 @@
 package column
 
 import (
+	"github.com/zodimo/go-compose/compose/foundation/layout/internal/rowcolumn"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/modifiers/weight"
 
@@ -36,7 +37,7 @@ func Column(content Composable, options ...ColumnOption) Composable {
 }
 
 func columnWidgetConstructor(options ColumnOptions) layoutnode.LayoutNodeWidgetConstructor {
-	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+	return layoutnode.NewIntrinsicWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
 
 			flexedChildren := []layout.FlexChild{}
@@ -65,6 +66,6 @@ func columnWidgetConstructor(options ColumnOptions) layoutnode.LayoutNodeWidgetC
 				Alignment: options.Alignment,
 			}.Layout(gtx, flexedChildren...)
 		}
-	})
+	}), rowcolumn.Intrinsics{Horizontal: false})
 
 }
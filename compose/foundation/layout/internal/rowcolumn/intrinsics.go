@@ -0,0 +1,112 @@
+// Package rowcolumn holds the parts of the row and column layouts they share.
+package rowcolumn
+
+import (
+	"math"
+
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/modifiers/weight"
+)
+
+// Weight returns the weight set on a child by weight.Weight, or 0.
+func Weight(elements layoutnode.ElementStore) float32 {
+	maybeWeightElement := elements.GetElement(weight.WeightElementKey)
+	if maybeWeightElement.IsNone() {
+		return 0
+	}
+	return maybeWeightElement.UnwrapUnsafe().(weight.WeightElement).WeightData().Weight
+}
+
+var _ layoutnode.Intrinsics = (*Intrinsics)(nil)
+
+// Intrinsics computes the intrinsic size of a row, when Horizontal, or of a
+// column. Along the main axis the children are summed, with weighted children
+// sharing the space in proportion to their weight; across it the largest child
+// is taken.
+type Intrinsics struct {
+	Horizontal bool
+}
+
+func (r Intrinsics) MinIntrinsicWidth(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, height int) int {
+	if r.Horizontal {
+		return mainAxisSize(measurables, height, layoutnode.IntrinsicMeasurable.MinIntrinsicWidth)
+	}
+	return crossAxisSize(measurables, height, layoutnode.IntrinsicMeasurable.MaxIntrinsicHeight, layoutnode.IntrinsicMeasurable.MinIntrinsicWidth)
+}
+
+func (r Intrinsics) MaxIntrinsicWidth(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, height int) int {
+	if r.Horizontal {
+		return mainAxisSize(measurables, height, layoutnode.IntrinsicMeasurable.MaxIntrinsicWidth)
+	}
+	return crossAxisSize(measurables, height, layoutnode.IntrinsicMeasurable.MaxIntrinsicHeight, layoutnode.IntrinsicMeasurable.MaxIntrinsicWidth)
+}
+
+func (r Intrinsics) MinIntrinsicHeight(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, width int) int {
+	if r.Horizontal {
+		return crossAxisSize(measurables, width, layoutnode.IntrinsicMeasurable.MaxIntrinsicWidth, layoutnode.IntrinsicMeasurable.MinIntrinsicHeight)
+	}
+	return mainAxisSize(measurables, width, layoutnode.IntrinsicMeasurable.MinIntrinsicHeight)
+}
+
+func (r Intrinsics) MaxIntrinsicHeight(_ layoutnode.MeasureScope, measurables []layoutnode.IntrinsicMeasurable, width int) int {
+	if r.Horizontal {
+		return crossAxisSize(measurables, width, layoutnode.IntrinsicMeasurable.MaxIntrinsicWidth, layoutnode.IntrinsicMeasurable.MaxIntrinsicHeight)
+	}
+	return mainAxisSize(measurables, width, layoutnode.IntrinsicMeasurable.MaxIntrinsicHeight)
+}
+
+type intrinsicFunc = func(measurable layoutnode.IntrinsicMeasurable, size int) int
+
+// mainAxisSize sums the children along the main axis, given the cross axis size.
+func mainAxisSize(measurables []layoutnode.IntrinsicMeasurable, crossAxisSize int, mainAxis intrinsicFunc) int {
+	fixedSpace := 0
+	totalWeight := float32(0)
+	weightUnitSpace := float32(0)
+	for _, m := range measurables {
+		size := mainAxis(m, crossAxisSize)
+		if w := Weight(m.ParentData()); w > 0 {
+			totalWeight += w
+			weightUnitSpace = max(weightUnitSpace, float32(size)/w)
+			continue
+		}
+		fixedSpace += size
+	}
+	return int(math.Round(float64(weightUnitSpace*totalWeight))) + fixedSpace
+}
+
+// crossAxisSize returns the largest child across the main axis, given the main
+// axis size: children without weight take their intrinsic main axis size and
+// weighted children share what is left.
+func crossAxisSize(measurables []layoutnode.IntrinsicMeasurable, mainAxisSize int, mainAxis, crossAxis intrinsicFunc) int {
+	fixedSpace := 0
+	crossAxisMax := 0
+	totalWeight := float32(0)
+	for _, m := range measurables {
+		if w := Weight(m.ParentData()); w > 0 {
+			totalWeight += w
+			continue
+		}
+		space := mainAxis(m, unit.Infinity)
+		if mainAxisSize != unit.Infinity {
+			space = min(space, max(mainAxisSize-fixedSpace, 0))
+		}
+		fixedSpace += space
+		crossAxisMax = max(crossAxisMax, crossAxis(m, space))
+	}
+	if totalWeight == 0 {
+		return crossAxisMax
+	}
+	for _, m := range measurables {
+		w := Weight(m.ParentData())
+		if w <= 0 {
+			continue
+		}
+		space := unit.Infinity
+		if mainAxisSize != unit.Infinity {
+			space = int(math.Round(float64(float32(max(mainAxisSize-fixedSpace, 0)) / totalWeight * w)))
+		}
+		crossAxisMax = max(crossAxisMax, crossAxis(m, space))
+	}
+	return crossAxisMax
+}
@@ -0,0 +1,54 @@
+package rowcolumn
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+)
+
+// text is a child that wraps to the width it is given, keeping its area.
+type text struct {
+	width, height int
+	elements      layoutnode.ElementStore
+}
+
+func (t text) ParentData() layoutnode.ElementStore { return t.elements }
+func (t text) MinIntrinsicWidth(int) int           { return t.width }
+func (t text) MaxIntrinsicWidth(int) int           { return t.width }
+func (t text) MinIntrinsicHeight(width int) int    { return t.heightAt(width) }
+func (t text) MaxIntrinsicHeight(width int) int    { return t.heightAt(width) }
+
+func (t text) heightAt(width int) int {
+	if width == unit.Infinity || width >= t.width || width == 0 {
+		return t.height
+	}
+	return (t.width*t.height + width - 1) / width
+}
+
+func TestRowIntrinsics(t *testing.T) {
+	children := []layoutnode.IntrinsicMeasurable{
+		text{width: 40, height: 10, elements: layoutnode.EmptyElementStore},
+		text{width: 20, height: 30, elements: layoutnode.EmptyElementStore},
+	}
+	row := Intrinsics{Horizontal: true}
+
+	if got := row.MaxIntrinsicWidth(nil, children, unit.Infinity); got != 60 {
+		t.Errorf("MaxIntrinsicWidth = %d, want 60", got)
+	}
+	if got := row.MinIntrinsicHeight(nil, children, unit.Infinity); got != 30 {
+		t.Errorf("MinIntrinsicHeight = %d, want 30", got)
+	}
+	// With 40 pixels the first child takes all of them and the second wraps to nothing.
+	if got := row.MinIntrinsicHeight(nil, children, 40); got != 30 {
+		t.Errorf("MinIntrinsicHeight(40) = %d, want 30", got)
+	}
+
+	column := Intrinsics{}
+	if got := column.MaxIntrinsicHeight(nil, children, unit.Infinity); got != 40 {
+		t.Errorf("column MaxIntrinsicHeight = %d, want 40", got)
+	}
+	if got := column.MinIntrinsicWidth(nil, children, unit.Infinity); got != 40 {
+		t.Errorf("column MinIntrinsicWidth = %d, want 40", got)
+	}
+}
@@ -1,6 +1,7 @@
 package row
 
 import (
+	"github.com/zodimo/go-compose/compose/foundation/layout/internal/rowcolumn"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/modifiers/weight"
 
@@ -36,7 +37,7 @@ func Row(content Composable, options ...RowOption) Composable {
 }
 
 func rowWidgetConstructor(options RowOptions) layoutnode.LayoutNodeWidgetConstructor {
-	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+	return layoutnode.NewIntrinsicWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
 			flexedChildren := []layout.FlexChild{}
 			for _, child := range node.Children() {
@@ -64,6 +65,6 @@ func rowWidgetConstructor(options RowOptions) layoutnode.LayoutNodeWidgetConstru
 				Alignment: options.Alignment,
 			}.Layout(gtx, flexedChildren...)
 		}
-	})
+	}), rowcolumn.Intrinsics{Horizontal: true})
 
 }
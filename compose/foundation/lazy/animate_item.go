@@ -0,0 +1,192 @@
+package lazy
+
+import (
+	"time"
+
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+// DefaultItemAnimationDuration is the duration of the default item animations.
+const DefaultItemAnimationDuration = 300 * time.Millisecond
+
+// Easing adjusts the fraction of an animation, so that it can speed up and slow
+// down rather than move at a constant rate. The easings of material3 are
+// Easings.
+type Easing interface {
+	Transform(fraction float32) float32
+}
+
+// ItemAnimationSpec describes an animation of a lazy item.
+type ItemAnimationSpec struct {
+	Duration time.Duration
+	// Easing is applied to the fraction of the animation; nil is linear.
+	Easing Easing
+}
+
+// NewItemAnimationSpec returns a spec of an animation lasting duration.
+func NewItemAnimationSpec(duration time.Duration, easing Easing) *ItemAnimationSpec {
+	return &ItemAnimationSpec{Duration: duration, Easing: easing}
+}
+
+// value returns the eased fraction of an animation started at start, and
+// whether it has ended by now.
+func (s *ItemAnimationSpec) value(start, now time.Time) (float32, bool) {
+	if s.Duration <= 0 {
+		return 1, true
+	}
+	fraction := float32(now.Sub(start)) / float32(s.Duration)
+	if fraction >= 1 {
+		return 1, true
+	}
+	if s.Easing == nil {
+		return fraction, false
+	}
+	return s.Easing.Transform(fraction), false
+}
+
+// easeOutCubic decelerates towards the end of an animation.
+type easeOutCubic struct{}
+
+func (easeOutCubic) Transform(fraction float32) float32 {
+	inverse := 1 - fraction
+	return 1 - inverse*inverse*inverse
+}
+
+// AnimateItemOption is a functional option for configuring AnimateItem.
+type AnimateItemOption func(*AnimateItemOptions)
+
+// AnimateItemOptions holds the animations of AnimateItem. A nil spec disables
+// its animation.
+type AnimateItemOptions struct {
+	// FadeInSpec fades in items inserted into the list.
+	FadeInSpec *ItemAnimationSpec
+	// PlacementSpec moves items to their new position when items before them
+	// are inserted, removed or reordered.
+	PlacementSpec *ItemAnimationSpec
+	// FadeOutSpec fades out items removed from the list.
+	FadeOutSpec *ItemAnimationSpec
+}
+
+// DefaultAnimateItemOptions returns the default animations of AnimateItem.
+func DefaultAnimateItemOptions() AnimateItemOptions {
+	return AnimateItemOptions{
+		FadeInSpec:    NewItemAnimationSpec(DefaultItemAnimationDuration, easeOutCubic{}),
+		PlacementSpec: NewItemAnimationSpec(DefaultItemAnimationDuration, easeOutCubic{}),
+		FadeOutSpec:   NewItemAnimationSpec(DefaultItemAnimationDuration, easeOutCubic{}),
+	}
+}
+
+func WithFadeInSpec(spec *ItemAnimationSpec) AnimateItemOption {
+	return func(o *AnimateItemOptions) {
+		o.FadeInSpec = spec
+	}
+}
+
+func WithPlacementSpec(spec *ItemAnimationSpec) AnimateItemOption {
+	return func(o *AnimateItemOptions) {
+		o.PlacementSpec = spec
+	}
+}
+
+func WithFadeOutSpec(spec *ItemAnimationSpec) AnimateItemOption {
+	return func(o *AnimateItemOptions) {
+		o.FadeOutSpec = spec
+	}
+}
+
+// AnimateItem animates the item of a lazy list whose content it modifies, as
+// identified by the key of the item: the item fades in when it is inserted,
+// moves to its new position when items before it change, and fades out when
+// it is removed.
+//
+//	scope.Items(len(messages), func(i int) any { return messages[i].ID }, func(i int) compose.Composable {
+//		return Message(messages[i], box.WithModifier(lazy.AnimateItem()))
+//	})
+func AnimateItem(options ...AnimateItemOption) modifier.Modifier {
+	opts := DefaultAnimateItemOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return modifier.NewInspectableModifier(
+		modifier.NewModifier(&AnimateItemElement{options: opts}),
+		modifier.NewInspectorInfo(
+			"animateItem",
+			map[string]any{
+				"fadeInSpec":    opts.FadeInSpec,
+				"placementSpec": opts.PlacementSpec,
+				"fadeOutSpec":   opts.FadeOutSpec,
+			},
+		),
+	)
+}
+
+// AnimateItemElementKey is the key of the AnimateItemElement in the elements
+// of a node, read by the lazy list laying out the node.
+const AnimateItemElementKey = "animateItem"
+
+var _ modifier.Element = (*AnimateItemElement)(nil)
+
+type AnimateItemElement struct {
+	options AnimateItemOptions
+}
+
+func (e AnimateItemElement) Create() node.Node {
+	return newAnimateItemNode(e)
+}
+
+func (e AnimateItemElement) Update(n node.Node) {
+	if n == nil {
+		panic("node cannot be nil")
+	}
+	n.(*animateItemNode).options = e.options
+}
+
+func (e AnimateItemElement) Equals(other modifier.Element) bool {
+	if otherElement, ok := other.(*AnimateItemElement); ok {
+		return e.options == otherElement.options
+	}
+	return false
+}
+
+func (e AnimateItemElement) Options() AnimateItemOptions {
+	return e.options
+}
+
+var _ node.ChainNode = (*animateItemNode)(nil)
+
+type animateItemNode struct {
+	node.ChainNode
+	options AnimateItemOptions
+}
+
+func newAnimateItemNode(element AnimateItemElement) *animateItemNode {
+	n := &animateItemNode{options: element.options}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		func(t node.TreeNode) {
+			t.(layoutnode.ParentDataModifierNode).AttachParentDataModifier(func(store layoutnode.ElementStore) layoutnode.ElementStore {
+				return store.SetElement(AnimateItemElementKey, AnimateItemElement{options: n.options})
+			})
+		},
+	)
+	return n
+}
+
+// itemAnimations returns the animations of AnimateItem applied to the content
+// of item, once laid out.
+func itemAnimations(item layoutnode.NodeCoordinator) (AnimateItemOptions, bool) {
+	for _, child := range item.Children() {
+		element := child.(layoutnode.NodeCoordinator).Elements().GetElement(AnimateItemElementKey)
+		if element.IsSome() {
+			return element.UnwrapUnsafe().(AnimateItemElement).Options(), true
+		}
+	}
+	return AnimateItemOptions{}, false
+}
@@ -1,7 +1,6 @@
 package lazy
 
 import (
-	"fmt"
 	"image"
 
 	"github.com/zodimo/go-compose/compose"
@@ -40,10 +39,7 @@ func lazyGrid(axis layout.Axis, cells GridCells, content func(LazyGridScope), op
 
 		// Ensure state is initialized
 		if opts.State == nil {
-			id := c.GenerateID()
-			path := c.GetPath()
-			key := fmt.Sprintf("%d/%s/lazyGridState", id, path)
-			opts.State = c.State(key, func() any { return NewLazyGridState() }).Get().(*LazyGridState)
+			opts.State = RememberLazyGridState(c)
 		}
 
 		c.StartBlock("LazyGrid")
@@ -60,8 +56,10 @@ func lazyGrid(axis layout.Axis, cells GridCells, content func(LazyGridScope), op
 			c.WithComposable(item.Content)
 		}
 
+		opts.State.observe()
+
 		// Store cells and axis for widget constructor
-		c.SetWidgetConstructor(lazyGridWidgetConstructor(opts.State, axis, cells))
+		c.SetWidgetConstructor(lazyGridWidgetConstructor(opts.State, axis, cells, scope))
 
 		return c.EndBlock()
 	}
@@ -71,6 +69,7 @@ func lazyGridWidgetConstructor(
 	state *LazyGridState,
 	axis layout.Axis,
 	cells GridCells,
+	scope *lazyGridScopeImpl,
 ) layoutnode.LayoutNodeWidgetConstructor {
 	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
@@ -101,9 +100,16 @@ func lazyGridWidgetConstructor(
 			}
 
 			// Set up list axis
-			state.List.List.Axis = axis
+			list := &state.List
+			list.List.Axis = axis
+			lines := scrollLines{count: rowCount, itemsPerLine: cellCount}
+			state.startLayout(gtx, list, lines)
+
+			// Sizes of the cells and the rows laid out, for the layout info
+			cellSizes := map[int]image.Point{}
+			rowSizes := map[int]int{}
 
-			return state.List.List.Layout(gtx, rowCount, func(gtx C, rowIndex int) D {
+			dims := list.List.Layout(gtx, rowCount, func(gtx C, rowIndex int) D {
 				// Calculate range of items for this row
 				startIdx := rowIndex * cellCount
 				endIdx := startIdx + cellCount
@@ -121,6 +127,7 @@ func lazyGridWidgetConstructor(
 					// Capture for closure
 					capturedCoordinator := childCoordinator
 					capturedCellSize := cellSize
+					capturedIndex := i
 
 					flexChildren = append(flexChildren, layout.Rigid(func(gtx C) D {
 						// Constrain cell size in the cross-axis direction
@@ -132,7 +139,9 @@ func lazyGridWidgetConstructor(
 							gtx.Constraints.Max.Y = capturedCellSize
 						}
 
-						return capturedCoordinator.Layout(gtx)
+						dims := capturedCoordinator.Layout(gtx)
+						cellSizes[capturedIndex] = dims.Size
+						return dims
 					}))
 				}
 
@@ -155,8 +164,35 @@ func lazyGridWidgetConstructor(
 					rowAxis = layout.Vertical
 				}
 
-				return layout.Flex{Axis: rowAxis}.Layout(gtx, flexChildren...)
+				dims := layout.Flex{Axis: rowAxis}.Layout(gtx, flexChildren...)
+				rowSizes[rowIndex] = axis.Convert(dims.Size).X
+				return dims
 			})
+
+			position := list.Position
+			info := LazyGridLayoutInfo{
+				TotalItemsCount:   itemCount,
+				ViewportEndOffset: axis.Convert(dims.Size).X,
+				ViewportSize:      dims.Size,
+			}
+			offset := -position.Offset
+			for row := position.First; row < position.First+position.Count; row++ {
+				for i := row * cellCount; i < min((row+1)*cellCount, itemCount); i++ {
+					column := i - row*cellCount
+					info.VisibleItemsInfo = append(info.VisibleItemsInfo, LazyGridItemInfo{
+						Index:  i,
+						Key:    scope.items[i].Key,
+						Row:    row,
+						Column: column,
+						Offset: axis.Convert(image.Pt(offset, column*cellSize)),
+						Size:   cellSizes[i],
+					})
+				}
+				offset += rowSizes[row]
+			}
+			state.layoutInfo.Set(info)
+			state.endLayout(gtx, list, lines)
+			return dims
 		}
 	})
 }
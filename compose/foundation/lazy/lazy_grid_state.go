@@ -1,17 +1,48 @@
 package lazy
 
 import (
-	"fmt"
+	"image"
 
 	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
 
 	"gioui.org/layout"
 	"gioui.org/widget"
 )
 
 // LazyGridState holds the state for a lazy grid, including scroll position.
+// Like LazyListState, it controls and observes the scrolling of the grid; its
+// item indices are those of the items, not of the rows.
 type LazyGridState struct {
 	List widget.List
+	scrollState
+
+	layoutInfo state.MutableState[LazyGridLayoutInfo]
+}
+
+// LazyGridLayoutInfo describes the last layout of a lazy grid.
+type LazyGridLayoutInfo struct {
+	// VisibleItemsInfo holds the items that are laid out, in order.
+	VisibleItemsInfo []LazyGridItemInfo
+	TotalItemsCount  int
+	// ViewportStartOffset and ViewportEndOffset are the bounds of the viewport
+	// along the scrolling axis of the grid.
+	ViewportStartOffset int
+	ViewportEndOffset   int
+	ViewportSize        image.Point
+}
+
+// LazyGridItemInfo describes an item of the last layout of a lazy grid.
+type LazyGridItemInfo struct {
+	Index int
+	Key   any
+	// Row and Column are the line of the item along the scrolling axis and
+	// its cell across it.
+	Row    int
+	Column int
+	// Offset is the position of the item relative to the start of the viewport.
+	Offset image.Point
+	Size   image.Point
 }
 
 // NewLazyGridState creates a new LazyGridState with default configuration.
@@ -22,14 +53,29 @@ func NewLazyGridState() *LazyGridState {
 				Axis: layout.Vertical,
 			},
 		},
+		scrollState: newScrollState(),
+		layoutInfo:  state.NewMutableState(LazyGridLayoutInfo{}, nil),
 	}
 }
 
+// LayoutInfo returns the items laid out by the last layout of the grid.
+func (s *LazyGridState) LayoutInfo() LazyGridLayoutInfo {
+	return s.layoutInfo.Get()
+}
+
 // RememberLazyGridState creates or retrieves a remembered LazyGridState.
+// Its scroll position is saveable, so it is restored after a restart.
 func RememberLazyGridState(c compose.Composer) *LazyGridState {
-	id := c.GenerateID()
-	key := fmt.Sprintf("lazyGridState-%v", id)
-	return c.State(key, func() any {
-		return NewLazyGridState()
-	}).Get().(*LazyGridState)
+	return state.RememberSaveable(c, "lazyGridState", NewLazyGridState, LazyGridStateSaver).Get()
 }
+
+// LazyGridStateSaver saves the scroll position of a LazyGridState.
+var LazyGridStateSaver = state.NewSaver(
+	func(s *LazyGridState) ([]byte, error) {
+		return saveScrollPosition(&s.List)
+	},
+	func(data []byte) (*LazyGridState, error) {
+		s := NewLazyGridState()
+		return s, restoreScrollPosition(&s.List, data)
+	},
+)
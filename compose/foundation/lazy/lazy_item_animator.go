@@ -0,0 +1,212 @@
+package lazy
+
+import (
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+)
+
+// keyWindow is the number of items on each side of the visible ones whose keys
+// are tracked, to tell items inserted into or removed from the list from items
+// scrolled into or out of view.
+const keyWindow = 100
+
+// itemAnimator animates the items with AnimateItem of a lazy list, by their
+// keys. Positions are along the axis of the list, relative to the start of the
+// viewport.
+//
+// The items laid out in a frame are compared to those of the previous frame:
+// an item whose index changed moves from where it was drawn, an item whose key
+// is new fades in, and an item whose key is gone fades out where it was drawn.
+// The layout passes of a frame compare to the same previous frame, so they
+// agree on the animations.
+type itemAnimator struct {
+	frame    lazyFrame
+	now      time.Time
+	laidOut  bool
+	previous animatorFrame
+	current  animatorFrame
+
+	animations   map[any]*itemAnimation
+	disappearing map[any]*disappearingItem
+}
+
+// animatorFrame holds the items laid out in a frame, and the keys around them.
+type animatorFrame struct {
+	placed map[any]placedItem
+	keys   map[any]int
+	first  int
+	last   int
+}
+
+type placedItem struct {
+	index int
+	start int
+	size  int
+	// displacement is the offset of the item from start when drawn.
+	displacement int
+	options      AnimateItemOptions
+}
+
+type itemAnimation struct {
+	// from is the displacement of the item when it started moving.
+	from           int
+	placementStart time.Time
+	placement      *ItemAnimationSpec
+	fadeStart      time.Time
+	fadeIn         *ItemAnimationSpec
+}
+
+// disappearingItem is an item removed from the list, drawn while it fades out.
+type disappearingItem struct {
+	placedItem
+	fadeStart time.Time
+}
+
+func rememberItemAnimator(c compose.Composer) *itemAnimator {
+	return c.Remember("itemAnimator", func() any {
+		return &itemAnimator{
+			animations:   map[any]*itemAnimation{},
+			disappearing: map[any]*disappearingItem{},
+		}
+	}).(*itemAnimator)
+}
+
+// startPass starts a layout pass of the frame of items.
+func (a *itemAnimator) startPass(frame lazyFrame, now time.Time) {
+	if frame != a.frame {
+		if a.current.placed != nil {
+			a.previous = a.current
+			a.laidOut = true
+		}
+		a.frame = frame
+		a.now = now
+	}
+	a.current = animatorFrame{placed: map[any]placedItem{}}
+}
+
+// transform returns the displacement and the opacity of the item with key.
+func (a *itemAnimator) transform(key any) (int, float32) {
+	animation, ok := a.animations[key]
+	if !ok {
+		return 0, 1
+	}
+	displacement, alpha := 0, float32(1)
+	if animation.placement != nil {
+		fraction, _ := animation.placement.value(animation.placementStart, a.now)
+		displacement = int(float32(animation.from) * (1 - fraction))
+	}
+	if animation.fadeIn != nil {
+		alpha, _ = animation.fadeIn.value(animation.fadeStart, a.now)
+	}
+	return displacement, alpha
+}
+
+// place records the item with key laid out at index, from start. It reports
+// whether an animation of the item started.
+func (a *itemAnimator) place(key any, index, start, size int, options AnimateItemOptions) bool {
+	started := false
+	if previous, ok := a.previous.placed[key]; ok {
+		from := previous.start + previous.displacement - start
+		if previous.index != index && from != 0 && options.PlacementSpec != nil {
+			animation := a.animation(key)
+			if animation.placementStart != a.now {
+				started = true
+			}
+			animation.from, animation.placementStart, animation.placement = from, a.now, options.PlacementSpec
+		}
+	} else if a.isInserted(key, index) && options.FadeInSpec != nil {
+		animation := a.animation(key)
+		if animation.fadeStart != a.now {
+			started = true
+		}
+		animation.fadeStart, animation.fadeIn = a.now, options.FadeInSpec
+	}
+	displacement, _ := a.transform(key)
+	a.current.placed[key] = placedItem{index: index, start: start, size: size, displacement: displacement, options: options}
+	return started
+}
+
+// isInserted reports whether the item with key at index was inserted since
+// the previous frame, rather than scrolled into view: its key was not around
+// the items of the previous frame.
+func (a *itemAnimator) isInserted(key any, index int) bool {
+	if !a.laidOut || index < a.previous.first-keyWindow || index > a.previous.last+keyWindow {
+		return false
+	}
+	_, seen := a.previous.keys[key]
+	return !seen
+}
+
+func (a *itemAnimator) animation(key any) *itemAnimation {
+	animation, ok := a.animations[key]
+	if !ok {
+		animation = &itemAnimation{}
+		a.animations[key] = animation
+	}
+	return animation
+}
+
+// endPass records the keys of the items from first to last, as returned by
+// key, and starts fading out the items drawn in the previous frame whose keys
+// are gone. It removes the animations that ended, and reports whether any is
+// still running.
+func (a *itemAnimator) endPass(first, last, count int, key func(index int) any) bool {
+	a.current.first, a.current.last = first, last
+	a.current.keys = map[any]int{}
+	for i := max(first-keyWindow, 0); i < min(last+1+keyWindow, count); i++ {
+		a.current.keys[key(i)] = i
+	}
+	for key, previous := range a.previous.placed {
+		if _, ok := a.current.keys[key]; ok || previous.options.FadeOutSpec == nil {
+			continue
+		}
+		if _, ok := a.disappearing[key]; !ok {
+			a.disappearing[key] = &disappearingItem{placedItem: previous, fadeStart: a.now}
+		}
+	}
+
+	running := false
+	for key, animation := range a.animations {
+		ended := true
+		if animation.placement != nil {
+			_, done := animation.placement.value(animation.placementStart, a.now)
+			ended = ended && done
+		}
+		if animation.fadeIn != nil {
+			_, done := animation.fadeIn.value(animation.fadeStart, a.now)
+			ended = ended && done
+		}
+		if ended {
+			delete(a.animations, key)
+		} else {
+			running = true
+		}
+	}
+	for key, item := range a.disappearing {
+		if _, ok := a.current.keys[key]; ok {
+			// The item is back.
+			delete(a.disappearing, key)
+			continue
+		}
+		if _, done := item.options.FadeOutSpec.value(item.fadeStart, a.now); done {
+			delete(a.disappearing, key)
+		} else {
+			running = true
+		}
+	}
+	return running
+}
+
+// fadingOut returns the opacity of a removed item that is fading out.
+func (a *itemAnimator) fadingOut(item *disappearingItem) float32 {
+	fraction, _ := item.options.FadeOutSpec.value(item.fadeStart, a.now)
+	return 1 - fraction
+}
+
+// isDisappearing reports whether the item with key is fading out, so that its
+// composition must be kept.
+func (a *itemAnimator) isDisappearing(key any) bool {
+	_, ok := a.disappearing[key]
+	return ok
+}
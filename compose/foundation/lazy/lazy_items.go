@@ -0,0 +1,187 @@
+package lazy
+
+import (
+	"fmt"
+	"image"
+	"sort"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// lazyContent is the content of a lazy layout, as declared by its scope.
+type lazyContent interface {
+	itemCount() int
+	item(index int) lazyItemContent
+	key(index int) any
+}
+
+// indexKey is the key of an item declared without one.
+type indexKey int
+
+func (k indexKey) String() string {
+	return fmt.Sprintf("index:%d", int(k))
+}
+
+// lazyItems composes the items of a lazy layout on demand, while the layout
+// is laid out, each in its own slot of the subcomposition of the layout. The
+// slots are keyed by the keys of the items, so an item keeps its state when
+// items before it are added or removed.
+//
+// An item is composed at most once per frame. Once a layout pass is done the
+// items around the visible ones are composed ahead of time, up to
+// prefetchDistance on each side, and the composition of the other items is
+// disposed, except for the retainedItems of each content type that were
+// visible most recently. An item whose content type changes is composed anew.
+type lazyItems struct {
+	subcomposition   compose.Subcomposition
+	content          lazyContent
+	prefetchDistance int
+	retainedItems    int
+
+	generation int
+	pass       int
+	items      map[any]*lazyItem
+	frame      lazyFrame
+}
+
+// lazyFrame identifies a frame: the composition that declared the content and
+// the time of the layout pass.
+type lazyFrame struct {
+	generation int
+	now        int64
+}
+
+type lazyItem struct {
+	key         any
+	contentType any
+	coordinator layoutnode.NodeCoordinator
+	composed    lazyFrame
+	used        int // last layout pass in which the item was visible or prefetched
+}
+
+func rememberLazyItems(c compose.Composer) *lazyItems {
+	subcomposition := c.Subcomposition()
+	return c.Remember("lazyItems", func() any {
+		return &lazyItems{
+			subcomposition: subcomposition,
+			items:          map[any]*lazyItem{},
+		}
+	}).(*lazyItems)
+}
+
+// update sets the content declared by the current composition.
+func (l *lazyItems) update(content lazyContent, prefetchDistance, retainedItems int) {
+	l.content = content
+	l.prefetchDistance = max(prefetchDistance, 0)
+	l.retainedItems = max(retainedItems, 0)
+	l.generation++
+}
+
+// startPass starts a layout pass in the frame of gtx.
+func (l *lazyItems) startPass(gtx layoutnode.LayoutContext) {
+	l.frame = lazyFrame{generation: l.generation, now: gtx.Now.UnixNano()}
+	l.pass++
+}
+
+// compose returns the coordinator of the item at index, composing it unless it
+// was already composed in this frame.
+func (l *lazyItems) compose(index int) layoutnode.NodeCoordinator {
+	content := l.content.item(index)
+	item, ok := l.items[content.key]
+	if ok && item.contentType != content.contentType {
+		l.dispose(item)
+		ok = false
+	}
+	if !ok {
+		item = &lazyItem{key: content.key, contentType: content.contentType}
+		l.items[content.key] = item
+	}
+	item.used = l.pass
+	if item.coordinator != nil && item.composed == l.frame {
+		return item.coordinator
+	}
+	item.composed = l.frame
+
+	root := l.subcomposition.Compose(content.key, content.content)
+	if item.coordinator == nil {
+		item.coordinator = layoutnode.NewNodeCoordinator(root)
+	} else {
+		item.coordinator.Update(root)
+	}
+	return item.coordinator
+}
+
+// endPass prefetches the items within prefetchDistance of the visible items,
+// first to last, and disposes the items that are neither used, kept nor
+// retained. Content types must be comparable.
+func (l *lazyItems) endPass(first, last int, keep func(key any) bool) {
+	count := l.content.itemCount()
+	for i := max(first-l.prefetchDistance, 0); i < min(last+1+l.prefetchDistance, count); i++ {
+		l.compose(i)
+	}
+
+	offscreen := map[any][]*lazyItem{}
+	for _, item := range l.items {
+		if item.used != l.pass && !keep(item.key) {
+			offscreen[item.contentType] = append(offscreen[item.contentType], item)
+		}
+	}
+	// Dispose the items of each type that were used longest ago.
+	for _, items := range offscreen {
+		sort.Slice(items, func(i, j int) bool { return items[i].used < items[j].used })
+		for _, item := range items[:max(len(items)-l.retainedItems, 0)] {
+			l.dispose(item)
+		}
+	}
+}
+
+// coordinator returns the coordinator of the item with key, if composed.
+func (l *lazyItems) coordinator(key any) (layoutnode.NodeCoordinator, bool) {
+	item, ok := l.items[key]
+	if !ok || item.coordinator == nil {
+		return nil, false
+	}
+	return item.coordinator, true
+}
+
+func (l *lazyItems) dispose(item *lazyItem) {
+	if item.coordinator != nil {
+		item.coordinator.Dispose()
+	}
+	delete(l.items, item.key)
+	l.subcomposition.Dispose(item.key)
+}
+
+func (l *lazyItems) OnRemembered() {}
+
+// OnForgotten disposes every item once the layout leaves the composition.
+func (l *lazyItems) OnForgotten() {
+	for _, item := range l.items {
+		l.dispose(item)
+	}
+}
+
+// layoutItem lays out the nodes emitted by an item one after the other along axis.
+func layoutItem(gtx layoutnode.LayoutContext, axis layout.Axis, item layoutnode.NodeCoordinator) layoutnode.LayoutDimensions {
+	children := item.Children()
+	if len(children) == 1 {
+		return children[0].(layoutnode.NodeCoordinator).Layout(gtx)
+	}
+	var size image.Point
+	for _, child := range children {
+		trans := op.Offset(axis.Convert(image.Pt(axis.Convert(size).X, 0))).Push(gtx.Ops)
+		cgtx := gtx
+		cgtx.Constraints.Min = image.Point{}
+		dims := child.(layoutnode.NodeCoordinator).Layout(cgtx)
+		trans.Pop()
+
+		main := axis.Convert(size).X + axis.Convert(dims.Size).X
+		cross := max(axis.Convert(size).Y, axis.Convert(dims.Size).Y)
+		size = axis.Convert(image.Pt(main, cross))
+	}
+	return layoutnode.LayoutDimensions{Size: gtx.Constraints.Constrain(size)}
+}
@@ -1,30 +1,37 @@
 package lazy
 
 import (
-	"fmt"
+	"image"
 
 	"github.com/zodimo/go-compose/compose"
+	foundationLayout "github.com/zodimo/go-compose/compose/foundation/layout"
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/compose/ui/unit"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/internal/modifier"
 
 	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+	gioUnit "gioui.org/unit"
 )
 
 type C = layout.Context
 type D = layout.Dimensions
 
 // LazyColumn is a vertically scrolling list that only composes and lays out currently visible items.
-// Note: Current implementation purely implements Lazy Layout (eager composition).
 func LazyColumn(content func(LazyListScope), options ...LazyListOption) compose.Composable {
 	return lazyList(layout.Vertical, content, options...)
 }
 
 // LazyRow is a horizontally scrolling list that only composes and lays out currently visible items.
-// Note: Current implementation purely implements Lazy Layout (eager composition).
 func LazyRow(content func(LazyListScope), options ...LazyListOption) compose.Composable {
 	return lazyList(layout.Horizontal, content, options...)
 }
 
+// lazyList declares its items during composition and composes them during
+// layout, once the visible window of the list is known. See lazyItems.
 func lazyList(axis layout.Axis, content func(LazyListScope), options ...LazyListOption) compose.Composable {
 	return func(c compose.Composer) compose.Composer {
 		opts := DefaultLazyListOptions()
@@ -33,18 +40,10 @@ func lazyList(axis layout.Axis, content func(LazyListScope), options ...LazyList
 		}
 
 		// Ensure state is initialized
-		// Note: Ideally state should be passed by user. If not, we create a local one,
-		// but since we can't persist it easily without ID generation here (or using Remember internally),
-		// we should encourage passing state.
-		// For now, if state is nil, we create a new one but it won't be persisted across recompositions
-		// unless the user provided a state from RememberLazyListState.
-		// To fix this auto-persistence if nil, we would need to look it up in state.
+		// State keys are scoped to the composition position, so a list without a
+		// user provided state keeps its own across recompositions.
 		if opts.State == nil {
-			id := c.GenerateID()
-			path := c.GetPath()
-			key := fmt.Sprintf("%d/%s/lazyListState", id, path)
-			// Try to get existing or create new
-			opts.State = c.State(key, func() any { return NewLazyListState() }).Get().(*LazyListState)
+			opts.State = RememberLazyListState(c)
 		}
 
 		c.StartBlock("LazyList")
@@ -55,29 +54,306 @@ func lazyList(axis layout.Axis, content func(LazyListScope), options ...LazyList
 		scope := &lazyListScopeImpl{}
 		content(scope)
 
-		// Emit all items as children
-		for _, item := range scope.items {
-			c.WithComposable(item.Content)
-		}
+		items := rememberLazyItems(c)
+		items.update(scope, opts.PrefetchDistance, opts.RetainedItems)
+		opts.State.observe()
+
+		animator := rememberItemAnimator(c)
 
-		c.SetWidgetConstructor(lazyListWidgetConstructor(opts.State, axis))
+		c.SetWidgetConstructor(lazyListWidgetConstructor(opts, axis, scope, items, animator))
 		return c.EndBlock()
 	}
 }
 
-func lazyListWidgetConstructor(state *LazyListState, axis layout.Axis) layoutnode.LayoutNodeWidgetConstructor {
+// lazyElement is an element of the widget.List of a lazy list: an item, with
+// the content padding before it and the spacing or content padding after it.
+type lazyElement struct {
+	index  int
+	key    any
+	before int
+	size   int // of the item, along the axis of the list
+	cross  int // of the item, across the axis of the list
+	length int // of the element, along the axis of the list
+	// animations holds the animations of the item, when animated is set by
+	// AnimateItem.
+	animations AnimateItemOptions
+	animated   bool
+	// header holds the drawing of a sticky header, which is drawn over the
+	// list rather than by it.
+	header *op.CallOp
+}
+
+func lazyListWidgetConstructor(opts LazyListOptions, axis layout.Axis, scope *lazyListScopeImpl, items *lazyItems, animator *itemAnimator) layoutnode.LayoutNodeWidgetConstructor {
+	l := &lazyListLayout{opts: opts, axis: axis, scope: scope, items: items, animator: animator}
+	l.arrangement = opts.VerticalArrangement
+	if axis == layout.Horizontal {
+		l.arrangement = opts.HorizontalArrangement
+	}
 	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
-			// Update axis configuration
-			state.List.List.Axis = axis
+			// Lay the list out again when item animations start, so that
+			// they apply from the frame in which the items changed.
+			macro := op.Record(gtx.Ops)
+			dims, started := l.layout(gtx)
+			call := macro.Stop()
+			if started {
+				macro = op.Record(gtx.Ops)
+				dims, _ = l.layout(gtx)
+				call = macro.Stop()
+			}
+			call.Add(gtx.Ops)
+			return dims
+		}
+	})
+}
+
+// lazyListLayout lays out a lazy list. See layout.
+type lazyListLayout struct {
+	opts        LazyListOptions
+	axis        layout.Axis
+	arrangement foundationLayout.Arrangement
+	scope       *lazyListScopeImpl
+	items       *lazyItems
+	animator    *itemAnimator
+}
+
+// layout lays out and draws the list, and reports whether item animations
+// started.
+func (l *lazyListLayout) layout(gtx layoutnode.LayoutContext) (layoutnode.LayoutDimensions, bool) {
+	opts, axis, scope, items, animator := l.opts, l.axis, l.scope, l.items, l.animator
+	state := opts.State
+	// Update axis configuration
+	state.List.List.Axis = axis
+
+	list := &state.List
+	count := scope.itemCount()
+	lines := scrollLines{count: count, itemsPerLine: 1, reverse: opts.ReverseLayout}
+	state.startLayout(gtx, list, lines)
+
+	padding := resolvePadding(gtx, axis, opts.ContentPadding)
+	spacing := gtx.Dp(gioUnit.Dp(l.arrangement.Spacing))
+	// index returns the item of an element; reverse lists lay out the
+	// first item last.
+	index := func(element int) int {
+		if opts.ReverseLayout {
+			return count - 1 - element
+		}
+		return element
+	}
+
+	items.startPass(gtx)
+	animator.startPass(items.frame, gtx.Now)
+	elements := map[int]*lazyElement{}
+	// The constraints of the elements, which items get without the content
+	// padding across the list.
+	var elementConstraints layout.Constraints
+	itemGtx := func(gtx C) C {
+		cross := max(axis.Convert(gtx.Constraints.Max).Y-padding.crossStart-padding.crossEnd, 0)
+		gtx.Constraints.Max = axis.Convert(image.Pt(axis.Convert(gtx.Constraints.Max).X, cross))
+		gtx.Constraints.Min = axis.Convert(image.Pt(0, min(axis.Convert(gtx.Constraints.Min).Y, cross)))
+		return gtx
+	}
+	layoutElement := func(gtx C, i, before int) (*lazyElement, op.CallOp) {
+		elementConstraints = gtx.Constraints
+		gtx = itemGtx(gtx)
 
-			return state.List.List.Layout(gtx, len(node.Children()), func(gtx C, i int) D {
-				if i < 0 || i >= len(node.Children()) {
-					return D{}
-				}
-				child := node.Children()[i].(layoutnode.NodeCoordinator)
-				return child.Layout(gtx)
-			})
+		macro := op.Record(gtx.Ops)
+		trans := op.Offset(axis.Convert(image.Pt(before, padding.crossStart))).Push(gtx.Ops)
+		coordinator := items.compose(i)
+		dims := layoutItem(gtx, axis, coordinator)
+		trans.Pop()
+		call := macro.Stop()
+		size := axis.Convert(dims.Size)
+		e := &lazyElement{index: i, key: scope.key(i), before: before, size: size.X, cross: size.Y}
+		e.animations, e.animated = itemAnimations(coordinator)
+		return e, call
+	}
+	macro := op.Record(gtx.Ops)
+	dims := list.List.Layout(gtx, count, func(gtx C, element int) D {
+		before, after := 0, spacing
+		if element == 0 {
+			before = padding.start
+		}
+		if element == count-1 {
+			after = padding.end
+		}
+		i := index(element)
+		laidOut, call := layoutElement(gtx, i, before)
+		if scope.isHeader(i) {
+			laidOut.header = &call
+		} else {
+			displacement, alpha := animator.transform(laidOut.key)
+			drawAnimated(gtx, axis, displacement, alpha, call)
 		}
+		laidOut.length = before + laidOut.size + after
+		elements[element] = laidOut
+
+		cross := padding.crossStart + laidOut.cross + padding.crossEnd
+		return D{Size: axis.Convert(image.Pt(laidOut.length, cross))}
 	})
+	listCall := macro.Stop()
+	position := list.Position
+	viewport := axis.Convert(dims.Size).X
+
+	// Arrange the items when they all fit.
+	shift := 0
+	if l.arrangement.Alignment != foundationLayout.ArrangeStart && position.First == 0 && position.Count == count && count > 0 {
+		length := 0
+		for element := 0; element < count; element++ {
+			length += elements[element].length
+		}
+		viewport = max(viewport, axis.Convert(gtx.Constraints.Max).X)
+		shift = l.arrangement.Offset(length, viewport)
+		if opts.ReverseLayout {
+			// The widget.List lays out reverse lists at its end, which
+			// is their start.
+			shift = -shift
+		}
+		dims.Size = axis.Convert(image.Pt(viewport, axis.Convert(dims.Size).Y))
+	}
+
+	// Physical positions of the items, from the start of the viewport,
+	// and their offsets in the direction of the layout.
+	starts := map[int]int{}
+	start := -position.Offset + shift
+	for element := position.First; element < position.First+position.Count; element++ {
+		starts[element] = start
+		start += elements[element].length
+	}
+	offset := func(element int) int {
+		e := elements[element]
+		if opts.ReverseLayout {
+			return viewport - (starts[element] + e.before + e.size)
+		}
+		return starts[element] + e.before
+	}
+	first, last := position.First, position.First+position.Count-1
+	if opts.ReverseLayout {
+		first, last = count-1-last, count-1-first
+	}
+
+	// Compare the animated items to the previous frame.
+	started := false
+	for element := position.First; element < position.First+position.Count; element++ {
+		if e := elements[element]; e.animated {
+			started = animator.place(e.key, e.index, starts[element]+e.before, e.size, e.animations) || started
+		}
+	}
+	if animator.endPass(first, last, count, scope.key) {
+		frameclock.Current(gtx).RequestFrame()
+	}
+
+	drawAt(gtx, axis, shift, listCall)
+
+	clipStack := clip.Rect(image.Rectangle{Max: dims.Size}).Push(gtx.Ops)
+	// Draw the removed items fading out where they were.
+	for key, item := range animator.disappearing {
+		coordinator, ok := items.coordinator(key)
+		if !ok {
+			continue
+		}
+		gtx := gtx
+		gtx.Constraints = elementConstraints
+		gtx = itemGtx(gtx)
+		macro := op.Record(gtx.Ops)
+		trans := op.Offset(axis.Convert(image.Pt(0, padding.crossStart))).Push(gtx.Ops)
+		layoutItem(gtx, axis, coordinator)
+		trans.Pop()
+		call := macro.Stop()
+		drawAnimated(gtx, axis, item.start+item.displacement, animator.fadingOut(item), call)
+	}
+
+	// Draw the headers over the items, keeping the one of the section
+	// of the first item at the start of the viewport.
+	sticky := -1
+	if count > 0 && position.Count > 0 {
+		sticky = scope.headerBefore(first)
+	}
+	for element := position.First; element < position.First+position.Count; element++ {
+		if e := elements[element]; e.header != nil && e.index != sticky {
+			drawAt(gtx, axis, starts[element], *e.header)
+		}
+	}
+	if sticky >= 0 {
+		element := sticky
+		if opts.ReverseLayout {
+			element = count - 1 - sticky
+		}
+		e, visible := elements[element]
+		if !visible || e.header == nil {
+			gtx := gtx
+			gtx.Constraints = elementConstraints
+			var call op.CallOp
+			e, call = layoutElement(gtx, sticky, 0)
+			e.header = &call
+		}
+		stickyOffset := 0
+		if _, visible := starts[element]; visible {
+			stickyOffset = max(offset(element), 0)
+		}
+		if next := scope.headerAfter(sticky); next >= 0 && next <= last {
+			nextElement := next
+			if opts.ReverseLayout {
+				nextElement = count - 1 - next
+			}
+			stickyOffset = min(stickyOffset, offset(nextElement)-e.size)
+		}
+		start := stickyOffset - e.before
+		if opts.ReverseLayout {
+			start = viewport - stickyOffset - e.size - e.before
+		}
+		drawAt(gtx, axis, start, *e.header)
+	}
+	clipStack.Pop()
+	items.endPass(first, last, animator.isDisappearing)
+
+	info := LazyListLayoutInfo{
+		TotalItemsCount:   count,
+		ViewportEndOffset: viewport,
+		ViewportSize:      dims.Size,
+	}
+	for i := first; i <= last; i++ {
+		element := i
+		if opts.ReverseLayout {
+			element = count - 1 - i
+		}
+		info.VisibleItemsInfo = append(info.VisibleItemsInfo, LazyListItemInfo{
+			Index:  i,
+			Key:    elements[element].key,
+			Offset: offset(element),
+			Size:   elements[element].size,
+		})
+	}
+	state.layoutInfo.Set(info)
+	state.endLayout(gtx, list, lines)
+	return dims, started
+}
+
+// drawAnimated draws call displaced along axis, with opacity alpha.
+func drawAnimated(gtx layoutnode.LayoutContext, axis layout.Axis, displacement int, alpha float32, call op.CallOp) {
+	if alpha < 1 {
+		defer paint.PushOpacity(gtx.Ops, alpha).Pop()
+	}
+	drawAt(gtx, axis, displacement, call)
+}
+
+// drawAt draws call at start along axis.
+func drawAt(gtx layoutnode.LayoutContext, axis layout.Axis, start int, call op.CallOp) {
+	defer op.Offset(axis.Convert(image.Pt(start, 0))).Push(gtx.Ops).Pop()
+	call.Add(gtx.Ops)
+}
+
+// contentPadding is the content padding of a list in pixels: before its first
+// element and after its last one along its axis, and on each side across it.
+type contentPadding struct {
+	start, end           int
+	crossStart, crossEnd int
+}
+
+func resolvePadding(gtx layoutnode.LayoutContext, axis layout.Axis, padding foundationLayout.PaddingValues) contentPadding {
+	px := func(dp unit.Dp) int { return gtx.Dp(gioUnit.Dp(dp)) }
+	if axis == layout.Vertical {
+		return contentPadding{start: px(padding.Top), end: px(padding.Bottom), crossStart: px(padding.Start), crossEnd: px(padding.End)}
+	}
+	return contentPadding{start: px(padding.Start), end: px(padding.End), crossStart: px(padding.Top), crossEnd: px(padding.Bottom)}
 }
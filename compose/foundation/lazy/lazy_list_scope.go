@@ -1,33 +1,159 @@
 package lazy
 
 import (
+	"sort"
+
 	"github.com/zodimo/go-compose/compose"
 )
 
 type LazyListScope interface {
-	Item(key any, content compose.Composable)
-	Items(count int, key func(index int) any, itemContent func(index int) compose.Composable)
+	Item(key any, content compose.Composable, options ...LazyItemOption)
+	// Items adds count items. Neither key nor itemContent is called before the
+	// item is about to be shown.
+	Items(count int, key func(index int) any, itemContent func(index int) compose.Composable, options ...LazyItemOption)
+	// StickyHeader adds an item that stays at the start of the list while the
+	// items after it, up to the next header, are scrolled past.
+	StickyHeader(key any, content compose.Composable, options ...LazyItemOption)
 }
 
 type lazyListScopeImpl struct {
-	items []lazyItem
+	intervals []lazyInterval
+	count     int
+}
+
+// lazyInterval is a run of items added by one call to Item, Items or
+// StickyHeader.
+type lazyInterval struct {
+	start       int
+	count       int
+	key         func(index int) any
+	contentType func(index int) any
+	span        func(index int) StaggeredGridItemSpan
+	content     func(index int) compose.Composable
+	header      bool
+}
+
+// lazyItemContent is the content of the item at an index of a lazy layout.
+type lazyItemContent struct {
+	key         any
+	contentType any
+	content     compose.Composable
+}
+
+func (s *lazyListScopeImpl) Item(key any, content compose.Composable, options ...LazyItemOption) {
+	s.addInterval(1, func(int) any { return key }, func(int) compose.Composable { return content }, false, options)
+}
+
+func (s *lazyListScopeImpl) Items(count int, key func(index int) any, itemContent func(index int) compose.Composable, options ...LazyItemOption) {
+	s.addInterval(count, key, itemContent, false, options)
+}
+
+func (s *lazyListScopeImpl) StickyHeader(key any, content compose.Composable, options ...LazyItemOption) {
+	s.addInterval(1, func(int) any { return key }, func(int) compose.Composable { return content }, true, options)
+}
+
+func (s *lazyListScopeImpl) addInterval(count int, key func(index int) any, content func(index int) compose.Composable, header bool, options []LazyItemOption) {
+	if count <= 0 {
+		return
+	}
+	opts := DefaultLazyItemOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	s.intervals = append(s.intervals, lazyInterval{
+		start:       s.count,
+		count:       count,
+		key:         key,
+		contentType: opts.ContentType,
+		span:        opts.Span,
+		content:     content,
+		header:      header,
+	})
+	s.count += count
+}
+
+func (s *lazyListScopeImpl) itemCount() int {
+	return s.count
 }
 
-type lazyItem struct {
-	Key     any
-	Content compose.Composable
+func (s *lazyListScopeImpl) interval(index int) lazyInterval {
+	i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].start+s.intervals[i].count > index
+	})
+	return s.intervals[i]
 }
 
-func (s *lazyListScopeImpl) Item(key any, content compose.Composable) {
-	s.items = append(s.items, lazyItem{Key: key, Content: content})
+// item returns the content of the item at index. Items without a key are
+// keyed by their index.
+func (s *lazyListScopeImpl) item(index int) lazyItemContent {
+	interval := s.interval(index)
+	local := index - interval.start
+
+	item := lazyItemContent{content: interval.content(local)}
+	if interval.key != nil {
+		item.key = interval.key(local)
+	}
+	if item.key == nil {
+		item.key = indexKey(index)
+	}
+	if interval.contentType != nil {
+		item.contentType = interval.contentType(local)
+	}
+	return item
+}
+
+// key returns the key of the item at index without its content.
+func (s *lazyListScopeImpl) key(index int) any {
+	interval := s.interval(index)
+	var key any
+	if interval.key != nil {
+		key = interval.key(index - interval.start)
+	}
+	if key == nil {
+		key = indexKey(index)
+	}
+	return key
+}
+
+// span returns the span of the item at index in a staggered grid.
+func (s *lazyListScopeImpl) span(index int) StaggeredGridItemSpan {
+	interval := s.interval(index)
+	if interval.span == nil {
+		return StaggeredGridItemSpanSingleLane
+	}
+	return interval.span(index - interval.start)
+}
+
+// isHeader reports whether the item at index is a sticky header.
+func (s *lazyListScopeImpl) isHeader(index int) bool {
+	return s.interval(index).header
+}
+
+// headerBefore returns the index of the last sticky header at or before index,
+// or -1 when there is none.
+func (s *lazyListScopeImpl) headerBefore(index int) int {
+	for i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].start > index
+	}) - 1; i >= 0; i-- {
+		if s.intervals[i].header {
+			return s.intervals[i].start
+		}
+	}
+	return -1
 }
 
-func (s *lazyListScopeImpl) Items(count int, key func(index int) any, itemContent func(index int) compose.Composable) {
-	for i := 0; i < count; i++ {
-		var k any
-		if key != nil {
-			k = key(i)
+// headerAfter returns the index of the first sticky header after index, or -1
+// when there is none.
+func (s *lazyListScopeImpl) headerAfter(index int) int {
+	for i := sort.Search(len(s.intervals), func(i int) bool {
+		return s.intervals[i].start > index
+	}); i < len(s.intervals); i++ {
+		if s.intervals[i].header {
+			return s.intervals[i].start
 		}
-		s.items = append(s.items, lazyItem{Key: k, Content: itemContent(i)})
 	}
+	return -1
 }
@@ -1,16 +1,52 @@
 package lazy
 
 import (
-	"fmt"
+	"encoding/json"
+	"image"
 
 	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
 
 	"gioui.org/layout"
 	"gioui.org/widget"
 )
 
+// LazyListState controls and observes the scrolling of a lazy list.
+//
+// Its position and LayoutInfo are updated as the list is laid out; reading
+// them in a composable composes it again when they change:
+//
+//	if listState.FirstVisibleItemIndex() > 0 {
+//		JumpToTopButton(func() { listState.ScrollToItem(0, 0) })(c)
+//	}
 type LazyListState struct {
 	List widget.List
+	scrollState
+
+	layoutInfo state.MutableState[LazyListLayoutInfo]
+}
+
+// LazyListLayoutInfo describes the last layout of a lazy list.
+type LazyListLayoutInfo struct {
+	// VisibleItemsInfo holds the items that are laid out, in order.
+	VisibleItemsInfo []LazyListItemInfo
+	TotalItemsCount  int
+	// ViewportStartOffset and ViewportEndOffset are the bounds of the viewport
+	// along the axis of the list, relative to which item offsets are given.
+	ViewportStartOffset int
+	ViewportEndOffset   int
+	ViewportSize        image.Point
+}
+
+// LazyListItemInfo describes an item of the last layout of a lazy list.
+type LazyListItemInfo struct {
+	Index int
+	Key   any
+	// Offset is the position of the item along the axis of the list, relative
+	// to the start of the viewport; it is negative once scrolled past it.
+	Offset int
+	// Size is the size of the item along the axis of the list.
+	Size int
 }
 
 func NewLazyListState() *LazyListState {
@@ -20,13 +56,49 @@ func NewLazyListState() *LazyListState {
 				Axis: layout.Vertical,
 			},
 		},
+		scrollState: newScrollState(),
+		layoutInfo:  state.NewMutableState(LazyListLayoutInfo{}, nil),
 	}
 }
 
+// LayoutInfo returns the items laid out by the last layout of the list.
+func (s *LazyListState) LayoutInfo() LazyListLayoutInfo {
+	return s.layoutInfo.Get()
+}
+
+// RememberLazyListState creates or retrieves a remembered LazyListState.
+// Its scroll position is saveable, so it is restored after a restart.
 func RememberLazyListState(c compose.Composer) *LazyListState {
-	id := c.GenerateID()
-	key := fmt.Sprintf("lazyListState-%v", id)
-	return c.State(key, func() any {
-		return NewLazyListState()
-	}).Get().(*LazyListState)
+	return state.RememberSaveable(c, "lazyListState", NewLazyListState, LazyListStateSaver).Get()
+}
+
+// scrollPosition is the saved form of a list scroll position.
+type scrollPosition struct {
+	First  int `json:"first"`
+	Offset int `json:"offset"`
+}
+
+func saveScrollPosition(list *widget.List) ([]byte, error) {
+	return json.Marshal(scrollPosition{First: list.Position.First, Offset: list.Position.Offset})
+}
+
+func restoreScrollPosition(list *widget.List, data []byte) error {
+	var position scrollPosition
+	if err := json.Unmarshal(data, &position); err != nil {
+		return err
+	}
+	list.Position.First = position.First
+	list.Position.Offset = position.Offset
+	return nil
 }
+
+// LazyListStateSaver saves the scroll position of a LazyListState.
+var LazyListStateSaver = state.NewSaver(
+	func(s *LazyListState) ([]byte, error) {
+		return saveScrollPosition(&s.List)
+	},
+	func(data []byte) (*LazyListState, error) {
+		s := NewLazyListState()
+		return s, restoreScrollPosition(&s.List, data)
+	},
+)
@@ -0,0 +1,619 @@
+package lazy
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	foundationLayout "github.com/zodimo/go-compose/compose/foundation/layout"
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/modifiers/size"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+
+	"gioui.org/op"
+)
+
+type forgettable struct{ forgotten *int }
+
+func (f forgettable) OnRemembered() {}
+func (f forgettable) OnForgotten()  { *f.forgotten++ }
+
+// listHost composes a list in a frame and lays it out in a 100x100 viewport.
+type listHost struct {
+	store       state.PersistentState
+	coordinator layoutnode.NodeCoordinator
+	now         time.Time
+}
+
+func (h *listHost) frame(content compose.Composable) {
+	c := compose.NewComposer(h.store)
+	root := content(c).Build()
+	if h.coordinator == nil {
+		h.coordinator = layoutnode.NewNodeCoordinator(root)
+	} else {
+		h.coordinator.Update(root)
+	}
+	gtx := layoutnode.LayoutContext{Ops: new(op.Ops), Now: h.now}
+	gtx.Constraints.Max = image.Pt(100, 100)
+	h.coordinator.Layout(gtx)
+}
+
+func TestLazyColumnComposesVisibleItems(t *testing.T) {
+	composed := map[int]int{}
+	forgotten := 0
+	listState := NewLazyListState()
+	list := LazyColumn(func(scope LazyListScope) {
+		scope.Items(50000, func(index int) any { return index }, func(index int) compose.Composable {
+			return func(c compose.Composer) compose.Composer {
+				composed[index]++
+				c.Remember("item", func() any { return forgettable{&forgotten} })
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))(c)
+			}
+		})
+	}, WithState(listState), WithPrefetchDistance(2))
+
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+
+	// Ten items are visible, and two more are prefetched.
+	if len(composed) != 12 {
+		t.Fatalf("composed %d items, want 12", len(composed))
+	}
+	for index := range composed {
+		if index >= 12 {
+			t.Errorf("composed item %d, which is neither visible nor prefetched", index)
+		}
+	}
+
+	h.frame(list)
+	if composed[0] != 2 {
+		t.Errorf("item 0 composed %d times in two frames, want 2", composed[0])
+	}
+	if forgotten != 0 {
+		t.Errorf("forgot %d items that stayed visible", forgotten)
+	}
+
+	listState.List.Position.First = 100
+	h.frame(list)
+	if forgotten != 12 {
+		t.Errorf("forgot %d items after scrolling away, want 12", forgotten)
+	}
+	if composed[100] == 0 || composed[50] != 0 {
+		t.Errorf("expected items from 100 to be composed, and not the items in between")
+	}
+}
+
+func TestLazyColumnRetainsItems(t *testing.T) {
+	composed, forgotten := 0, 0
+	listState := NewLazyListState()
+	list := LazyColumn(func(scope LazyListScope) {
+		scope.Items(100, nil, func(index int) compose.Composable {
+			return func(c compose.Composer) compose.Composer {
+				c.Remember("item", func() any { composed++; return forgettable{&forgotten} })
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))(c)
+			}
+		})
+	}, WithState(listState), WithPrefetchDistance(0), WithRetainedItems(4))
+
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+	laidOut := composed
+	listState.List.Position.First = 50
+	h.frame(list)
+
+	// Four of the items that scrolled out of view keep their composition.
+	if forgotten != laidOut-4 {
+		t.Errorf("forgot %d of %d items, want %d", forgotten, laidOut, laidOut-4)
+	}
+}
+
+func rows(count int) func(LazyListScope) {
+	return func(scope LazyListScope) {
+		scope.Items(count, func(index int) any { return fmt.Sprintf("row-%d", index) }, func(index int) compose.Composable {
+			return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))
+		})
+	}
+}
+
+func TestLazyListStateScrollToItem(t *testing.T) {
+	listState := NewLazyListState()
+	list := LazyColumn(rows(100), WithState(listState))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+
+	if listState.CanScrollBackward() || !listState.CanScrollForward() {
+		t.Errorf("at the top: backward %v, forward %v; want false, true", listState.CanScrollBackward(), listState.CanScrollForward())
+	}
+
+	invalidated := 0
+	h.store.SetOnStateChange(func() { invalidated++ })
+	listState.ScrollToItem(20, 5)
+	if invalidated == 0 {
+		t.Error("Expected a scroll request to schedule a frame")
+	}
+	h.frame(list)
+
+	if got := listState.FirstVisibleItemIndex(); got != 20 {
+		t.Errorf("FirstVisibleItemIndex = %d, want 20", got)
+	}
+	if got := listState.FirstVisibleItemScrollOffset(); got != 5 {
+		t.Errorf("FirstVisibleItemScrollOffset = %d, want 5", got)
+	}
+	info := listState.LayoutInfo()
+	first := info.VisibleItemsInfo[0]
+	if first.Index != 20 || first.Key != "row-20" || first.Offset != -5 || first.Size != 10 {
+		t.Errorf("first visible item = %+v, want index 20, key row-20, offset -5, size 10", first)
+	}
+	if info.TotalItemsCount != 100 || info.ViewportEndOffset != 100 {
+		t.Errorf("layout info = %d items, viewport end %d; want 100, 100", info.TotalItemsCount, info.ViewportEndOffset)
+	}
+	if !listState.CanScrollBackward() {
+		t.Error("Expected the list to scroll backward once scrolled")
+	}
+
+	listState.ScrollToItem(90, 0)
+	h.frame(list)
+	if listState.CanScrollForward() {
+		t.Error("Expected the list not to scroll forward at its end")
+	}
+}
+
+func TestLazyListStateAnimateScrollToItem(t *testing.T) {
+	listState := NewLazyListState()
+	list := LazyColumn(rows(100), WithState(listState))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+
+	done := make(chan error)
+	go func() { done <- listState.AnimateScrollToItem(context.Background(), 50, 0) }()
+	if err := <-done; err != nil {
+		t.Fatalf("AnimateScrollToItem: %v", err)
+	}
+	h.frame(list)
+	if got := listState.FirstVisibleItemIndex(); got != 50 {
+		t.Errorf("FirstVisibleItemIndex = %d, want 50", got)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := listState.AnimateScrollToItem(ctx, 0, 0); err != context.Canceled {
+		t.Errorf("cancelled AnimateScrollToItem = %v, want %v", err, context.Canceled)
+	}
+}
+
+func visibleOffsets(info LazyListLayoutInfo) map[int]int {
+	offsets := map[int]int{}
+	for _, item := range info.VisibleItemsInfo {
+		offsets[item.Index] = item.Offset
+	}
+	return offsets
+}
+
+func TestLazyColumnContentPaddingAndSpacing(t *testing.T) {
+	listState := NewLazyListState()
+	list := LazyColumn(rows(100), WithState(listState),
+		WithContentPadding(foundationLayout.PaddingValues{Top: 10, Bottom: 10}),
+		WithVerticalArrangement(foundationLayout.SpacedBy(5)))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+
+	offsets := visibleOffsets(listState.LayoutInfo())
+	for index, want := range map[int]int{0: 10, 1: 25, 2: 40} {
+		if offsets[index] != want {
+			t.Errorf("item %d at %d, want %d", index, offsets[index], want)
+		}
+	}
+}
+
+func TestLazyColumnArrangement(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		arrangement foundationLayout.Arrangement
+		reverse     bool
+		want        map[int]int
+	}{
+		{"start", foundationLayout.ArrangementStart, false, map[int]int{0: 0, 1: 10, 2: 20}},
+		{"center", foundationLayout.ArrangementCenter, false, map[int]int{0: 35, 1: 45, 2: 55}},
+		{"end", foundationLayout.ArrangementEnd, false, map[int]int{0: 70, 1: 80, 2: 90}},
+		{"reverse start", foundationLayout.ArrangementStart, true, map[int]int{0: 0, 1: 10, 2: 20}},
+		{"reverse end", foundationLayout.ArrangementEnd, true, map[int]int{0: 70, 1: 80, 2: 90}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			listState := NewLazyListState()
+			list := LazyColumn(rows(3), WithState(listState),
+				WithVerticalArrangement(test.arrangement), WithReverseLayout(test.reverse))
+			h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+			h.frame(list)
+
+			offsets := visibleOffsets(listState.LayoutInfo())
+			for index, want := range test.want {
+				if offsets[index] != want {
+					t.Errorf("item %d at %d, want %d", index, offsets[index], want)
+				}
+			}
+		})
+	}
+}
+
+func TestLazyColumnReverseLayout(t *testing.T) {
+	listState := NewLazyListState()
+	list := LazyColumn(rows(100), WithState(listState), WithReverseLayout(true))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+
+	// The first item is at the bottom, where the list starts.
+	if got := listState.FirstVisibleItemIndex(); got != 0 {
+		t.Errorf("FirstVisibleItemIndex = %d, want 0", got)
+	}
+	if offsets := visibleOffsets(listState.LayoutInfo()); offsets[0] != 0 || offsets[9] != 90 {
+		t.Errorf("items 0 and 9 at %d and %d, want 0 and 90", offsets[0], offsets[9])
+	}
+	if listState.CanScrollBackward() || !listState.CanScrollForward() {
+		t.Errorf("at the start: backward %v, forward %v; want false, true", listState.CanScrollBackward(), listState.CanScrollForward())
+	}
+
+	listState.ScrollToItem(20, 5)
+	h.frame(list)
+	if got := listState.FirstVisibleItemIndex(); got != 20 {
+		t.Errorf("FirstVisibleItemIndex = %d, want 20", got)
+	}
+	if got := listState.FirstVisibleItemScrollOffset(); got != 5 {
+		t.Errorf("FirstVisibleItemScrollOffset = %d, want 5", got)
+	}
+	if offsets := visibleOffsets(listState.LayoutInfo()); offsets[20] != -5 {
+		t.Errorf("item 20 at %d, want -5", offsets[20])
+	}
+	if !listState.CanScrollBackward() {
+		t.Error("Expected the list to scroll backward once scrolled")
+	}
+}
+
+func TestLazyColumnStickyHeader(t *testing.T) {
+	composed := map[string]int{}
+	section := func(name string) func(index int) compose.Composable {
+		return func(index int) compose.Composable {
+			return func(c compose.Composer) compose.Composer {
+				composed[fmt.Sprintf("%s%d", name, index)]++
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))(c)
+			}
+		}
+	}
+	listState := NewLazyListState()
+	list := LazyColumn(func(scope LazyListScope) {
+		scope.StickyHeader("A", section("header A")(0))
+		scope.Items(20, nil, section("a"))
+		scope.StickyHeader("B", section("header B")(0))
+		scope.Items(20, nil, section("b"))
+	}, WithState(listState), WithPrefetchDistance(0))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+
+	// The header of the section of the first visible item is composed, however
+	// far it is scrolled out of view.
+	listState.ScrollToItem(10, 0)
+	h.frame(list)
+	if composed["header A0"] == 0 {
+		t.Error("Expected header A to be composed while its section is visible")
+	}
+	if composed["header B0"] != 0 {
+		t.Error("Expected header B not to be composed before its section is visible")
+	}
+
+	listState.ScrollToItem(30, 0)
+	h.frame(list)
+	before := composed["header A0"]
+	h.frame(list)
+	if composed["header A0"] != before {
+		t.Error("Expected header A not to be composed once its section is scrolled past")
+	}
+	if composed["header B0"] == 0 {
+		t.Error("Expected header B to be composed while its section is visible")
+	}
+}
+
+func TestLazyItemsContentType(t *testing.T) {
+	forgotten := 0
+	contentType := "text"
+	listState := NewLazyListState()
+	list := func() compose.Composable {
+		return LazyColumn(func(scope LazyListScope) {
+			scope.Item("item", func(c compose.Composer) compose.Composer {
+				c.Remember("item", func() any { return forgettable{&forgotten} })
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))(c)
+			}, WithContentType(contentType))
+		}, WithState(listState))
+	}
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list())
+	h.frame(list())
+	if forgotten != 0 {
+		t.Fatalf("forgot the item while its content type stayed the same")
+	}
+
+	// An item is composed anew when its content type changes.
+	contentType = "image"
+	h.frame(list())
+	if forgotten != 1 {
+		t.Errorf("forgot the item %d times once its content type changed, want 1", forgotten)
+	}
+}
+
+func TestLazyItemsKeysOfDifferentTypes(t *testing.T) {
+	// The keys 1 and "1" differ, and so do the key "index:3" and the key of
+	// the fourth item, which has none.
+	keys := []any{1, "1", "index:3", nil}
+	remembered := map[int]int{}
+	listState := NewLazyListState()
+	list := LazyColumn(func(scope LazyListScope) {
+		scope.Items(len(keys), func(index int) any { return keys[index] }, func(index int) compose.Composable {
+			return func(c compose.Composer) compose.Composer {
+				remembered[index] = c.Remember("item", func() any { return index }).(int)
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10)))(c)
+			}
+		})
+	}, WithState(listState))
+
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(list)
+	h.frame(list)
+	for index := range keys {
+		if remembered[index] != index {
+			t.Errorf("item %d (key %#v) remembered the value of item %d", index, keys[index], remembered[index])
+		}
+	}
+}
+
+func TestItemAnimator(t *testing.T) {
+	a := &itemAnimator{animations: map[any]*itemAnimation{}, disappearing: map[any]*disappearingItem{}}
+	options := DefaultAnimateItemOptions()
+	start := time.Unix(0, 0)
+	generation := 0
+	frame := func(now time.Duration, keys ...string) bool {
+		generation++
+		a.startPass(lazyFrame{generation: generation}, start.Add(now))
+		started := false
+		for i, key := range keys {
+			started = a.place(key, i, i*10, 10, options) || started
+		}
+		a.endPass(0, len(keys)-1, len(keys), func(i int) any { return keys[i] })
+		return started
+	}
+
+	if frame(0, "a", "b") {
+		t.Error("Expected no animation in the first frame")
+	}
+	if !frame(0, "x", "a", "b") {
+		t.Fatal("Expected animations once an item is inserted")
+	}
+	if displacement, alpha := a.transform("a"); displacement != -10 || alpha != 1 {
+		t.Errorf("moved item: displacement %d, alpha %v; want -10, 1", displacement, alpha)
+	}
+	if displacement, alpha := a.transform("x"); displacement != 0 || alpha != 0 {
+		t.Errorf("inserted item: displacement %d, alpha %v; want 0, 0", displacement, alpha)
+	}
+
+	frame(DefaultItemAnimationDuration/2, "x", "a", "b")
+	if displacement, _ := a.transform("a"); displacement >= 0 || displacement <= -10 {
+		t.Errorf("moved item halfway: displacement %d, want between -10 and 0", displacement)
+	}
+	if _, alpha := a.transform("x"); alpha <= 0 || alpha >= 1 {
+		t.Errorf("inserted item halfway: alpha %v, want between 0 and 1", alpha)
+	}
+
+	frame(DefaultItemAnimationDuration, "x", "a", "b")
+	if len(a.animations) != 0 {
+		t.Errorf("%d animations left once they ended", len(a.animations))
+	}
+
+	frame(DefaultItemAnimationDuration, "x", "b")
+	if !a.isDisappearing("a") {
+		t.Fatal("Expected the removed item to fade out")
+	}
+	if alpha := a.fadingOut(a.disappearing["a"]); alpha != 1 {
+		t.Errorf("removed item: alpha %v, want 1", alpha)
+	}
+	frame(2*DefaultItemAnimationDuration, "x", "b")
+	if a.isDisappearing("a") {
+		t.Error("Expected the removed item to be gone once faded out")
+	}
+}
+
+func TestAnimateItemKeepsRemovedItems(t *testing.T) {
+	forgotten := 0
+	keys := []string{"a", "b", "c"}
+	list := func() compose.Composable {
+		keys := keys
+		return LazyColumn(func(scope LazyListScope) {
+			scope.Items(len(keys), func(i int) any { return keys[i] }, func(i int) compose.Composable {
+				return func(c compose.Composer) compose.Composer {
+					c.Remember("item", func() any { return forgettable{&forgotten} })
+					return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10).Then(AnimateItem())))(c)
+				}
+			})
+		}, WithPrefetchDistance(0))
+	}
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{}), now: time.Unix(0, 0)}
+	h.frame(list())
+
+	keys = []string{"a", "c"}
+	h.now = h.now.Add(time.Millisecond)
+	h.frame(list())
+	if forgotten != 0 {
+		t.Fatalf("forgot %d items while the removed one fades out", forgotten)
+	}
+
+	h.now = h.now.Add(DefaultItemAnimationDuration)
+	h.frame(list())
+	h.now = h.now.Add(time.Millisecond)
+	h.frame(list())
+	if forgotten != 1 {
+		t.Errorf("forgot %d items once the removed one faded out, want 1", forgotten)
+	}
+}
+
+// tiles adds items of the heights given, keyed "tile-%d", the item at full
+// spans the full line.
+func tiles(heights []int, full int) func(LazyStaggeredGridScope) {
+	return func(scope LazyStaggeredGridScope) {
+		scope.Items(len(heights), func(index int) any { return fmt.Sprintf("tile-%d", index) }, func(index int) compose.Composable {
+			return box.Box(compose.Id(), box.WithModifier(size.Size(100, heights[index])))
+		}, WithSpans(func(index int) StaggeredGridItemSpan {
+			if index == full {
+				return StaggeredGridItemSpanFullLine
+			}
+			return StaggeredGridItemSpanSingleLane
+		}))
+	}
+}
+
+func visibleTiles(info LazyStaggeredGridLayoutInfo) map[int]LazyStaggeredGridItemInfo {
+	tiles := map[int]LazyStaggeredGridItemInfo{}
+	for _, item := range info.VisibleItemsInfo {
+		tiles[item.Index] = item
+	}
+	return tiles
+}
+
+func TestLazyVerticalStaggeredGridPlacesItemsInShortestLane(t *testing.T) {
+	gridState := NewLazyStaggeredGridState()
+	grid := LazyVerticalStaggeredGrid(StaggeredGridCells.Fixed(2), tiles([]int{30, 10, 10, 20, 15, 5}, 4), WithStaggeredGridState(gridState))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(grid)
+
+	want := map[int]struct {
+		lane int
+		top  int
+	}{
+		0: {0, 0},
+		1: {1, 0},
+		2: {1, 10},
+		3: {1, 20}, // lane 1 ends first, at 20, before lane 0 at 30
+		4: {0, 40}, // full line, below both lanes
+		5: {0, 55},
+	}
+	tiles := visibleTiles(gridState.LayoutInfo())
+	if len(tiles) != len(want) {
+		t.Fatalf("laid out %d items, want %d", len(tiles), len(want))
+	}
+	for index, w := range want {
+		tile := tiles[index]
+		if tile.Lane != w.lane || tile.Offset != image.Pt(w.lane*50, w.top) {
+			t.Errorf("item %d in lane %d at %v, want lane %d at %v", index, tile.Lane, tile.Offset, w.lane, image.Pt(w.lane*50, w.top))
+		}
+	}
+	if got := tiles[4].Size; got != image.Pt(100, 15) {
+		t.Errorf("full line item size = %v, want (100,15)", got)
+	}
+	if got := tiles[0].Size; got != image.Pt(50, 30) {
+		t.Errorf("item size = %v, want (50,30)", got)
+	}
+	if gridState.CanScrollForward() {
+		t.Error("Expected a grid shorter than its viewport not to scroll")
+	}
+}
+
+func TestLazyStaggeredGridStateScrollToItem(t *testing.T) {
+	heights := make([]int, 100)
+	for i := range heights {
+		heights[i] = 10 + i%3*5
+	}
+	gridState := NewLazyStaggeredGridState()
+	grid := LazyVerticalStaggeredGrid(StaggeredGridCells.Adaptive(40), tiles(heights, -1),
+		WithStaggeredGridState(gridState), WithMainAxisSpacing(2))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(grid)
+
+	info := gridState.LayoutInfo()
+	if info.VisibleItemsInfo[1].Lane != 1 || info.VisibleItemsInfo[1].Size.X != 50 {
+		t.Errorf("second item = %+v, want lane 1 of two lanes of 50", info.VisibleItemsInfo[1])
+	}
+	if !gridState.CanScrollForward() || gridState.CanScrollBackward() {
+		t.Errorf("at the top: backward %v, forward %v; want false, true", gridState.CanScrollBackward(), gridState.CanScrollForward())
+	}
+
+	gridState.ScrollToItem(40, 3)
+	h.frame(grid)
+	if got := gridState.FirstVisibleItemIndex(); got > 40 {
+		t.Errorf("FirstVisibleItemIndex = %d, want at most 40", got)
+	}
+	tiles := visibleTiles(gridState.LayoutInfo())
+	if tile, ok := tiles[40]; !ok || tile.Offset.Y != -3 {
+		t.Errorf("item 40 = %+v, want at -3", tile)
+	}
+	if !gridState.CanScrollBackward() {
+		t.Error("Expected the grid to scroll backward once scrolled")
+	}
+
+	gridState.ScrollToItem(99, 0)
+	h.frame(grid)
+	if gridState.CanScrollForward() {
+		t.Error("Expected the grid not to scroll forward at its end")
+	}
+	if _, ok := visibleTiles(gridState.LayoutInfo())[99]; !ok {
+		t.Error("Expected the last item to be visible")
+	}
+}
+
+func TestLazyStaggeredGridScrollToFarItem(t *testing.T) {
+	composed, keyed := 0, 0
+	gridState := NewLazyStaggeredGridState()
+	grid := LazyVerticalStaggeredGrid(StaggeredGridCells.Fixed(2), func(scope LazyStaggeredGridScope) {
+		scope.Items(50000, func(index int) any { keyed++; return index }, func(index int) compose.Composable {
+			return func(c compose.Composer) compose.Composer {
+				composed++
+				return box.Box(compose.Id(), box.WithModifier(size.Size(100, 10+index%3*5)))(c)
+			}
+		})
+	}, WithStaggeredGridState(gridState))
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(grid)
+
+	composed = 0
+	gridState.ScrollToItem(40000, 0)
+	h.frame(grid)
+	// The items skipped over are not composed; only the visible ones are.
+	if composed > 20 {
+		t.Errorf("composed %d items to jump to item 40000, want the visible ones", composed)
+	}
+	if tile, ok := visibleTiles(gridState.LayoutInfo())[40000]; !ok || tile.Offset.Y != 0 {
+		t.Errorf("item 40000 = %+v, want at the top", tile)
+	}
+	if got := gridState.FirstVisibleItemIndex(); got > 40000 {
+		t.Errorf("FirstVisibleItemIndex = %d, want at most 40000", got)
+	}
+
+	// The next frames place the items from a checkpoint near the viewport,
+	// rather than from the first item on.
+	keyed = 0
+	gridState.offset += 5
+	h.frame(grid)
+	if keyed > 4*staggeredCheckpointInterval {
+		t.Errorf("looked up %d keys to scroll by 5, want the ones from the last checkpoint on", keyed)
+	}
+	if tile, ok := visibleTiles(gridState.LayoutInfo())[40000]; !ok || tile.Offset.Y != -5 {
+		t.Errorf("item 40000 = %+v, want at -5", tile)
+	}
+}
+
+func TestLazyStaggeredGridStateDropsSizesOfRemovedItems(t *testing.T) {
+	heights := []int{10, 20, 30, 40, 50, 60}
+	gridState := NewLazyStaggeredGridState()
+	grid := func() compose.Composable {
+		return LazyVerticalStaggeredGrid(StaggeredGridCells.Fixed(2), tiles(heights, -1), WithStaggeredGridState(gridState))
+	}
+	h := &listHost{store: store.NewPersistentState(map[string]state.MutableValue{})}
+	h.frame(grid())
+	if len(gridState.sizes) != 6 {
+		t.Fatalf("kept %d sizes, want 6", len(gridState.sizes))
+	}
+
+	heights = heights[:2]
+	h.frame(grid())
+	if len(gridState.sizes) != 2 || gridState.sizeTotal != 30 {
+		t.Errorf("kept %d sizes totalling %d once four items were removed, want 2 totalling 30", len(gridState.sizes), gridState.sizeTotal)
+	}
+}
@@ -0,0 +1,256 @@
+package lazy
+
+import (
+	"context"
+	"math"
+	"sync/atomic"
+	"time"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/state"
+
+	"gioui.org/layout"
+	"gioui.org/widget"
+)
+
+// AnimateScrollDuration is the duration of AnimateScrollToItem.
+const AnimateScrollDuration = 300 * time.Millisecond
+
+// scrollRequest is a scroll to an item, applied by the next layout.
+type scrollRequest struct {
+	sequence int
+	index    int
+	offset   int
+}
+
+// scrollLines describes the lines of a list, the elements of its
+// widget.List: an item of a lazy list, or a row of items of a lazy grid.
+type scrollLines struct {
+	count        int
+	itemsPerLine int
+	// reverse lists lay out their first line at the end of the widget.List,
+	// whose ScrollToEnd keeps it in view.
+	reverse bool
+}
+
+// scrollMetrics is the position of a laid out list in lines, from the start of
+// the list.
+type scrollMetrics struct {
+	firstLine    int
+	firstOffset  int
+	itemsPerLine int
+	// lineSize is the average size of the lines laid out.
+	lineSize float64
+}
+
+// scrollState is the scroll position of a lazy layout, in items, shared by
+// LazyListState and LazyGridState.
+//
+// Its observable values are updated by the layout, so that composables reading
+// them are composed again when the list scrolls. Scroll requests can be made
+// from any goroutine; they are applied by the next layout, which they schedule.
+type scrollState struct {
+	firstVisibleItemIndex        state.MutableState[int]
+	firstVisibleItemScrollOffset state.MutableState[int]
+	scrollInProgress             state.MutableState[bool]
+	canScrollForward             state.MutableState[bool]
+	canScrollBackward            state.MutableState[bool]
+	request                      state.MutableState[scrollRequest]
+
+	requests  atomic.Int64
+	applied   int
+	animating atomic.Int32
+	metrics   atomic.Pointer[scrollMetrics]
+
+	frame         int64 // time of the frame of the last layout
+	movedInFrame  bool
+	startPosition layout.Position
+}
+
+func newScrollState() scrollState {
+	return scrollState{
+		firstVisibleItemIndex:        state.NewMutableState(0, nil),
+		firstVisibleItemScrollOffset: state.NewMutableState(0, nil),
+		scrollInProgress:             state.NewMutableState(false, nil),
+		canScrollForward:             state.NewMutableState(false, nil),
+		canScrollBackward:            state.NewMutableState(false, nil),
+		request:                      state.NewMutableState(scrollRequest{}, nil),
+	}
+}
+
+// FirstVisibleItemIndex returns the index of the first item that is visible.
+func (s *scrollState) FirstVisibleItemIndex() int {
+	return s.firstVisibleItemIndex.Get()
+}
+
+// FirstVisibleItemScrollOffset returns how far the first visible item is
+// scrolled out of view, in pixels.
+func (s *scrollState) FirstVisibleItemScrollOffset() int {
+	return s.firstVisibleItemScrollOffset.Get()
+}
+
+// IsScrollInProgress reports whether the list is dragged, flung or animated.
+func (s *scrollState) IsScrollInProgress() bool {
+	return s.scrollInProgress.Get()
+}
+
+// CanScrollForward reports whether the list can scroll towards its end.
+func (s *scrollState) CanScrollForward() bool {
+	return s.canScrollForward.Get()
+}
+
+// CanScrollBackward reports whether the list can scroll towards its start.
+func (s *scrollState) CanScrollBackward() bool {
+	return s.canScrollBackward.Get()
+}
+
+// ScrollToItem scrolls the list so that the item at index is first, scrolled
+// out of view by scrollOffset pixels. It can be called from any goroutine.
+func (s *scrollState) ScrollToItem(index, scrollOffset int) {
+	sequence := int(s.requests.Add(1))
+	s.request.Set(scrollRequest{sequence: sequence, index: index, offset: scrollOffset})
+}
+
+// AnimateScrollToItem scrolls the list to the item at index, like ScrollToItem,
+// over AnimateScrollDuration. It blocks until the list is scrolled, or ctx is
+// done, so it is meant to be called from an effect:
+//
+//	effect.LaunchedEffect(func(ctx context.Context) {
+//		listState.AnimateScrollToItem(ctx, len(messages)-1, 0)
+//	}, len(messages))
+//
+// Items that have not been laid out are assumed to be the average size of
+// those that have.
+func (s *scrollState) AnimateScrollToItem(ctx context.Context, index, scrollOffset int) error {
+	s.animating.Add(1)
+	defer s.animating.Add(-1)
+
+	metrics := s.metrics.Load()
+	if metrics == nil || metrics.lineSize <= 0 {
+		s.ScrollToItem(index, scrollOffset)
+		return nil
+	}
+	size, perLine := metrics.lineSize, metrics.itemsPerLine
+	from := float64(metrics.firstLine) + float64(metrics.firstOffset)/size
+	to := float64(index/perLine) + float64(scrollOffset)/size
+
+	// The list moves once a frame, on the frame clock of ctx.
+	var start int64
+	for {
+		var now int64
+		if err := frameclock.WithFrameNanos(ctx, func(frameTimeNanos int64) { now = frameTimeNanos }); err != nil {
+			return err
+		}
+		if start == 0 {
+			start = now
+		}
+		fraction := float64(now-start) / float64(AnimateScrollDuration)
+		if fraction >= 1 {
+			s.ScrollToItem(index, scrollOffset)
+			return nil
+		}
+		position := from + (to-from)*easeOut(fraction)
+		line := math.Floor(position)
+		s.ScrollToItem(int(line)*perLine, int(math.Round((position-line)*size)))
+	}
+}
+
+// easeOut decelerates towards the end of an animation.
+func easeOut(fraction float64) float64 {
+	return 1 - math.Pow(1-fraction, 3)
+}
+
+// observe reads the scroll requests during composition, so that making one
+// schedules a frame.
+func (s *scrollState) observe() {
+	s.request.Get()
+}
+
+// pendingRequest returns the scroll request that was not applied yet, which
+// the caller applies.
+func (s *scrollState) pendingRequest() (scrollRequest, bool) {
+	request := s.request.Get()
+	if request.sequence == s.applied {
+		return scrollRequest{}, false
+	}
+	s.applied = request.sequence
+	return request, true
+}
+
+// startLayout applies the pending scroll request to list.
+func (s *scrollState) startLayout(gtx layoutnode.LayoutContext, list *widget.List, lines scrollLines) {
+	list.List.ScrollToEnd = lines.reverse
+	if request, ok := s.pendingRequest(); ok {
+		line := request.index / lines.itemsPerLine
+		if lines.reverse {
+			// Lay out the lines before the line after the requested one,
+			// which ends scrollOffset pixels past the end of the viewport.
+			list.Position.First = lines.count - line
+			list.Position.Offset = -(list.List.Axis.Convert(gtx.Constraints.Max).X + request.offset)
+			list.Position.BeforeEnd = line > 0 || request.offset > 0
+		} else {
+			list.Position.First = line
+			list.Position.Offset = request.offset
+			list.Position.BeforeEnd = true
+		}
+	}
+	s.startPosition = list.Position
+}
+
+// endLayout publishes the position of list once laid out. Scrolling is in
+// progress while the list is dragged or animated, or moves between frames.
+func (s *scrollState) endLayout(gtx layoutnode.LayoutContext, list *widget.List, lines scrollLines) {
+	position := list.Position
+	metrics := scrollMetrics{
+		firstLine:    position.First,
+		firstOffset:  position.Offset,
+		itemsPerLine: lines.itemsPerLine,
+	}
+	canScrollBackward := position.First > 0 || position.Offset > 0
+	canScrollForward := position.BeforeEnd
+	if lines.reverse {
+		metrics.firstLine = max(lines.count-position.First-position.Count, 0)
+		metrics.firstOffset = max(-position.OffsetLast, 0)
+		canScrollBackward, canScrollForward = canScrollForward, canScrollBackward
+	}
+	if lines.count > 0 {
+		metrics.lineSize = float64(position.Length) / float64(lines.count)
+	}
+	moved := position.First != s.startPosition.First || position.Offset != s.startPosition.Offset
+	s.publish(gtx, scrollPublication{
+		metrics:           metrics,
+		firstVisibleItem:  metrics.firstLine * lines.itemsPerLine,
+		canScrollBackward: canScrollBackward,
+		canScrollForward:  canScrollForward,
+		dragging:          list.List.Dragging(),
+		moved:             moved,
+	})
+}
+
+// scrollPublication is the position of a laid out lazy layout.
+type scrollPublication struct {
+	metrics           scrollMetrics
+	firstVisibleItem  int
+	canScrollBackward bool
+	canScrollForward  bool
+	dragging          bool
+	// moved reports whether the layout moved since the start of the layout.
+	moved bool
+}
+
+// publish updates the observable values from the position of a layout.
+func (s *scrollState) publish(gtx layoutnode.LayoutContext, p scrollPublication) {
+	if now := gtx.Now.UnixNano(); now != s.frame {
+		s.frame = now
+		s.movedInFrame = false
+	}
+	s.movedInFrame = s.movedInFrame || p.moved
+
+	s.metrics.Store(&p.metrics)
+	s.firstVisibleItemIndex.Set(p.firstVisibleItem)
+	s.firstVisibleItemScrollOffset.Set(p.metrics.firstOffset)
+	s.canScrollBackward.Set(p.canScrollBackward)
+	s.canScrollForward.Set(p.canScrollForward)
+	s.scrollInProgress.Set(p.dragging || s.movedInFrame || s.animating.Load() > 0)
+}
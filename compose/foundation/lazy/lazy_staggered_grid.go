@@ -0,0 +1,313 @@
+package lazy
+
+import (
+	"image"
+	"math"
+	"slices"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+
+	"gioui.org/gesture"
+	"gioui.org/io/pointer"
+	"gioui.org/layout"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	gioUnit "gioui.org/unit"
+)
+
+// LazyVerticalStaggeredGrid is a vertically scrolling grid that lays out items
+// of varying heights in columns, each item in the column that ends first.
+func LazyVerticalStaggeredGrid(columns StaggeredCells, content func(LazyStaggeredGridScope), options ...LazyStaggeredGridOption) compose.Composable {
+	return lazyStaggeredGrid(layout.Vertical, columns, content, options...)
+}
+
+// LazyHorizontalStaggeredGrid is a horizontally scrolling grid that lays out
+// items of varying widths in rows, each item in the row that ends first.
+func LazyHorizontalStaggeredGrid(rows StaggeredCells, content func(LazyStaggeredGridScope), options ...LazyStaggeredGridOption) compose.Composable {
+	return lazyStaggeredGrid(layout.Horizontal, rows, content, options...)
+}
+
+// lazyStaggeredGrid declares its items during composition and composes them
+// during layout, like lazyList.
+func lazyStaggeredGrid(axis layout.Axis, cells StaggeredCells, content func(LazyStaggeredGridScope), options ...LazyStaggeredGridOption) compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		opts := DefaultLazyStaggeredGridOptions()
+		for _, opt := range options {
+			opt(&opts)
+		}
+
+		if opts.State == nil {
+			opts.State = RememberLazyStaggeredGridState(c)
+		}
+
+		c.StartBlock("LazyStaggeredGrid")
+		c.Modifier(func(m modifier.Modifier) modifier.Modifier {
+			return m.Then(opts.Modifier)
+		})
+
+		scope := &lazyListScopeImpl{}
+		content(scope)
+
+		items := rememberLazyItems(c)
+		items.update(scope, opts.PrefetchDistance, 0)
+		opts.State.observe()
+
+		g := &lazyStaggeredGridLayout{opts: opts, axis: axis, cells: cells, scope: scope, items: items}
+		c.SetWidgetConstructor(layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
+			return g.layout
+		}))
+		return c.EndBlock()
+	}
+}
+
+// lazyStaggeredGridLayout lays out a staggered grid.
+//
+// The position of an item depends on the sizes of all the items before it, so
+// the items are placed from the first one on, up to the end of the viewport;
+// the state keeps the ends of the lanes at checkpoints along the way, so that a
+// layout pass starts at the last checkpoint before the viewport instead.
+// The sizes of the items are kept by the state, so that only the visible items
+// and the items never measured are composed and measured. The items a jump or
+// the scroll position skips over are not measured either: their sizes are
+// estimated from the average size of the items known, until they are visible.
+type lazyStaggeredGridLayout struct {
+	opts  LazyStaggeredGridOptions
+	axis  layout.Axis
+	cells StaggeredCells
+	scope *lazyListScopeImpl
+	items *lazyItems
+}
+
+// staggeredItem is an item of a staggered grid, placed in a lane.
+type staggeredItem struct {
+	index int
+	key   any
+	span  StaggeredGridItemSpan
+	lane  int
+	start int // along the scrolling axis, from the start of the content
+	size  image.Point
+	call  *op.CallOp
+}
+
+func (g *lazyStaggeredGridLayout) layout(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+	state, axis, scope, items := g.opts.State, g.axis, g.scope, g.items
+	count := scope.itemCount()
+	bounds := axis.Convert(gtx.Constraints.Max)
+	viewport, cross := bounds.X, bounds.Y
+	mainSpacing := gtx.Dp(gioUnit.Dp(g.opts.MainAxisSpacing))
+	crossSpacing := gtx.Dp(gioUnit.Dp(g.opts.CrossAxisSpacing))
+	lanes := g.cells.laneCount(gtx.Metric, cross, crossSpacing)
+	lane := laneSize(cross, lanes, crossSpacing)
+	if lane != state.laneSize || lanes != state.lanes || mainSpacing != state.mainSpacing {
+		state.resetLayout(lane, lanes, mainSpacing)
+	}
+
+	// measure composes and lays out item, recording its drawing.
+	width := func(item *staggeredItem) int {
+		if item.span == StaggeredGridItemSpanFullLine {
+			return cross
+		}
+		return lane
+	}
+	measure := func(item *staggeredItem) {
+		width := width(item)
+		gtx := gtx
+		gtx.Constraints = layout.Constraints{
+			Min: axis.Convert(image.Pt(0, width)),
+			Max: axis.Convert(image.Pt(math.MaxInt32, width)),
+		}
+		macro := op.Record(gtx.Ops)
+		dims := layoutItem(gtx, axis, items.compose(item.index))
+		call := macro.Stop()
+		item.size = dims.Size
+		item.call = &call
+		state.setSize(item.index, item.key, axis.Convert(dims.Size).X)
+	}
+
+	// estimated returns the size of the items skipped over, once an item is known.
+	estimate, estimateKnown := -1, false
+	estimated := func() (int, bool) {
+		if estimate < 0 {
+			estimate, estimateKnown = state.estimate()
+		}
+		return estimate, estimateKnown
+	}
+
+	items.startPass(gtx)
+	start := state.offset
+	request, requested := state.pendingRequest()
+	target := -1
+	if requested && count > 0 {
+		target = min(request.index, count-1)
+	}
+	xrange, yrange := g.scrollRanges()
+	scrolled := state.offset + state.scroll.Update(gtx.Metric, gtx.Source, gtx.Now, gesture.Axis(axis), xrange, yrange)
+
+	// Place the items, until every lane is past the end of the viewport. The
+	// items are placed from the last checkpoint before the viewport on, or
+	// before the target of a jump, whose offset is only known once the target
+	// is placed; they are placed again from an earlier checkpoint if an item
+	// before the checkpoint turns out visible.
+	var (
+		from, offset, contentEnd int
+		placed                   []*staggeredItem
+		reachedEnd               bool
+	)
+	limit, bound := count-1, scrolled
+	if target >= 0 {
+		limit, bound = target, math.MaxInt
+	}
+	for {
+		var ends []int
+		from, ends = state.resume(scope, limit, bound)
+		resumed := slices.Max(ends)
+		offset, placed, reachedEnd = scrolled, nil, true
+		for i := from; i < count; i++ {
+			item := &staggeredItem{index: i, key: scope.key(i), span: scope.span(i)}
+			state.checkpoint(i, item.key, ends)
+			if item.span == StaggeredGridItemSpanFullLine {
+				for _, end := range ends {
+					item.start = max(item.start, end)
+				}
+			} else {
+				for l, end := range ends {
+					if end < ends[item.lane] {
+						item.lane = l
+					}
+				}
+				item.start = ends[item.lane]
+			}
+			if i == target {
+				offset = item.start + request.offset
+			}
+			if item.start >= offset+viewport && i > target {
+				reachedEnd = false
+				break
+			}
+
+			size, measured := state.sizes[item.key]
+			if !measured && (i < target || item.start < offset) {
+				if estimate, ok := estimated(); ok && (i < target || item.start+estimate <= offset) {
+					size, measured = estimate, true
+					state.setSize(i, item.key, size)
+				}
+			}
+			// The offset of a jump is only known once its target is placed; the
+			// items before it that turn out visible are measured when drawn.
+			if !measured || i >= target && item.start < offset+viewport && item.start+size > offset {
+				measure(item)
+				size = state.sizes[item.key]
+			}
+			item.size = axis.Convert(image.Pt(size, width(item)))
+			if item.span == StaggeredGridItemSpanFullLine {
+				for l := range ends {
+					ends[l] = item.start + size + mainSpacing
+				}
+			} else {
+				ends[item.lane] = item.start + size + mainSpacing
+			}
+			placed = append(placed, item)
+		}
+		contentEnd = 0
+		for _, end := range ends {
+			contentEnd = max(contentEnd, end-mainSpacing)
+		}
+		if reachedEnd {
+			offset = min(offset, max(contentEnd-viewport, 0))
+		}
+		offset = max(offset, 0)
+		if resumed-mainSpacing <= offset {
+			break
+		}
+		bound = offset
+	}
+	if offset == 0 || reachedEnd && offset+viewport >= contentEnd {
+		state.scroll.Stop()
+	}
+	state.offset = offset
+
+	size := viewport
+	if reachedEnd {
+		size = min(contentEnd, viewport)
+	}
+	dims := layoutnode.LayoutDimensions{Size: gtx.Constraints.Constrain(axis.Convert(image.Pt(size, cross)))}
+
+	defer clip.Rect(image.Rectangle{Max: dims.Size}).Push(gtx.Ops).Pop()
+	state.scroll.Add(gtx.Ops)
+
+	info := LazyStaggeredGridLayoutInfo{
+		TotalItemsCount:   count,
+		ViewportEndOffset: viewport,
+		ViewportSize:      dims.Size,
+	}
+	first, last := -1, -1
+	for _, item := range placed {
+		size := axis.Convert(item.size).X
+		if item.start+size <= offset || item.start >= offset+viewport {
+			continue
+		}
+		if item.call == nil {
+			// The item became visible once the offset was clamped.
+			measure(item)
+		}
+		if first < 0 {
+			first = item.index
+		}
+		last = item.index
+
+		position := axis.Convert(image.Pt(item.start-offset, item.lane*(lane+crossSpacing)))
+		trans := op.Offset(position).Push(gtx.Ops)
+		item.call.Add(gtx.Ops)
+		trans.Pop()
+		info.VisibleItemsInfo = append(info.VisibleItemsInfo, LazyStaggeredGridItemInfo{
+			Index:  item.index,
+			Key:    item.key,
+			Lane:   item.lane,
+			Span:   item.span,
+			Offset: position,
+			Size:   item.size,
+		})
+	}
+	if first >= 0 {
+		items.endPass(first, last, func(any) bool { return false })
+	} else {
+		items.endPass(0, -1, func(any) bool { return false })
+	}
+	state.layoutInfo.Set(info)
+
+	metrics := scrollMetrics{itemsPerLine: lanes}
+	firstVisible := 0
+	if first >= 0 {
+		firstVisible = first
+		metrics.firstLine = first / lanes
+		metrics.firstOffset = offset - placed[first-from].start
+	}
+	if len(placed) > 0 {
+		metrics.lineSize = float64(contentEnd) * float64(lanes) / float64(from+len(placed))
+	}
+	state.publish(gtx, scrollPublication{
+		metrics:           metrics,
+		firstVisibleItem:  firstVisible,
+		canScrollBackward: offset > 0,
+		canScrollForward:  !reachedEnd || offset+viewport < contentEnd,
+		dragging:          state.scroll.State() == gesture.StateDragging,
+		moved:             offset != start,
+	})
+	return dims
+}
+
+// scrollRanges returns the ranges the grid can scroll by, horizontally and
+// vertically, as known from its last layout.
+func (g *lazyStaggeredGridLayout) scrollRanges() (pointer.ScrollRange, pointer.ScrollRange) {
+	state := g.opts.State
+	scroll := pointer.ScrollRange{Min: -state.offset, Max: math.MaxInt32}
+	if info := state.layoutInfo.Get(); !state.CanScrollForward() && len(info.VisibleItemsInfo) > 0 {
+		scroll.Max = 0
+	}
+	if g.axis == layout.Vertical {
+		return pointer.ScrollRange{}, scroll
+	}
+	return scroll, pointer.ScrollRange{}
+}
@@ -0,0 +1,16 @@
+package lazy
+
+import (
+	"github.com/zodimo/go-compose/compose"
+)
+
+// LazyStaggeredGridScope is a DSL scope for defining the items of a staggered
+// grid. Items span a single lane unless WithSpan says otherwise.
+type LazyStaggeredGridScope interface {
+	Item(key any, content compose.Composable, options ...LazyItemOption)
+	// Items adds count items. Neither key nor itemContent is called before the
+	// item is about to be shown.
+	Items(count int, key func(index int) any, itemContent func(index int) compose.Composable, options ...LazyItemOption)
+}
+
+var _ LazyStaggeredGridScope = (*lazyListScopeImpl)(nil)
@@ -0,0 +1,202 @@
+package lazy
+
+import (
+	"encoding/json"
+	"image"
+	"slices"
+	"sort"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+
+	"gioui.org/gesture"
+)
+
+// LazyStaggeredGridState controls and observes the scrolling of a staggered
+// grid. Like LazyListState, its position and LayoutInfo are updated as the
+// grid is laid out; its item indices are those of the items.
+type LazyStaggeredGridState struct {
+	scrollState
+
+	scroll gesture.Scroll
+	// offset is the scroll position, from the start of the content.
+	offset int
+	// sizes holds the size along the scrolling axis of the items measured, or
+	// estimated for the items skipped over, by key, for lanes of laneSize.
+	// sizeTotal is their sum.
+	sizes     map[any]int
+	sizeTotal int
+	laneSize  int
+	// checkpoints holds the ends of the lanes before every
+	// staggeredCheckpointInterval-th item, as placed with lanes lanes and
+	// mainSpacing between items, so that a layout pass places the items from
+	// the last checkpoint before the viewport on. itemCount is the number of
+	// items the checkpoints and sizes were checked against.
+	checkpoints []staggeredCheckpoint
+	lanes       int
+	mainSpacing int
+	itemCount   int
+
+	layoutInfo state.MutableState[LazyStaggeredGridLayoutInfo]
+}
+
+// staggeredCheckpointInterval is the number of items between two checkpoints
+// of a staggered grid.
+const staggeredCheckpointInterval = 32
+
+// staggeredCheckpoint holds the ends of the lanes of a staggered grid before
+// the item of key is placed, spacing included.
+type staggeredCheckpoint struct {
+	key  any
+	ends []int
+}
+
+// LazyStaggeredGridLayoutInfo describes the last layout of a staggered grid.
+type LazyStaggeredGridLayoutInfo struct {
+	// VisibleItemsInfo holds the items that are laid out, in order.
+	VisibleItemsInfo []LazyStaggeredGridItemInfo
+	TotalItemsCount  int
+	// ViewportStartOffset and ViewportEndOffset are the bounds of the viewport
+	// along the scrolling axis of the grid.
+	ViewportStartOffset int
+	ViewportEndOffset   int
+	ViewportSize        image.Point
+}
+
+// LazyStaggeredGridItemInfo describes an item of the last layout of a
+// staggered grid.
+type LazyStaggeredGridItemInfo struct {
+	Index int
+	Key   any
+	// Lane is the lane of the item; it is 0 for items of the full line.
+	Lane int
+	Span StaggeredGridItemSpan
+	// Offset is the position of the item relative to the start of the viewport.
+	Offset image.Point
+	Size   image.Point
+}
+
+// NewLazyStaggeredGridState creates a LazyStaggeredGridState scrolled to the
+// start of the grid.
+func NewLazyStaggeredGridState() *LazyStaggeredGridState {
+	return &LazyStaggeredGridState{
+		scrollState: newScrollState(),
+		sizes:       map[any]int{},
+		layoutInfo:  state.NewMutableState(LazyStaggeredGridLayoutInfo{}, nil),
+	}
+}
+
+// resetLayout drops the checkpoints once the lanes change, and the sizes too
+// once the size of the lanes does.
+func (s *LazyStaggeredGridState) resetLayout(laneSize, lanes, mainSpacing int) {
+	if laneSize != s.laneSize {
+		s.laneSize = laneSize
+		s.sizes = map[any]int{}
+		s.sizeTotal = 0
+	}
+	s.lanes, s.mainSpacing = lanes, mainSpacing
+	s.checkpoints = nil
+}
+
+// setSize records the size of the item of key at index. The checkpoints after
+// the item are dropped once its size changes, as the items after it move.
+func (s *LazyStaggeredGridState) setSize(index int, key any, size int) {
+	old, ok := s.sizes[key]
+	if ok && old == size {
+		return
+	}
+	s.sizes[key] = size
+	s.sizeTotal += size - old
+	s.checkpoints = s.checkpoints[:min(len(s.checkpoints), index/staggeredCheckpointInterval+1)]
+}
+
+// estimate returns the average size of the items known.
+func (s *LazyStaggeredGridState) estimate() (int, bool) {
+	if len(s.sizes) == 0 {
+		return 0, false
+	}
+	return s.sizeTotal / len(s.sizes), true
+}
+
+// checkpoint records the ends of the lanes before the item of key at index,
+// once index is the next multiple of staggeredCheckpointInterval to record.
+func (s *LazyStaggeredGridState) checkpoint(index int, key any, ends []int) {
+	if index%staggeredCheckpointInterval == 0 && index/staggeredCheckpointInterval == len(s.checkpoints) {
+		s.checkpoints = append(s.checkpoints, staggeredCheckpoint{key: key, ends: slices.Clone(ends)})
+	}
+}
+
+// resume returns the index of the last checkpoint up to index limit before
+// which no item ends past offset, and the ends of the lanes there. The items
+// of content are checked against the checkpoints once their count changes,
+// and against the checkpoint resumed from otherwise.
+func (s *LazyStaggeredGridState) resume(content lazyContent, limit, offset int) (int, []int) {
+	if count := content.itemCount(); count != s.itemCount {
+		s.itemCount = count
+		s.prune(content)
+	}
+	for {
+		n := sort.Search(len(s.checkpoints), func(k int) bool {
+			return k*staggeredCheckpointInterval > limit || slices.Max(s.checkpoints[k].ends)-s.mainSpacing > offset
+		})
+		if n == 0 {
+			return 0, make([]int, s.lanes)
+		}
+		index, checkpoint := (n-1)*staggeredCheckpointInterval, s.checkpoints[n-1]
+		if content.key(index) == checkpoint.key {
+			return index, slices.Clone(checkpoint.ends)
+		}
+		s.prune(content)
+	}
+}
+
+// prune drops the sizes of the items no longer in content, and the
+// checkpoints from the first one whose item changed on.
+func (s *LazyStaggeredGridState) prune(content lazyContent) {
+	count := content.itemCount()
+	keys := make(map[any]struct{}, count)
+	for i := 0; i < count; i++ {
+		keys[content.key(i)] = struct{}{}
+	}
+	for key, size := range s.sizes {
+		if _, ok := keys[key]; !ok {
+			delete(s.sizes, key)
+			s.sizeTotal -= size
+		}
+	}
+	for k, checkpoint := range s.checkpoints {
+		if index := k * staggeredCheckpointInterval; index >= count || content.key(index) != checkpoint.key {
+			s.checkpoints = s.checkpoints[:k]
+			break
+		}
+	}
+}
+
+// LayoutInfo returns the items laid out by the last layout of the grid.
+func (s *LazyStaggeredGridState) LayoutInfo() LazyStaggeredGridLayoutInfo {
+	return s.layoutInfo.Get()
+}
+
+// RememberLazyStaggeredGridState creates or retrieves a remembered
+// LazyStaggeredGridState. Its scroll position is saveable, so it is restored
+// after a restart.
+func RememberLazyStaggeredGridState(c compose.Composer) *LazyStaggeredGridState {
+	return state.RememberSaveable(c, "lazyStaggeredGridState", NewLazyStaggeredGridState, LazyStaggeredGridStateSaver).Get()
+}
+
+// LazyStaggeredGridStateSaver saves the first visible item of a
+// LazyStaggeredGridState, which the restored state scrolls to.
+var LazyStaggeredGridStateSaver = state.NewSaver(
+	func(s *LazyStaggeredGridState) ([]byte, error) {
+		return json.Marshal(scrollPosition{First: s.FirstVisibleItemIndex(), Offset: s.FirstVisibleItemScrollOffset()})
+	},
+	func(data []byte) (*LazyStaggeredGridState, error) {
+		var position scrollPosition
+		if err := json.Unmarshal(data, &position); err != nil {
+			return nil, err
+		}
+		s := NewLazyStaggeredGridState()
+		s.ScrollToItem(position.First, position.Offset)
+		return s, nil
+	},
+)
@@ -1,6 +1,7 @@
 package lazy
 
 import (
+	foundationLayout "github.com/zodimo/go-compose/compose/foundation/layout"
 	"github.com/zodimo/go-compose/internal/modifier"
 )
 
@@ -9,12 +10,43 @@ type LazyListOption func(*LazyListOptions)
 type LazyListOptions struct {
 	Modifier modifier.Modifier
 	State    *LazyListState
+	// PrefetchDistance is the number of items on each side of the visible ones
+	// that are composed ahead of time.
+	PrefetchDistance int
+	// RetainedItems is the number of items of each content type scrolled out of
+	// view that keep their composition, and so their state; the others are
+	// disposed.
+	RetainedItems int
+	// ContentPadding is the padding around the items, scrolled with them.
+	ContentPadding foundationLayout.PaddingValues
+	// VerticalArrangement places the items of a LazyColumn, and
+	// HorizontalArrangement those of a LazyRow: the spacing between them, and
+	// where they are placed when they do not fill the list. The alignment
+	// follows the direction of the layout, so ArrangeStart is at the bottom of
+	// a reversed column.
+	VerticalArrangement   foundationLayout.Arrangement
+	HorizontalArrangement foundationLayout.Arrangement
+	// ReverseLayout lays out the items from the end of the list: the first
+	// item is at the bottom of a column, which scrolls from there. The list
+	// stays at its start as items are added before the first one, as in a
+	// chat.
+	ReverseLayout bool
 }
 
+// DefaultPrefetchDistance is the default PrefetchDistance of lazy lists.
+const DefaultPrefetchDistance = 2
+
 func DefaultLazyListOptions() LazyListOptions {
 	return LazyListOptions{
-		Modifier: modifier.EmptyModifier,
-		State:    nil,
+		Modifier:         modifier.EmptyModifier,
+		State:            nil,
+		PrefetchDistance: DefaultPrefetchDistance,
+		RetainedItems:    0,
+		ContentPadding:   foundationLayout.PaddingValues{},
+
+		VerticalArrangement:   foundationLayout.ArrangementStart,
+		HorizontalArrangement: foundationLayout.ArrangementStart,
+		ReverseLayout:         false,
 	}
 }
 
@@ -29,3 +61,91 @@ func WithState(state *LazyListState) LazyListOption {
 		o.State = state
 	}
 }
+
+func WithPrefetchDistance(items int) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.PrefetchDistance = items
+	}
+}
+
+func WithRetainedItems(items int) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.RetainedItems = items
+	}
+}
+
+func WithContentPadding(padding foundationLayout.PaddingValues) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.ContentPadding = padding
+	}
+}
+
+func WithVerticalArrangement(arrangement foundationLayout.Arrangement) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.VerticalArrangement = arrangement
+	}
+}
+
+func WithHorizontalArrangement(arrangement foundationLayout.Arrangement) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.HorizontalArrangement = arrangement
+	}
+}
+
+func WithReverseLayout(reverse bool) LazyListOption {
+	return func(o *LazyListOptions) {
+		o.ReverseLayout = reverse
+	}
+}
+
+// LazyItemOption is a functional option for configuring the items of lazy lists.
+type LazyItemOption func(*LazyItemOptions)
+
+// LazyItemOptions holds configuration for items of a lazy list.
+type LazyItemOptions struct {
+	// ContentType returns the type of the content of the item at an index of
+	// the items added together. The composition of an item is only reused for
+	// content of the same type, and items scrolled out of view are retained per
+	// content type. Items without a content type share the nil type.
+	ContentType func(index int) any
+	// Span returns the span of the item at an index of the items added
+	// together, in staggered grids; lists ignore it.
+	Span func(index int) StaggeredGridItemSpan
+}
+
+// DefaultLazyItemOptions returns the default options for items of a lazy list.
+func DefaultLazyItemOptions() LazyItemOptions {
+	return LazyItemOptions{
+		ContentType: nil,
+		Span:        nil,
+	}
+}
+
+// WithContentType sets the content type of all the items added together.
+func WithContentType(contentType any) LazyItemOption {
+	return func(o *LazyItemOptions) {
+		o.ContentType = func(int) any { return contentType }
+	}
+}
+
+// WithContentTypes sets the content type of each of the items added together.
+func WithContentTypes(contentType func(index int) any) LazyItemOption {
+	return func(o *LazyItemOptions) {
+		o.ContentType = contentType
+	}
+}
+
+// WithSpan sets the span of all the items added together to a staggered grid.
+func WithSpan(span StaggeredGridItemSpan) LazyItemOption {
+	return func(o *LazyItemOptions) {
+		o.Span = func(int) StaggeredGridItemSpan { return span }
+	}
+}
+
+// WithSpans sets the span of each of the items added together to a staggered
+// grid.
+func WithSpans(span func(index int) StaggeredGridItemSpan) LazyItemOption {
+	return func(o *LazyItemOptions) {
+		o.Span = span
+	}
+}
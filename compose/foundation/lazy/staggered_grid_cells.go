@@ -0,0 +1,65 @@
+package lazy
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
+
+	gioUnit "gioui.org/unit"
+)
+
+// StaggeredCells determines the lanes of a staggered grid: its columns (for
+// LazyVerticalStaggeredGrid) or rows (for LazyHorizontalStaggeredGrid).
+type StaggeredCells interface {
+	// laneCount returns the number of lanes fitting in availableSpacePx.
+	laneCount(metric gioUnit.Metric, availableSpacePx int, spacingPx int) int
+}
+
+// StaggeredGridCells creates the StaggeredCells of staggered grids.
+var StaggeredGridCells = staggeredGridCells{}
+
+type staggeredGridCells struct{}
+
+// Fixed creates StaggeredCells with count lanes.
+func (staggeredGridCells) Fixed(count int) StaggeredCells {
+	return staggeredCellsFixed{count: max(count, 1)}
+}
+
+// Adaptive creates StaggeredCells with as many lanes as fit, each at least
+// minSize across. The lanes share the remaining space.
+func (staggeredGridCells) Adaptive(minSize unit.Dp) StaggeredCells {
+	return staggeredCellsAdaptive{minSize: minSize}
+}
+
+type staggeredCellsFixed struct {
+	count int
+}
+
+func (c staggeredCellsFixed) laneCount(metric gioUnit.Metric, availableSpacePx int, spacingPx int) int {
+	return c.count
+}
+
+type staggeredCellsAdaptive struct {
+	minSize unit.Dp
+}
+
+func (c staggeredCellsAdaptive) laneCount(metric gioUnit.Metric, availableSpacePx int, spacingPx int) int {
+	minSizePx := max(metric.Dp(gioUnit.Dp(c.minSize)), 1)
+	// n lanes need n-1 gaps: n*minSize + (n-1)*spacing <= available
+	return max((availableSpacePx+spacingPx)/(minSizePx+spacingPx), 1)
+}
+
+// laneSize returns the size of each of count lanes sharing availableSpacePx.
+func laneSize(availableSpacePx, count, spacingPx int) int {
+	return max(availableSpacePx-spacingPx*(count-1), 0) / count
+}
+
+// StaggeredGridItemSpan is the span of an item of a staggered grid.
+type StaggeredGridItemSpan int
+
+const (
+	// StaggeredGridItemSpanSingleLane places the item in the lane that ends
+	// first.
+	StaggeredGridItemSpanSingleLane StaggeredGridItemSpan = iota
+	// StaggeredGridItemSpanFullLine places the item across all the lanes,
+	// after the items before it.
+	StaggeredGridItemSpanFullLine
+)
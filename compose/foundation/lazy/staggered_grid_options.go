@@ -0,0 +1,69 @@
+package lazy
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+// LazyStaggeredGridOption is a functional option for configuring staggered grids.
+type LazyStaggeredGridOption func(*LazyStaggeredGridOptions)
+
+// LazyStaggeredGridOptions holds configuration for a staggered grid.
+type LazyStaggeredGridOptions struct {
+	Modifier modifier.Modifier
+	State    *LazyStaggeredGridState
+	// MainAxisSpacing is the space between the items of a lane, and
+	// CrossAxisSpacing the space between the lanes.
+	MainAxisSpacing  unit.Dp
+	CrossAxisSpacing unit.Dp
+	// PrefetchDistance is the number of items on each side of the visible ones
+	// that are composed ahead of time.
+	PrefetchDistance int
+}
+
+// DefaultLazyStaggeredGridOptions returns the default options for a staggered grid.
+func DefaultLazyStaggeredGridOptions() LazyStaggeredGridOptions {
+	return LazyStaggeredGridOptions{
+		Modifier:         modifier.EmptyModifier,
+		State:            nil,
+		MainAxisSpacing:  0,
+		CrossAxisSpacing: 0,
+		PrefetchDistance: DefaultPrefetchDistance,
+	}
+}
+
+// WithStaggeredGridModifier applies a modifier to the staggered grid.
+func WithStaggeredGridModifier(m modifier.Modifier) LazyStaggeredGridOption {
+	return func(o *LazyStaggeredGridOptions) {
+		o.Modifier = m
+	}
+}
+
+// WithStaggeredGridState sets the state controlling the scrolling of the grid.
+func WithStaggeredGridState(state *LazyStaggeredGridState) LazyStaggeredGridOption {
+	return func(o *LazyStaggeredGridOptions) {
+		o.State = state
+	}
+}
+
+// WithMainAxisSpacing sets the space between the items of a lane.
+func WithMainAxisSpacing(spacing unit.Dp) LazyStaggeredGridOption {
+	return func(o *LazyStaggeredGridOptions) {
+		o.MainAxisSpacing = spacing
+	}
+}
+
+// WithCrossAxisSpacing sets the space between the lanes.
+func WithCrossAxisSpacing(spacing unit.Dp) LazyStaggeredGridOption {
+	return func(o *LazyStaggeredGridOptions) {
+		o.CrossAxisSpacing = spacing
+	}
+}
+
+// WithStaggeredGridPrefetchDistance sets the number of items composed ahead of
+// time on each side of the visible ones.
+func WithStaggeredGridPrefetchDistance(items int) LazyStaggeredGridOption {
+	return func(o *LazyStaggeredGridOptions) {
+		o.PrefetchDistance = items
+	}
+}
@@ -1,8 +1,6 @@
 package text
 
 import (
-	"fmt"
-
 	"gioui.org/op"
 	"gioui.org/op/paint"
 
@@ -102,12 +100,7 @@ func BasicText(
 					)
 			})
 
-			key := c.GenerateID()
-			path := c.GetPath()
-
-			displayTextPath := fmt.Sprintf("%d/%s/displayText", key, path)
-
-			displayText := c.State(displayTextPath, func() any {
+			displayText := c.State("displayText", func() any {
 				return annotatedString
 			})
 
@@ -1,7 +1,6 @@
 package text
 
 import (
-	"fmt"
 	"image"
 
 	"github.com/zodimo/go-compose/compose"
@@ -40,17 +39,12 @@ func Text(value string, options ...TextOption) Composable {
 		// Resolve text style with defaults
 		opts.TextStyle = text.TextStyleResolveDefaults(opts.TextStyle, layoutDirection)
 
-		path := c.GetPath()
-		key := c.GenerateID()
-
-		selectablePath := fmt.Sprintf("%d/%s/selectable", key, path)
-
 		// @TODO selection container present
 
 		var selectable state.MutableValue
 		if opts.Selectable.IsSome() {
 			if opts.Selectable.UnwrapUnsafe() {
-				selectable = c.State(selectablePath, func() any { return &widget.Selectable{} })
+				selectable = c.State("selectable", func() any { return &widget.Selectable{} })
 			}
 		}
 
@@ -1,8 +1,6 @@
 package textfield
 
 import (
-	"fmt"
-
 	"gioui.org/op"
 	"gioui.org/op/paint"
 
@@ -56,18 +54,14 @@ func BasicTextField(
 		textShaper := compose.LocalTextShaper.Current(c)
 		layoutDirection := platform.LocalLayoutDirection.Current(c)
 
-		// Generate unique key for state persistence
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Store the controller in compose state to persist across frames
-		controllerState := c.State(fmt.Sprintf("%d/%s/textfield_controller", key, path), func() any {
+		controllerState := c.State("textfield_controller", func() any {
 			return input.NewEditableTextLayoutController(state)
 		})
 		controller := controllerState.Get().(*input.EditableTextLayoutController)
 
 		// Store onValueChange wrapper to avoid closure capture issues
-		handlerState := c.State(fmt.Sprintf("%d/%s/handler", key, path), func() any {
+		handlerState := c.State("handler", func() any {
 			return &onValueChangeWrapper{Func: onValueChange}
 		})
 		handler := handlerState.Get().(*onValueChangeWrapper)
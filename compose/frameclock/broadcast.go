@@ -0,0 +1,279 @@
+package frameclock
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// BroadcastFrameClock is the clock of a host, which sends it the time of each
+// of its frames. It keeps track of the goroutines waiting on it, so that tests
+// know when the animations and effects of a composition are idle.
+type BroadcastFrameClock interface {
+	MonotonicFrameClock
+	// SendFrame sets the time of the clock to frameTime, before the frame is
+	// composed. The goroutines waiting for a frame have their onFrame function
+	// called, on the calling goroutine, and resume with those whose delay has
+	// passed.
+	SendFrame(frameTime time.Time)
+	// Pending reports whether a frame was requested since the last one was
+	// sent, or goroutines wait for a frame or for a delay. Infinite animations
+	// are not pending.
+	Pending() bool
+	// Idle returns a channel that is closed once none of the goroutines started
+	// by Go runs, as they all wait on the clock or have returned.
+	Idle() <-chan struct{}
+}
+
+var _ BroadcastFrameClock = (*broadcastFrameClock)(nil)
+
+type broadcastFrameClock struct {
+	onAwait func(deadline time.Time)
+
+	mu        sync.Mutex
+	now       time.Time
+	frames    []*waiter
+	delays    []*waiter
+	requested bool
+	// busy is the number of goroutines started by Go that run.
+	busy int
+	// idle is closed while busy is zero.
+	idle chan struct{}
+}
+
+// waiter is a goroutine waiting for a frame, or for a delay.
+type waiter struct {
+	onFrame  func(frameTimeNanos int64)
+	infinite bool
+	deadline time.Time
+	tracked  bool
+	waiting  bool
+	released bool
+	resume   chan struct{}
+}
+
+// NewBroadcastFrameClock returns a clock whose time is that of the last frame
+// sent. The clock calls onAwait when it needs a frame: with the zero time for
+// the next frame, and with the deadline of a delay otherwise. A window
+// invalidates itself then, at the deadline if there is one.
+func NewBroadcastFrameClock(onAwait func(deadline time.Time)) BroadcastFrameClock {
+	idle := make(chan struct{})
+	close(idle)
+	return &broadcastFrameClock{
+		onAwait: onAwait,
+		idle:    idle,
+	}
+}
+
+func (c *broadcastFrameClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *broadcastFrameClock) WithFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64)) error {
+	return c.withFrameNanos(ctx, onFrame, false)
+}
+
+func (c *broadcastFrameClock) withFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64), infinite bool) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	w := c.newWaiter(ctx)
+	w.onFrame = onFrame
+	w.infinite = infinite
+
+	c.mu.Lock()
+	c.frames = append(c.frames, w)
+	first := len(c.frames) == 1 && !c.requested
+	c.mu.Unlock()
+	if first {
+		c.await(time.Time{})
+	}
+	return c.wait(ctx, w)
+}
+
+func (c *broadcastFrameClock) Delay(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil || d <= 0 {
+		return err
+	}
+	w := c.newWaiter(ctx)
+
+	c.mu.Lock()
+	w.deadline = c.now.Add(d)
+	c.delays = append(c.delays, w)
+	c.mu.Unlock()
+	c.await(w.deadline)
+	return c.wait(ctx, w)
+}
+
+func (c *broadcastFrameClock) RequestFrame() {
+	c.mu.Lock()
+	requested := c.requested || len(c.frames) > 0
+	c.requested = true
+	c.mu.Unlock()
+	if !requested {
+		c.await(time.Time{})
+	}
+}
+
+func (c *broadcastFrameClock) SendFrame(frameTime time.Time) {
+	c.mu.Lock()
+	c.now = frameTime
+	c.requested = false
+	frames := c.frames
+	c.frames = nil
+	var delays []*waiter
+	c.delays = slices.DeleteFunc(c.delays, func(w *waiter) bool {
+		if w.deadline.After(frameTime) {
+			return false
+		}
+		delays = append(delays, w)
+		return true
+	})
+	released := append(frames, delays...)
+	for _, w := range released {
+		c.release(w)
+	}
+	c.mu.Unlock()
+
+	for _, w := range frames {
+		w.onFrame(frameTime.UnixNano())
+	}
+	for _, w := range released {
+		close(w.resume)
+	}
+}
+
+func (c *broadcastFrameClock) Pending() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	finite := slices.ContainsFunc(c.frames, func(w *waiter) bool { return !w.infinite })
+	return c.requested || finite || len(c.delays) > 0
+}
+
+func (c *broadcastFrameClock) Idle() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.idle
+}
+
+func (c *broadcastFrameClock) await(deadline time.Time) {
+	if c.onAwait != nil {
+		c.onAwait(deadline)
+	}
+}
+
+// newWaiter returns the waiter of the goroutine of ctx.
+func (c *broadcastFrameClock) newWaiter(ctx context.Context) *waiter {
+	return &waiter{
+		tracked: ctx.Value(goKey{}) == c,
+		resume:  make(chan struct{}),
+	}
+}
+
+// wait waits for w to be released by SendFrame, or for ctx to be done. The
+// goroutine stops running for the clock meanwhile, if it was started by Go.
+func (c *broadcastFrameClock) wait(ctx context.Context, w *waiter) error {
+	c.mu.Lock()
+	if w.tracked && !w.released {
+		c.setBusy(-1)
+		w.waiting = true
+	}
+	c.mu.Unlock()
+	select {
+	case <-w.resume:
+		return nil
+	case <-ctx.Done():
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !w.released {
+		c.frames = slices.DeleteFunc(c.frames, func(f *waiter) bool { return f == w })
+		c.delays = slices.DeleteFunc(c.delays, func(d *waiter) bool { return d == w })
+		c.release(w)
+	}
+	return ctx.Err()
+}
+
+// release marks w as released, and its goroutine as running again. The lock
+// must be held.
+func (c *broadcastFrameClock) release(w *waiter) {
+	w.released = true
+	if w.waiting {
+		c.setBusy(1)
+	}
+}
+
+// setBusy adds delta to the number of running goroutines. The lock must be
+// held.
+func (c *broadcastFrameClock) setBusy(delta int) {
+	before := c.busy
+	c.busy += delta
+	switch {
+	case before == 0 && c.busy > 0:
+		c.idle = make(chan struct{})
+	case before > 0 && c.busy == 0:
+		close(c.idle)
+	}
+}
+
+// WithInfiniteAnimationFrameNanos waits for the next frame as WithFrameNanos,
+// for animations that do not end. A BroadcastFrameClock does not count them as
+// pending, so that tests waiting for the animations to end do not wait for
+// them.
+func WithInfiniteAnimationFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64)) error {
+	clock := FromContext(ctx)
+	if c, ok := clock.(*broadcastFrameClock); ok {
+		return c.withFrameNanos(ctx, onFrame, true)
+	}
+	return clock.WithFrameNanos(ctx, onFrame)
+}
+
+type goKey struct{}
+
+// Go runs block in a new goroutine, with clock in its context. A
+// BroadcastFrameClock counts the goroutine as running until block returns,
+// except while it waits on the clock. The goroutines that block starts itself
+// and that wait on the clock must be started with Go too.
+func Go(ctx context.Context, clock MonotonicFrameClock, block func(ctx context.Context)) {
+	ctx = WithFrameClock(ctx, clock)
+	c, ok := clock.(*broadcastFrameClock)
+	if !ok {
+		go block(ctx)
+		return
+	}
+	ctx = context.WithValue(ctx, goKey{}, c)
+	c.mu.Lock()
+	c.setBusy(1)
+	c.mu.Unlock()
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			c.setBusy(-1)
+			c.mu.Unlock()
+		}()
+		block(ctx)
+	}()
+}
+
+// Await runs wait, which blocks on something other than the clock, such as a
+// channel or a flow. The goroutine of ctx does not count as running for the
+// clock meanwhile, so that tests do not wait for it.
+func Await(ctx context.Context, wait func()) {
+	c, ok := ctx.Value(goKey{}).(*broadcastFrameClock)
+	if !ok {
+		wait()
+		return
+	}
+	c.mu.Lock()
+	c.setBusy(-1)
+	c.mu.Unlock()
+	defer func() {
+		c.mu.Lock()
+		c.setBusy(1)
+		c.mu.Unlock()
+	}()
+	wait()
+}
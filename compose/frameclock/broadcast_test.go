@@ -0,0 +1,122 @@
+package frameclock_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+)
+
+var epoch = time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// waitIdle waits for the goroutines started by Go to wait on clock.
+func waitIdle(t *testing.T, clock frameclock.BroadcastFrameClock) {
+	t.Helper()
+	select {
+	case <-clock.Idle():
+	case <-time.After(time.Second):
+		t.Fatal("goroutines still running")
+	}
+}
+
+func TestBroadcastFrameClockSendsFrames(t *testing.T) {
+	awaited := 0
+	clock := frameclock.NewBroadcastFrameClock(func(deadline time.Time) {
+		if deadline.IsZero() {
+			awaited++
+		}
+	})
+	clock.SendFrame(epoch)
+
+	frames := make(chan int64, 2)
+	frameclock.Go(context.Background(), clock, func(ctx context.Context) {
+		for range 2 {
+			frameclock.WithFrameNanos(ctx, func(frameTimeNanos int64) {
+				frames <- frameTimeNanos
+			})
+		}
+	})
+	waitIdle(t, clock)
+	if !clock.Pending() || awaited != 1 {
+		t.Fatalf("Pending() = %v after %d awaits, want a frame awaited", clock.Pending(), awaited)
+	}
+
+	for i := range 2 {
+		now := epoch.Add(time.Duration(i+1) * frameclock.FrameDuration)
+		clock.SendFrame(now)
+		if got := <-frames; got != now.UnixNano() {
+			t.Errorf("frame %d at %d, want %d", i, got, now.UnixNano())
+		}
+		waitIdle(t, clock)
+	}
+	if clock.Pending() {
+		t.Error("Pending() = true once the goroutine returned")
+	}
+}
+
+func TestBroadcastFrameClockDelay(t *testing.T) {
+	var deadlines []time.Time
+	clock := frameclock.NewBroadcastFrameClock(func(deadline time.Time) {
+		deadlines = append(deadlines, deadline)
+	})
+	clock.SendFrame(epoch)
+
+	done := make(chan struct{})
+	frameclock.Go(context.Background(), clock, func(ctx context.Context) {
+		if frameclock.Delay(ctx, 100*time.Millisecond) == nil {
+			close(done)
+		}
+	})
+	waitIdle(t, clock)
+	if len(deadlines) != 1 || !deadlines[0].Equal(epoch.Add(100*time.Millisecond)) {
+		t.Fatalf("awaited %v, want the deadline of the delay", deadlines)
+	}
+
+	clock.SendFrame(epoch.Add(50 * time.Millisecond))
+	waitIdle(t, clock)
+	select {
+	case <-done:
+		t.Fatal("delay ended halfway")
+	default:
+	}
+
+	clock.SendFrame(epoch.Add(100 * time.Millisecond))
+	waitIdle(t, clock)
+	select {
+	case <-done:
+	default:
+		t.Fatal("delay did not end at its deadline")
+	}
+}
+
+func TestBroadcastFrameClockCancel(t *testing.T) {
+	clock := frameclock.NewBroadcastFrameClock(nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	frameclock.Go(ctx, clock, func(ctx context.Context) {
+		errs <- frameclock.Delay(ctx, time.Hour)
+	})
+	waitIdle(t, clock)
+
+	cancel()
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("Delay returned %v, want %v", err, context.Canceled)
+	}
+	waitIdle(t, clock)
+	if clock.Pending() {
+		t.Error("Pending() = true once the delay was cancelled")
+	}
+}
+
+func TestAwaitIsIdle(t *testing.T) {
+	clock := frameclock.NewBroadcastFrameClock(nil)
+	values := make(chan int)
+	frameclock.Go(context.Background(), clock, func(ctx context.Context) {
+		frameclock.Await(ctx, func() {
+			<-values
+		})
+	})
+	waitIdle(t, clock)
+	close(values)
+}
@@ -0,0 +1,110 @@
+// Package frameclock provides the time of the frames of a composition to the
+// animations and effects that run with it, as the MonotonicFrameClock of
+// Compose. The host of a composition drives a BroadcastFrameClock: a window
+// sends it the time of each of its frames, and composetest the time of its
+// test clock, so that animations can be tested frame by frame.
+//
+// The clock is found three ways:
+//
+//   - during composition, with LocalFrameClock.Current(c);
+//   - in the goroutines of effects, with FromContext(ctx);
+//   - during layout, with Current(gtx).
+//
+// A composition without a host clock uses the system clock.
+package frameclock
+
+import (
+	"context"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+// MonotonicFrameClock is the clock of the frames of a composition.
+type MonotonicFrameClock interface {
+	// Now returns the time of the current frame.
+	Now() time.Time
+	// WithFrameNanos waits for the next frame and calls onFrame with its time,
+	// in nanoseconds since the Unix epoch, before the frame is composed. It
+	// returns the error of ctx when ctx is done first.
+	WithFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64)) error
+	// Delay waits until the clock is d past its current time. It returns the
+	// error of ctx when ctx is done first.
+	Delay(ctx context.Context, d time.Duration) error
+	// RequestFrame asks for a frame after the current one, for animations that
+	// run during layout.
+	RequestFrame()
+}
+
+// LocalFrameClock is the clock of the composition. Hosts provide it at the
+// root of their content.
+var LocalFrameClock = compose.StaticCompositionLocalOf(func() MonotonicFrameClock {
+	return System
+})
+
+type contextKey struct{}
+
+// WithFrameClock returns ctx carrying clock.
+func WithFrameClock(ctx context.Context, clock MonotonicFrameClock) context.Context {
+	return context.WithValue(ctx, contextKey{}, clock)
+}
+
+// FromContext returns the clock carried by ctx, or System.
+func FromContext(ctx context.Context) MonotonicFrameClock {
+	if clock, ok := ctx.Value(contextKey{}).(MonotonicFrameClock); ok {
+		return clock
+	}
+	return System
+}
+
+// WithFrameNanos waits for the next frame of the clock of ctx, as
+// MonotonicFrameClock.WithFrameNanos.
+func WithFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64)) error {
+	return FromContext(ctx).WithFrameNanos(ctx, onFrame)
+}
+
+// Delay waits for d on the clock of ctx, in place of time.Sleep or time.After
+// in effects, so that tests control it.
+func Delay(ctx context.Context, d time.Duration) error {
+	return FromContext(ctx).Delay(ctx, d)
+}
+
+const layoutKey = "frameclock.clock"
+
+// Provide returns gtx with clock as the clock of the elements it lays out.
+func Provide(gtx layout.Context, clock MonotonicFrameClock) layout.Context {
+	values := make(map[string]any, len(gtx.Values)+1)
+	for k, v := range gtx.Values {
+		values[k] = v
+	}
+	values[layoutKey] = clock
+	gtx.Values = values
+	return gtx
+}
+
+// Current returns the clock provided to gtx. Without one, the clock is that of
+// gtx itself: its time is gtx.Now, and requesting a frame invalidates the
+// window.
+func Current(gtx layout.Context) MonotonicFrameClock {
+	if clock, ok := gtx.Values[layoutKey].(MonotonicFrameClock); ok {
+		return clock
+	}
+	return layoutClock{systemClock: systemClock{}, gtx: gtx}
+}
+
+// layoutClock is the clock of a layout.Context without a provided clock.
+type layoutClock struct {
+	systemClock
+	gtx layout.Context
+}
+
+func (c layoutClock) Now() time.Time {
+	return c.gtx.Now
+}
+
+func (c layoutClock) RequestFrame() {
+	c.gtx.Execute(op.InvalidateCmd{})
+}
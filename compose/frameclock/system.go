@@ -0,0 +1,42 @@
+package frameclock
+
+import (
+	"context"
+	"time"
+)
+
+// FrameDuration is the time between the frames of the system clock.
+const FrameDuration = 16 * time.Millisecond
+
+// System is the clock of compositions without a host clock. It runs on the
+// time of the system, with a frame every FrameDuration.
+var System MonotonicFrameClock = systemClock{}
+
+var _ MonotonicFrameClock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (c systemClock) WithFrameNanos(ctx context.Context, onFrame func(frameTimeNanos int64)) error {
+	if err := c.Delay(ctx, FrameDuration); err != nil {
+		return err
+	}
+	onFrame(time.Now().UnixNano())
+	return nil
+}
+
+func (systemClock) Delay(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (systemClock) RequestFrame() {}
@@ -6,6 +6,7 @@ import (
 	"github.com/zodimo/go-compose/compose"
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/material3"
 	"github.com/zodimo/go-compose/compose/material3/surface"
 	"github.com/zodimo/go-compose/compose/ui/graphics"
@@ -59,7 +60,7 @@ func ModalBottomSheet(
 			anim = opts.SheetState.visibleAnim
 		} else {
 			// Internal state sync with IsOpen
-			state := c.State(c.GenerateID().String()+"/anim", func() any {
+			state := c.State("anim", func() any {
 				return &animation.VisibilityAnimation{
 					Duration: time.Millisecond * 300,
 					State:    animation.Invisible,
@@ -72,14 +73,14 @@ func ModalBottomSheet(
 		// OR if IsOpen is the driver.
 		// Simplest: Check IsOpen.
 		if opts.IsOpen {
-			anim.Appear(time.Now())
+			anim.Appear(frameclock.LocalFrameClock.Current(c).Now())
 		} else {
 			// Only hide if not managed by SheetState manually?
 			// If opts.SheetState is set, the caller calls Show/Hide.
 			// But IsOpen is also an option.
 			// Let's assume IsOpen drives it if it changes.
 			// But since we are declarative, IsOpen IS the truth.
-			anim.Disappear(time.Now())
+			anim.Disappear(frameclock.LocalFrameClock.Current(c).Now())
 		}
 
 		baseScrim := graphics.SetOpacity(opts.ScrimColor, float32(token.OpacityLevel8))
@@ -19,12 +19,14 @@ func NewSheetState() *SheetState {
 	}
 }
 
+// Show opens the sheet, from the next frame.
 func (s *SheetState) Show() {
-	s.visibleAnim.Appear(time.Now())
+	s.visibleAnim.Appear(time.Time{})
 }
 
+// Hide closes the sheet, from the next frame.
 func (s *SheetState) Hide() {
-	s.visibleAnim.Disappear(time.Now())
+	s.visibleAnim.Disappear(time.Time{})
 }
 
 func (s *SheetState) IsVisible() bool {
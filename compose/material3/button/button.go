@@ -1,10 +1,14 @@
 package button
 
 import (
-	"fmt"
+	"image"
 
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
+	"gioui.org/io/semantic"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+
 	"git.sr.ht/~schnwalter/gio-mw/widget/button"
 )
 
@@ -41,11 +45,8 @@ func buttonComposable(material3Button *button.Button, onClick func(), label stri
 		}
 
 		if opts.Button == nil {
-			key := c.GenerateID()
-			path := c.GetPath()
 
-			buttonStatePath := fmt.Sprintf("%d/%s/button", key, path)
-			buttonValue := c.State(buttonStatePath, func() any { return material3Button })
+			buttonValue := c.State("button", func() any { return material3Button })
 			opts.Button = buttonValue.Get().(*button.Button)
 		}
 
@@ -75,7 +76,7 @@ type ButtonConstructorArgs struct {
 	LabelContent string
 }
 
-func buttonWidgetConstructor(_ ButtonOptions, constructorArgs ButtonConstructorArgs) layoutnode.LayoutNodeWidgetConstructor {
+func buttonWidgetConstructor(opts ButtonOptions, constructorArgs ButtonConstructorArgs) layoutnode.LayoutNodeWidgetConstructor {
 	return layoutnode.NewLayoutNodeWidgetConstructor(func(node layoutnode.LayoutNode) layoutnode.GioLayoutWidget {
 		return func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
 
@@ -85,7 +86,16 @@ func buttonWidgetConstructor(_ ButtonOptions, constructorArgs ButtonConstructorA
 				onClick()
 			}
 
-			return button.Layout(gtx, constructorArgs.LabelContent)
+			// The button is a semantics node of its own, which is disabled
+			// with the button; its label is the label of the node.
+			macro := op.Record(gtx.Ops)
+			dims := button.Layout(gtx, constructorArgs.LabelContent)
+			call := macro.Stop()
+			defer clip.Rect(image.Rectangle{Max: dims.Size}).Push(gtx.Ops).Pop()
+			semantic.Button.Add(gtx.Ops)
+			semantic.EnabledOp(opts.Enabled).Add(gtx.Ops)
+			call.Add(gtx.Ops)
+			return dims
 
 		}
 	})
@@ -1,8 +1,6 @@
 package checkbox
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
 	"git.sr.ht/~schnwalter/gio-mw/widget/checkbox"
@@ -26,11 +24,8 @@ func Checkbox(
 			option(&opts)
 		}
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Fix closure capture for dynamic handlers
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onCheckedChange}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
@@ -38,8 +33,7 @@ func Checkbox(
 
 		// Persist the generic Checkboxes widget state
 		// We use a specific key "checkbox" to manage single boolean state via generic string logic
-		checkboxStatePath := fmt.Sprintf("%d/%s/checkbox", key, path)
-		checkboxesValue := c.State(checkboxStatePath, func() any {
+		checkboxesValue := c.State("checkbox", func() any {
 			// Initial state based on checked param?
 			// gio-mw Checkboxes manages its own internal values list.
 			// But we want to drive it via props (controlled component).
@@ -1,8 +1,6 @@
 package chip
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/row"
 	"github.com/zodimo/go-compose/compose/material3/surface"
@@ -60,10 +58,7 @@ func Chip(onClick func(), label string, options ...ChipOption) api.Composable {
 		}
 
 		// State for clickable
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickStatePath := fmt.Sprintf("%d/%s/chip_click", key, path)
-		clickState := c.State(clickStatePath, func() any { return &widget.Clickable{} })
+		clickState := c.State("chip_click", func() any { return &widget.Clickable{} })
 		gioClickable := clickState.Get().(*widget.Clickable)
 
 		// Layout:
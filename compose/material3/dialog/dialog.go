@@ -1,8 +1,6 @@
 package dialog
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
 	"git.sr.ht/~schnwalter/gio-mw/wdk"
@@ -29,11 +27,8 @@ func AlertDialog(
 		}
 
 		// Persist the buttons so they maintain state (clicks/animations) across frames.
-		key := c.GenerateID()
-		path := c.GetPath()
 
-		buttonsStatePath := fmt.Sprintf("%d/%s/dialog_buttons", key, path)
-		buttonsValue := c.State(buttonsStatePath, func() any {
+		buttonsValue := c.State("dialog_buttons", func() any {
 			return &DialogButtonsState{
 				ConfirmButton: button.Text(),
 				CancelButton:  button.Text(),
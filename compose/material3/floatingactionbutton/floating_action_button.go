@@ -1,8 +1,6 @@
 package floatingactionbutton
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/material3/surface"
 	"github.com/zodimo/go-compose/internal/modifier"
@@ -33,10 +31,7 @@ func FloatingActionButton(
 		}
 
 		// Managing state for interaction (Pressed/Hovered)
-		key := c.GenerateID()
-		path := c.GetPath()
-		statePath := fmt.Sprintf("%d/%s/fab_clickable", key, path)
-		clickableState := c.State(statePath, func() any { return &widget.Clickable{} })
+		clickableState := c.State("fab_clickable", func() any { return &widget.Clickable{} })
 		fabClickable := clickableState.Get().(*widget.Clickable)
 
 		// Determine Elevation based on state
@@ -17,25 +17,28 @@ const Material3IconButtonNodeID = "Material3IconButton"
 
 // Standard represents a standard text-based icon button (no background).
 func Standard(onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
-	return iconButtonComposable(button.Text(), onClick, icon, description, options...)
+	return iconButtonComposable(button.Text(), true, onClick, icon, description, options...)
 }
 
 // Filled represents a filled icon button (high emphasis).
 func Filled(onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
-	return iconButtonComposable(button.Filled(), onClick, icon, description, options...)
+	return iconButtonComposable(button.Filled(), false, onClick, icon, description, options...)
 }
 
 // FilledTonal represents a filled tonal icon button (medium emphasis).
 func FilledTonal(onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
-	return iconButtonComposable(button.FilledTonal(), onClick, icon, description, options...)
+	return iconButtonComposable(button.FilledTonal(), false, onClick, icon, description, options...)
 }
 
 // Outlined represents an outlined icon button.
 func Outlined(onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
-	return iconButtonComposable(button.Outlined(), onClick, icon, description, options...)
+	return iconButtonComposable(button.Outlined(), false, onClick, icon, description, options...)
 }
 
-func iconButtonComposable(material3Button *button.Button, onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
+// iconButtonComposable lays out material3Button, or the Button option when set.
+// The icon of a standard button takes the content color; gio-mw only accepts
+// a color scheme for Text buttons, so it is not applied to the other variants.
+func iconButtonComposable(material3Button *button.Button, standard bool, onClick func(), icon []byte, description string, options ...IconButtonOption) Composable {
 	return func(c Composer) Composer {
 		opts := DefaultIconButtonOptions()
 		for _, option := range options {
@@ -48,18 +51,17 @@ func iconButtonComposable(material3Button *button.Button, onClick func(), icon [
 		contentColor := material3.LocalContentColor.Current(c)
 
 		if opts.Button == nil {
-			key := c.GenerateID()
-			path := c.GetPath()
 
-			buttonStatePath := fmt.Sprintf("%d/%s/iconbutton", key, path)
-			buttonValue := c.State(buttonStatePath, func() any { return material3Button })
+			buttonValue := c.State("iconbutton", func() any { return material3Button })
 			opts.Button = buttonValue.Get().(*button.Button)
 		}
 
-		opts.Button.WithColorScheme(&button.AlternativeColorScheme{
-			EnabledLabelColor: token.MatColor(graphics.ColorToNRGBA(contentColor)),
-			EnabledIconColor:  token.MatColor(graphics.ColorToNRGBA(contentColor)),
-		})
+		if standard {
+			opts.Button.WithColorScheme(&button.AlternativeColorScheme{
+				EnabledLabelColor: token.MatColor(graphics.ColorToNRGBA(contentColor)),
+				EnabledIconColor:  token.MatColor(graphics.ColorToNRGBA(contentColor)),
+			})
+		}
 
 		constructorArgs := IconButtonConstructorArgs{
 			Button:      opts.Button,
@@ -3,6 +3,8 @@ package material3
 import (
 	"math"
 	"time"
+
+	"github.com/zodimo/go-compose/compose/animation"
 )
 
 // The default duration used in [VectorizedAnimationSpec]s and [AnimationSpec].
@@ -11,93 +13,16 @@ var DefaultDurationMillis time.Duration = 300 * time.Millisecond
 // The value that is used when the animation time is not yet set.
 var UnspecifiedTime time.Duration = math.MinInt64
 
-// Easing is a way to adjust an animation's fraction. Easing allows transitioning
-// elements to speed up and slow down, rather than moving at a constant rate.
-//
-// Fraction is a value between 0 and 1.0 indicating the current point in the
-// animation where 0 represents the start and 1.0 represents the end.
-type Easing interface {
-	Transform(fraction float32) float32
-}
+// Easing is a way to adjust an animation's fraction. See animation.Easing.
+type Easing = animation.Easing
 
-// CubicBezierEasing is a cubic polynomial easing implementing third-order Bézier curves.
-//
-// This is equivalent to Android's PathInterpolator when a single cubic Bézier curve
-// is specified.
-//
-// Parameters:
-//   - A: The x coordinate of the first control point
-//   - B: The y coordinate of the first control point
-//   - C: The x coordinate of the second control point
-//   - D: The y coordinate of the second control point
-type CubicBezierEasing struct {
-	A, B, C, D float32
-}
+// CubicBezierEasing is a cubic polynomial easing implementing third-order
+// Bézier curves. See animation.CubicBezierEasing.
+type CubicBezierEasing = animation.CubicBezierEasing
 
 // NewCubicBezierEasing creates a new CubicBezierEasing with the given control points.
 func NewCubicBezierEasing(a, b, c, d float32) *CubicBezierEasing {
-	return &CubicBezierEasing{A: a, B: b, C: c, D: d}
-}
-
-// Transform transforms the specified fraction (0..1) by this cubic Bézier curve.
-func (e *CubicBezierEasing) Transform(fraction float32) float32 {
-	if fraction <= 0 {
-		return 0
-	}
-	if fraction >= 1 {
-		return 1
-	}
-
-	// Find t for the given x (fraction) using Newton-Raphson method
-	t := fraction
-	for i := 0; i < 8; i++ {
-		x := e.evaluateX(t) - fraction
-		if abs32(x) < 1e-6 {
-			break
-		}
-		dx := e.evaluateDX(t)
-		if abs32(dx) < 1e-6 {
-			break
-		}
-		t -= x / dx
-	}
-
-	// Clamp t to [0, 1]
-	if t < 0 {
-		t = 0
-	} else if t > 1 {
-		t = 1
-	}
-
-	return e.evaluateY(t)
-}
-
-// evaluateX evaluates the x coordinate of the Bézier curve at parameter t
-func (e *CubicBezierEasing) evaluateX(t float32) float32 {
-	// B(t) = (1-t)³*0 + 3*(1-t)²*t*a + 3*(1-t)*t²*c + t³*1
-	oneMinusT := 1 - t
-	return 3*oneMinusT*oneMinusT*t*e.A + 3*oneMinusT*t*t*e.C + t*t*t
-}
-
-// evaluateDX evaluates the derivative of x with respect to t
-func (e *CubicBezierEasing) evaluateDX(t float32) float32 {
-	// dB(t)/dt = 3*(1-t)²*a + 6*(1-t)*t*(c-a) + 3*t²*(1-c)
-	oneMinusT := 1 - t
-	return 3*oneMinusT*oneMinusT*e.A + 6*oneMinusT*t*(e.C-e.A) + 3*t*t*(1-e.C)
-}
-
-// evaluateY evaluates the y coordinate of the Bézier curve at parameter t
-func (e *CubicBezierEasing) evaluateY(t float32) float32 {
-	// B(t) = (1-t)³*0 + 3*(1-t)²*t*b + 3*(1-t)*t²*d + t³*1
-	oneMinusT := 1 - t
-	return 3*oneMinusT*oneMinusT*t*e.B + 3*oneMinusT*t*t*e.D + t*t*t
-}
-
-func abs32(x float32) float32 {
-	if x < 0 {
-		return -x
-	}
-	return x
+	return animation.NewCubicBezierEasing(a, b, c, d)
 }
 
 // ---- Material 3 Motion Easing Tokens ----
@@ -136,18 +61,16 @@ var EasingStandardDecelerate = NewCubicBezierEasing(0.0, 0.0, 0.0, 1.0)
 // ---- Common Easing Aliases (matching animation-core conventions) ----
 
 // FastOutSlowInEasing is equivalent to EasingLegacy.
-var FastOutSlowInEasing = EasingLegacy
+var FastOutSlowInEasing = animation.FastOutSlowInEasing
 
 // LinearOutSlowInEasing is equivalent to EasingLegacyDecelerate.
-var LinearOutSlowInEasing = EasingLegacyDecelerate
+var LinearOutSlowInEasing = animation.LinearOutSlowInEasing
 
 // FastOutLinearInEasing is equivalent to EasingLegacyAccelerate.
-var FastOutLinearInEasing = EasingLegacyAccelerate
+var FastOutLinearInEasing = animation.FastOutLinearInEasing
 
 // LinearEasing returns the fraction unmodified.
-type linearEasing struct{}
-
-func (linearEasing) Transform(fraction float32) float32 { return fraction }
+var LinearEasing = animation.LinearEasing
 
 var MotionTokensUnspecified = &MotionTokens{
 	DurationShort1:     UnspecifiedTime,
@@ -209,7 +132,94 @@ var DefaultMotionTokens = &MotionTokens{
 	DurationExtraLong4: 1000 * time.Millisecond,
 }
 
-type MotionScheme struct{}
+// MotionScheme is the set of springs that components animate with. Spatial
+// specs move and resize things, and may overshoot; effects specs animate colors
+// and opacity, and never do.
+type MotionScheme struct {
+	defaultSpatial springToken
+	fastSpatial    springToken
+	slowSpatial    springToken
+	defaultEffects springToken
+	fastEffects    springToken
+	slowEffects    springToken
+}
+
+type springToken struct {
+	dampingRatio float32
+	stiffness    float32
+}
+
+func (t springToken) spec() animation.AnimationSpec {
+	return animation.Spring(
+		animation.WithDampingRatio(t.dampingRatio),
+		animation.WithStiffness(t.stiffness),
+	)
+}
+
+// DefaultSpatialSpec animates the position and size of most components.
+func (m *MotionScheme) DefaultSpatialSpec() animation.AnimationSpec {
+	return m.defaultSpatial.spec()
+}
+
+// FastSpatialSpec animates the position and size of small components, such as
+// switches and buttons.
+func (m *MotionScheme) FastSpatialSpec() animation.AnimationSpec {
+	return m.fastSpatial.spec()
+}
+
+// SlowSpatialSpec animates the position and size of large components, such as
+// sheets and drawers.
+func (m *MotionScheme) SlowSpatialSpec() animation.AnimationSpec {
+	return m.slowSpatial.spec()
+}
+
+// DefaultEffectsSpec animates the color and opacity of most components.
+func (m *MotionScheme) DefaultEffectsSpec() animation.AnimationSpec {
+	return m.defaultEffects.spec()
+}
+
+// FastEffectsSpec animates the color and opacity of small components.
+func (m *MotionScheme) FastEffectsSpec() animation.AnimationSpec {
+	return m.fastEffects.spec()
+}
+
+// SlowEffectsSpec animates the color and opacity of large components.
+func (m *MotionScheme) SlowEffectsSpec() animation.AnimationSpec {
+	return m.slowEffects.spec()
+}
+
+// The effects springs of both motion schemes, which never overshoot.
+var (
+	defaultEffectsToken = springToken{dampingRatio: 1, stiffness: 1600}
+	fastEffectsToken    = springToken{dampingRatio: 1, stiffness: 3800}
+	slowEffectsToken    = springToken{dampingRatio: 1, stiffness: 800}
+)
+
+// StandardMotionScheme returns the motion scheme of utilitarian interfaces,
+// whose springs barely overshoot.
+func StandardMotionScheme() *MotionScheme {
+	return &MotionScheme{
+		defaultSpatial: springToken{dampingRatio: 0.9, stiffness: 700},
+		fastSpatial:    springToken{dampingRatio: 0.9, stiffness: 1400},
+		slowSpatial:    springToken{dampingRatio: 0.9, stiffness: 300},
+		defaultEffects: defaultEffectsToken,
+		fastEffects:    fastEffectsToken,
+		slowEffects:    slowEffectsToken,
+	}
+}
+
+// ExpressiveMotionScheme returns the motion scheme of expressive interfaces,
+// whose spatial springs bounce.
+func ExpressiveMotionScheme() *MotionScheme {
+	return &MotionScheme{
+		defaultSpatial: springToken{dampingRatio: 0.8, stiffness: 380},
+		fastSpatial:    springToken{dampingRatio: 0.6, stiffness: 800},
+		slowSpatial:    springToken{dampingRatio: 0.8, stiffness: 200},
+		defaultEffects: defaultEffectsToken,
+		fastEffects:    fastEffectsToken,
+		slowEffects:    slowEffectsToken,
+	}
+}
 
-// DefaultMotionScheme is the default motion scheme with all standard easing curves.
-var DefaultMotionScheme = &MotionScheme{}
+// DefaultMotionScheme is the motion scheme of MaterialTheme, the standard one.
+var DefaultMotionScheme = StandardMotionScheme()
@@ -1,8 +1,6 @@
 package navigationbar
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
 	"github.com/zodimo/go-compose/compose/foundation/layout/spacer"
@@ -47,10 +45,7 @@ func NavigationBarItem(
 		}
 
 		// State for click interaction
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickStatePath := fmt.Sprintf("%d/%s/navitem_click", key, path)
-		clickValue := c.State(clickStatePath, func() any { return &widget.Clickable{} })
+		clickValue := c.State("navitem_click", func() any { return &widget.Clickable{} })
 		clickWidget := clickValue.Get().(*widget.Clickable)
 
 		// Defaults
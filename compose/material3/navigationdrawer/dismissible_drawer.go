@@ -5,6 +5,7 @@ import (
 
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/row"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/material3"
 	"github.com/zodimo/go-compose/compose/material3/surface"
 	"github.com/zodimo/go-compose/compose/ui/graphics/shape"
@@ -34,7 +35,7 @@ func DismissibleNavigationDrawer(
 
 		// Animation state
 		// We use a persistent pointer for the animation state
-		anim := c.State(c.GenerateID().String()+"/anim", func() any {
+		anim := c.State("anim", func() any {
 			return &animation.VisibilityAnimation{
 				Duration: time.Millisecond * 250,
 				State:    animation.Invisible,
@@ -43,9 +44,9 @@ func DismissibleNavigationDrawer(
 
 		// Sync animation state with props
 		if opts.IsOpen {
-			anim.Appear(time.Now())
+			anim.Appear(frameclock.LocalFrameClock.Current(c).Now())
 		} else {
-			anim.Disappear(time.Now())
+			anim.Disappear(frameclock.LocalFrameClock.Current(c).Now())
 		}
 
 		return row.Row(
@@ -5,6 +5,7 @@ import (
 
 	"git.sr.ht/~schnwalter/gio-mw/token"
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/material3"
 	"github.com/zodimo/go-compose/compose/material3/surface"
 	"github.com/zodimo/go-compose/compose/ui/graphics"
@@ -41,7 +42,7 @@ func ModalNavigationDrawer(
 		drawerContainerColor := theme.ColorScheme().SurfaceContainerLow //theme.ColorHelper.ColorSelector().SurfaceRoles.ContainerLow
 
 		// Animation state
-		anim := c.State(c.GenerateID().String()+"/anim", func() any {
+		anim := c.State("anim", func() any {
 			return &animation.VisibilityAnimation{
 				Duration: time.Millisecond * 300,
 				State:    animation.Invisible,
@@ -50,9 +51,9 @@ func ModalNavigationDrawer(
 
 		// Sync animation state
 		if opts.IsOpen {
-			anim.Appear(time.Now())
+			anim.Appear(frameclock.LocalFrameClock.Current(c).Now())
 		} else {
-			anim.Disappear(time.Now())
+			anim.Disappear(frameclock.LocalFrameClock.Current(c).Now())
 		}
 
 		baseScrim := graphics.SetOpacity(theme.ColorScheme().Scrim, float32(token.OpacityLevel8))
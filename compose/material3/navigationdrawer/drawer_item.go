@@ -40,10 +40,7 @@ func NavigationDrawerItem(
 		}
 
 		// Click interaction state
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickStatePath := key.String() + "/" + path.String() + "/draweritem_click"
-		clickWidget := c.State(clickStatePath, func() any { return &widget.Clickable{} }).Get().(*widget.Clickable)
+		clickWidget := c.State("draweritem_click", func() any { return &widget.Clickable{} }).Get().(*widget.Clickable)
 
 		return surface.Surface(
 			func(c Composer) Composer {
@@ -1,8 +1,6 @@
 package navigationrail
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/column"
 	"github.com/zodimo/go-compose/compose/material3"
@@ -39,10 +37,7 @@ func NavigationRailItem(
 	return func(c Composer) Composer {
 		theme := material3.Theme(c)
 		// State for click interaction
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickStatePath := fmt.Sprintf("%d/%s/railitem_click", key, path)
-		clickValue := c.State(clickStatePath, func() any { return &widget.Clickable{} })
+		clickValue := c.State("railitem_click", func() any { return &widget.Clickable{} })
 		clickWidget := clickValue.Get().(*widget.Clickable)
 
 		// Define indicator styling (pill shape)
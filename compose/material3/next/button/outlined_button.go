@@ -1,8 +1,6 @@
 package button
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation"
 	foundationLayout "github.com/zodimo/go-compose/compose/foundation/layout"
 	"github.com/zodimo/go-compose/compose/foundation/layout/row"
@@ -81,10 +79,7 @@ func OutlinedButton(onClick func(), content Composable, options ...ButtonOption)
 
 		// State for interaction
 		// We need a persistent clickable state to track pressed/hovered state
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickableStatePath := fmt.Sprintf("%d/%s/buttonClickable", key, path)
-		clickableState := c.State(clickableStatePath, func() any { return &clickable.GioClickable{} })
+		clickableState := c.State("buttonClickable", func() any { return &clickable.GioClickable{} })
 		clickState := clickableState.Get().(*clickable.GioClickable)
 
 		// Determine colors and shape based on state
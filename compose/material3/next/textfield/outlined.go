@@ -37,11 +37,8 @@ func Outlined(
 		layoutDirection := platform.LocalLayoutDirection.Current(c)
 		textStyle = text.TextStyleResolveDefaults(textStyle, layoutDirection)
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Handler wrapper
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onValueChange}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
@@ -50,7 +47,7 @@ func Outlined(
 		// OnSubmit wrapper
 		var onSubmitWrapper *OnSubmitWrapper
 		if opts.OnSubmit != nil {
-			onSubmitWrapperState := c.State(fmt.Sprintf("%d/%s/onsubmit_wrapper", key, path), func() any {
+			onSubmitWrapperState := c.State("onsubmit_wrapper", func() any {
 				return &OnSubmitWrapper{Func: opts.OnSubmit}
 			})
 			onSubmitWrapper = onSubmitWrapperState.Get().(*OnSubmitWrapper)
@@ -58,8 +55,7 @@ func Outlined(
 		}
 
 		// Custom Outlined Widget State
-		widgetStatePath := fmt.Sprintf("%d/%s/outlined_widget/s%v", key, path, opts.SingleLine)
-		editorVal := c.State(widgetStatePath, func() any {
+		editorVal := c.State(fmt.Sprintf("outlined_widget/s%v", opts.SingleLine), func() any {
 			// return &TextFieldWidget{
 			// 	Editor: &widget.Editor{
 			// 		SingleLine: opts.SingleLine,
@@ -74,7 +70,7 @@ func Outlined(
 		outEditor := editorVal.Get().(*widget.Editor)
 
 		// State tracker for synchronization
-		trackerState := c.State(fmt.Sprintf("%d/%s/tracker/s%v", key, path, opts.SingleLine), func() any {
+		trackerState := c.State(fmt.Sprintf("tracker/s%v", opts.SingleLine), func() any {
 			return &TextFieldStateTracker{LastValue: ""}
 		})
 		tracker := trackerState.Get().(*TextFieldStateTracker)
@@ -16,6 +16,7 @@ import (
 	"gioui.org/op/clip"
 	"gioui.org/widget"
 	"gioui.org/widget/material"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/pkg/floatutils/lerp"
 
 	gioUnit "gioui.org/unit"
@@ -122,22 +123,23 @@ func (in *TextFieldComponent) Update(gtx C, th *material.Theme, hint string) {
 	const (
 		duration = time.Millisecond * 100
 	)
+	clock := frameclock.Current(gtx)
 	if in.anim == nil {
 		in.anim = &Progress{}
 	}
 	if in.state == activated || hasContents {
-		in.anim.Start(gtx.Now, Forward, 0)
+		in.anim.Start(clock.Now(), Forward, 0)
 	}
 	if in.state == focused && !hasContents && !in.anim.Started() {
-		in.anim.Start(gtx.Now, Forward, duration)
+		in.anim.Start(clock.Now(), Forward, duration)
 	}
 	if in.state == inactive && !hasContents && in.anim.Finished() {
-		in.anim.Start(gtx.Now, Reverse, duration)
+		in.anim.Start(clock.Now(), Reverse, duration)
 	}
 	if in.anim.Started() {
-		gtx.Execute(op.InvalidateCmd{})
+		clock.RequestFrame()
 	}
-	in.anim.Update(gtx.Now)
+	in.anim.Update(clock.Now())
 	var (
 		// Text size transitions.
 		textNormal = th.TextSize
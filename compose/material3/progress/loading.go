@@ -1,7 +1,6 @@
 package progress
 
 import (
-	"fmt"
 	"image"
 	"math"
 	"time"
@@ -14,6 +13,7 @@ import (
 
 	"git.sr.ht/~schnwalter/gio-mw/wdk"
 	"git.sr.ht/~schnwalter/gio-mw/widget/indicator"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
 	gioUnit "gioui.org/unit"
@@ -30,12 +30,8 @@ func LoadingIndicator(options ...IndicatorOption) Composable {
 			option(&opts)
 		}
 
-		// key := c.GenerateID() // unused
-		path := c.GetPath()
-		statePath := fmt.Sprintf("%v/loading_anim_state", path)
-
 		// State for animation
-		animState := c.State(statePath, func() any {
+		animState := c.State("loading_anim_state", func() any {
 			return &loadingState{}
 		}).Get().(*loadingState)
 
@@ -60,13 +56,15 @@ type loadingState struct {
 
 func drawLoadingIndicator(gtx layout.Context, state *loadingState, opts IndicatorOptions) layout.Dimensions {
 	// Ensure continuous animation
-	gtx.Execute(op.InvalidateCmd{})
+	clock := frameclock.Current(gtx)
+	clock.RequestFrame()
 
+	now := clock.Now()
 	if state.startTime.IsZero() {
-		state.startTime = gtx.Now
+		state.startTime = now
 	}
 
-	elapsed := gtx.Now.Sub(state.startTime)
+	elapsed := now.Sub(state.startTime)
 
 	// Default size matching gio-mw
 	diameter := gioUnit.Dp(48)
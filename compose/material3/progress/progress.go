@@ -1,8 +1,6 @@
 package progress
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
 	"git.sr.ht/~schnwalter/gio-mw/widget/indicator"
@@ -34,12 +32,9 @@ func indicatorComposable(defaultIndicator *indicator.Indicator, progress float32
 		}
 
 		if opts.Indicator == nil {
-			key := c.GenerateID()
-			path := c.GetPath()
 
 			// We persist the indicator to maintain animation state (lastProgress, startTime)
-			statePath := fmt.Sprintf("%d/%s/indicator", key, path)
-			opts.Indicator = c.State(statePath, func() any { return defaultIndicator }).Get().(*indicator.Indicator)
+			opts.Indicator = c.State("indicator", func() any { return defaultIndicator }).Get().(*indicator.Indicator)
 		}
 
 		// Update progress on every recomposition
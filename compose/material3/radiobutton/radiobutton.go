@@ -1,7 +1,6 @@
 package radiobutton
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 
@@ -36,18 +35,15 @@ func RadioButton(
 			option(&opts)
 		}
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Fix closure capture for handler
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onClick}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
 		handlerWrapper.Func = onClick
 
 		// State for Clickable
-		clickableState := c.State(fmt.Sprintf("%d/%s/clickable", key, path), func() any {
+		clickableState := c.State("clickable", func() any {
 			return &widget.Clickable{}
 		})
 		clickable := clickableState.Get().(*widget.Clickable)
@@ -1,8 +1,6 @@
 package segmentedbutton
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/compose/foundation/layout/box"
 	"github.com/zodimo/go-compose/compose/foundation/layout/row"
 	"github.com/zodimo/go-compose/compose/material3"
@@ -104,10 +102,7 @@ func SegmentedButton(
 		opts.BorderColor = opts.BorderColor.TakeOrElse(theme.ColorScheme().Outline)                                      //, theme.ColorHelper.ColorSelector().OutlineRoles.Outline)
 
 		// State for clickable
-		key := c.GenerateID()
-		path := c.GetPath()
-		clickStatePath := fmt.Sprintf("%d/%s/segment_click", key, path)
-		clickState := c.State(clickStatePath, func() any { return &widget.Clickable{} })
+		clickState := c.State("segment_click", func() any { return &widget.Clickable{} })
 		gioClickable := clickState.Get().(*widget.Clickable)
 
 		// Determine colors based on checked state
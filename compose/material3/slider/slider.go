@@ -1,7 +1,6 @@
 package slider
 
 import (
-	"fmt"
 	"image"
 
 	"github.com/zodimo/go-compose/compose/material3"
@@ -42,12 +41,8 @@ func Slider(value float32, onValueChange func(float32), options ...SliderOption)
 			internalValue = (value - opts.ValueRange.Min) / rangeDiff
 		}
 
-		key := c.GenerateID()
-		path := c.GetPath()
-		floatStatePath := fmt.Sprintf("%d/%s/widget_float", key, path)
-
 		// Create or retrieving the widget.Float state
-		floatState := c.State(floatStatePath, func() any {
+		floatState := c.State("widget_float", func() any {
 			return &widget.Float{Value: internalValue}
 		})
 		wFloat := floatState.Get().(*widget.Float)
@@ -1,8 +1,6 @@
 package mswitch
 
 import (
-	"fmt"
-
 	"github.com/zodimo/go-compose/internal/layoutnode"
 
 	"git.sr.ht/~schnwalter/gio-mw/widget/toggle"
@@ -26,18 +24,14 @@ func Switch(
 			option(&opts)
 		}
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Fix closure capture for dynamic handlers
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onCheckedChange}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
 		handlerWrapper.Func = onCheckedChange
 
-		switchStatePath := fmt.Sprintf("%d/%s/switch", key, path)
-		switchValue := c.State(switchStatePath, func() any {
+		switchValue := c.State("switch", func() any {
 			var values []string
 			if checked {
 				values = []string{singleSwitchKey}
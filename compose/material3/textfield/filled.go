@@ -6,6 +6,7 @@ import (
 	"image/color"
 	"time"
 
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/ui/graphics"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/pkg/sentinel"
@@ -45,11 +46,8 @@ func Filled(
 		opts.Colors = ResolveTextFieldColors(c, opts.Colors)
 		opts.SupportingText = sentinel.TakeOrElseString(opts.SupportingText, "")
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Handler wrappers
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onValueChange}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
@@ -57,7 +55,7 @@ func Filled(
 
 		var onSubmitWrapper *OnSubmitWrapper
 		if opts.OnSubmit != nil {
-			onSubmitWrapperState := c.State(fmt.Sprintf("%d/%s/onsubmit_wrapper", key, path), func() any {
+			onSubmitWrapperState := c.State("onsubmit_wrapper", func() any {
 				return &OnSubmitWrapper{Func: opts.OnSubmit}
 			})
 			onSubmitWrapper = onSubmitWrapperState.Get().(*OnSubmitWrapper)
@@ -65,8 +63,7 @@ func Filled(
 		}
 
 		// Widget state
-		widgetStatePath := fmt.Sprintf("%d/%s/filled_widget/s%v", key, path, opts.SingleLine)
-		widgetVal := c.State(widgetStatePath, func() any {
+		widgetVal := c.State(fmt.Sprintf("filled_widget/s%v", opts.SingleLine), func() any {
 			return &FilledTextFieldWidget{
 				Editor: widget.Editor{
 					SingleLine: opts.SingleLine,
@@ -78,7 +75,7 @@ func Filled(
 		w := widgetVal.Get().(*FilledTextFieldWidget)
 
 		// Tracker
-		trackerState := c.State(fmt.Sprintf("%d/%s/tracker/s%v", key, path, opts.SingleLine), func() any {
+		trackerState := c.State(fmt.Sprintf("tracker/s%v", opts.SingleLine), func() any {
 			return &TextFieldStateTracker{LastValue: ""}
 		})
 		tracker := trackerState.Get().(*TextFieldStateTracker)
@@ -358,19 +355,20 @@ func (in *FilledTextFieldWidget) update(gtx layout.Context, th *gioMaterial.Them
 		in.state = focused
 	}
 
+	clock := frameclock.Current(gtx)
 	if in.anim == nil {
 		in.anim = &Progress{}
 	}
 	// Animation logic
 	if in.state == activated || in.Editor.Len() > 0 || (in.state == focused && in.Editor.Len() == 0) {
-		in.anim.Start(gtx.Now, Forward, time.Millisecond*100)
+		in.anim.Start(clock.Now(), Forward, time.Millisecond*100)
 	} else if in.state == inactive && in.Editor.Len() == 0 {
-		in.anim.Start(gtx.Now, Reverse, time.Millisecond*100)
+		in.anim.Start(clock.Now(), Reverse, time.Millisecond*100)
 	}
 	if in.anim.Started() {
-		gtx.Execute(op.InvalidateCmd{})
+		clock.RequestFrame()
 	}
-	in.anim.Update(gtx.Now)
+	in.anim.Update(clock.Now())
 
 	in.border.Color = graphics.ColorToNRGBA(in.Colors.UnfocusedIndicatorColor)
 	in.helper.Color = graphics.ColorToNRGBA(in.Colors.SupportingTextColor)
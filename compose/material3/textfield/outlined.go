@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/compose/ui/graphics"
 	"github.com/zodimo/go-compose/internal/layoutnode"
 	"github.com/zodimo/go-compose/pkg/sentinel"
@@ -47,11 +48,8 @@ func Outlined(
 		opts.Colors = ResolveTextFieldColors(c, opts.Colors)
 		opts.SupportingText = sentinel.TakeOrElseString(opts.SupportingText, "")
 
-		key := c.GenerateID()
-		path := c.GetPath()
-
 		// Handler wrapper
-		handlerWrapperState := c.State(fmt.Sprintf("%d/%s/handler_wrapper", key, path), func() any {
+		handlerWrapperState := c.State("handler_wrapper", func() any {
 			return &HandlerWrapper{Func: onValueChange}
 		})
 		handlerWrapper := handlerWrapperState.Get().(*HandlerWrapper)
@@ -60,7 +58,7 @@ func Outlined(
 		// OnSubmit wrapper
 		var onSubmitWrapper *OnSubmitWrapper
 		if opts.OnSubmit != nil {
-			onSubmitWrapperState := c.State(fmt.Sprintf("%d/%s/onsubmit_wrapper", key, path), func() any {
+			onSubmitWrapperState := c.State("onsubmit_wrapper", func() any {
 				return &OnSubmitWrapper{Func: opts.OnSubmit}
 			})
 			onSubmitWrapper = onSubmitWrapperState.Get().(*OnSubmitWrapper)
@@ -68,8 +66,7 @@ func Outlined(
 		}
 
 		// Custom Outlined Widget State
-		widgetStatePath := fmt.Sprintf("%d/%s/outlined_widget/s%v", key, path, opts.SingleLine)
-		widgetVal := c.State(widgetStatePath, func() any {
+		widgetVal := c.State(fmt.Sprintf("outlined_widget/s%v", opts.SingleLine), func() any {
 			return &OutlinedTextFieldWidget{
 				Editor: widget.Editor{
 					SingleLine: opts.SingleLine,
@@ -80,7 +77,7 @@ func Outlined(
 		outWidget := widgetVal.Get().(*OutlinedTextFieldWidget)
 
 		// State tracker for synchronization
-		trackerState := c.State(fmt.Sprintf("%d/%s/tracker/s%v", key, path, opts.SingleLine), func() any {
+		trackerState := c.State(fmt.Sprintf("tracker/s%v", opts.SingleLine), func() any {
 			return &TextFieldStateTracker{LastValue: ""}
 		})
 		tracker := trackerState.Get().(*TextFieldStateTracker)
@@ -496,22 +493,23 @@ func (in *OutlinedTextFieldWidget) update(gtx layout.Context, th *gioMaterial.Th
 	const (
 		duration = time.Millisecond * 100
 	)
+	clock := frameclock.Current(gtx)
 	if in.anim == nil {
 		in.anim = &Progress{}
 	}
 	if in.state == activated || hasContents {
-		in.anim.Start(gtx.Now, Forward, 0)
+		in.anim.Start(clock.Now(), Forward, 0)
 	}
 	if in.state == focused && !hasContents && !in.anim.Started() {
-		in.anim.Start(gtx.Now, Forward, duration)
+		in.anim.Start(clock.Now(), Forward, duration)
 	}
 	if in.state == inactive && !hasContents && in.anim.Finished() {
-		in.anim.Start(gtx.Now, Reverse, duration)
+		in.anim.Start(clock.Now(), Reverse, duration)
 	}
 	if in.anim.Started() {
-		gtx.Execute(op.InvalidateCmd{})
+		clock.RequestFrame()
 	}
-	in.anim.Update(gtx.Now)
+	in.anim.Update(clock.Now())
 
 	var (
 		textNormal = th.TextSize
@@ -1,7 +1,6 @@
 package tooltip
 
 import (
-	"fmt"
 	"image"
 
 	"github.com/zodimo/go-compose/internal/layoutnode"
@@ -32,11 +31,8 @@ func Tooltip(
 		}
 
 		// State for hover detection
-		key := c.GenerateID()
-		path := c.GetPath()
-		hoverStatePath := fmt.Sprintf("%d/%s/hover", key, path)
 		// Pointer to bool to track hover state
-		hoveredValue := c.State(hoverStatePath, func() any { v := false; return &v })
+		hoveredValue := c.State("hover", func() any { v := false; return &v })
 		hovered := hoveredValue.Get().(*bool)
 
 		c.WithComposable(content)
@@ -0,0 +1,84 @@
+package compose_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+// Leaf emits a single node and counts how often it is composed.
+func Leaf(composed *int) compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		*composed++
+		c.StartBlock("Leaf")
+		return c.EndBlock()
+	}
+}
+
+func TestRememberSurvivesRecomposition(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+
+	calculations := 0
+	frame := func() (any, any) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		first := c.Remember("value", func() any { calculations++; return new(int) })
+		second := c.Remember("value", func() any { calculations++; return new(int) })
+		c.EndBlock()
+		c.Build()
+		return first, second
+	}
+
+	first, second := frame()
+	if first == second {
+		t.Error("Expected repeated Remember calls to get their own slot")
+	}
+	againFirst, againSecond := frame()
+	if first != againFirst || second != againSecond {
+		t.Error("Expected remembered values to survive recomposition")
+	}
+	if calculations != 2 {
+		t.Errorf("Expected 2 calculations, got %d", calculations)
+	}
+}
+
+func TestSkippable(t *testing.T) {
+	mockStore := store.NewPersistentState(map[string]state.MutableValue{})
+	counter := mockStore.GetState("counter", func() any { return 0 })
+
+	unchangedComposed, readerComposed := 0, 0
+	frame := func(input int) int {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		c.Skippable("Unchanged", Leaf(&unchangedComposed), input)(c)
+		c.Skippable("Reader", func(c compose.Composer) compose.Composer {
+			counter.Get()
+			return Leaf(&readerComposed)(c)
+		})(c)
+		c.EndBlock()
+		return len(c.Build().LayoutNodeChildren())
+	}
+
+	if children := frame(1); children != 2 {
+		t.Fatalf("Expected 2 children, got %d", children)
+	}
+	if children := frame(1); children != 2 {
+		t.Errorf("Expected skipped groups to keep their nodes, got %d children", children)
+	}
+	if unchangedComposed != 1 || readerComposed != 1 {
+		t.Errorf("Expected both groups to be skipped, composed %d and %d times", unchangedComposed, readerComposed)
+	}
+
+	counter.Set(1)
+	frame(1)
+	if unchangedComposed != 1 || readerComposed != 2 {
+		t.Errorf("Expected only the reader to recompose, composed %d and %d times", unchangedComposed, readerComposed)
+	}
+
+	frame(2)
+	if unchangedComposed != 2 {
+		t.Errorf("Expected a changed input to recompose, composed %d times", unchangedComposed)
+	}
+}
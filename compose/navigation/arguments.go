@@ -0,0 +1,85 @@
+package navigation
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// NavType is the type of a navigation argument. Routes whose arguments do not
+// parse as their type do not match the destination.
+type NavType int
+
+const (
+	NavTypeString NavType = iota
+	NavTypeInt
+	NavTypeFloat
+	NavTypeBool
+)
+
+func (t NavType) valid(value string) bool {
+	var err error
+	switch t {
+	case NavTypeInt:
+		_, err = strconv.Atoi(value)
+	case NavTypeFloat:
+		_, err = strconv.ParseFloat(value, 64)
+	case NavTypeBool:
+		_, err = strconv.ParseBool(value)
+	}
+	return err == nil
+}
+
+// NavArgument declares an argument of a destination.
+type NavArgument struct {
+	Name string
+	Type NavType
+	// DefaultValue is the value of an optional argument missing from the route;
+	// nil leaves it missing.
+	DefaultValue any
+}
+
+// NavArguments holds the arguments of a back stack entry, by name, as they
+// appear in its route.
+type NavArguments map[string]string
+
+// String returns the argument name.
+func (a NavArguments) String(name string) (string, bool) {
+	value, ok := a[name]
+	return value, ok
+}
+
+// Int returns the argument name parsed as an int.
+func (a NavArguments) Int(name string) (int, bool) {
+	value, err := strconv.Atoi(a[name])
+	return value, err == nil
+}
+
+// Float returns the argument name parsed as a float64.
+func (a NavArguments) Float(name string) (float64, bool) {
+	value, err := strconv.ParseFloat(a[name], 64)
+	return value, err == nil
+}
+
+// Bool returns the argument name parsed as a bool.
+func (a NavArguments) Bool(name string) (bool, bool) {
+	value, err := strconv.ParseBool(a[name])
+	return value, err == nil
+}
+
+// resolve applies the declared arguments to the arguments matched from a
+// route: it sets the defaults of the missing ones and checks their types.
+func (a NavArguments) resolve(declared []NavArgument) bool {
+	for _, argument := range declared {
+		value, ok := a[argument.Name]
+		if !ok {
+			if argument.DefaultValue != nil {
+				a[argument.Name] = fmt.Sprint(argument.DefaultValue)
+			}
+			continue
+		}
+		if !argument.Type.valid(value) {
+			return false
+		}
+	}
+	return true
+}
@@ -3,15 +3,119 @@ package navigation
 import "github.com/zodimo/go-compose/pkg/api"
 
 type NavGraphBuilder struct {
-	destinations map[string]api.Composable
+	destinations map[string]*navDestination
+	// routes holds the routes of the destinations in the order they were added,
+	// which is the order they are matched in.
+	routes []string
+}
+
+// navDestination is a destination of a navigation graph.
+type navDestination struct {
+	route     string
+	pattern   routePattern
+	content   func(entry BackStackEntry) api.Composable
+	arguments []NavArgument
+	deepLinks []routePattern
+}
+
+// DestinationOption is a functional option for configuring a destination.
+type DestinationOption func(*DestinationOptions)
+
+type DestinationOptions struct {
+	Arguments []NavArgument
+	// DeepLinks are URI templates, such as "app://example.com/user/{id}", that
+	// navigate to the destination. Their arguments are those of the route.
+	DeepLinks []string
+}
+
+func DefaultDestinationOptions() DestinationOptions {
+	return DestinationOptions{}
+}
+
+func WithArguments(arguments ...NavArgument) DestinationOption {
+	return func(o *DestinationOptions) {
+		o.Arguments = append(o.Arguments, arguments...)
+	}
+}
+
+func WithDeepLinks(uriTemplates ...string) DestinationOption {
+	return func(o *DestinationOptions) {
+		o.DeepLinks = append(o.DeepLinks, uriTemplates...)
+	}
 }
 
 func NewNavGraphBuilder() *NavGraphBuilder {
 	return &NavGraphBuilder{
-		destinations: make(map[string]api.Composable),
+		destinations: make(map[string]*navDestination),
 	}
 }
 
-func (b *NavGraphBuilder) Composable(route string, content api.Composable) {
-	b.destinations[route] = content
+// Composable adds a destination for route, which may be a template with
+// arguments such as "user/{id}?tab={tab}".
+func (b *NavGraphBuilder) Composable(route string, content api.Composable, options ...DestinationOption) {
+	b.ComposableWithEntry(route, func(BackStackEntry) api.Composable { return content }, options...)
+}
+
+// ComposableWithEntry adds a destination for route whose content reads the
+// back stack entry it is shown for, and its arguments.
+func (b *NavGraphBuilder) ComposableWithEntry(route string, content func(entry BackStackEntry) api.Composable, options ...DestinationOption) {
+	opts := DefaultDestinationOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	destination := &navDestination{
+		route:     route,
+		pattern:   parseRoutePattern(route),
+		content:   content,
+		arguments: opts.Arguments,
+	}
+	for _, deepLink := range opts.DeepLinks {
+		destination.deepLinks = append(destination.deepLinks, parseRoutePattern(deepLink))
+	}
+	if _, ok := b.destinations[route]; !ok {
+		b.routes = append(b.routes, route)
+	}
+	b.destinations[route] = destination
+}
+
+// match returns the destination of route and its arguments.
+func (b *NavGraphBuilder) match(route string) (*navDestination, NavArguments, bool) {
+	// A route without arguments matches its destination before any template.
+	if destination, ok := b.destinations[route]; ok && destination.pattern.literal() {
+		if arguments, ok := destination.resolve(destination.pattern.match(route)); ok {
+			return destination, arguments, true
+		}
+	}
+	for _, template := range b.routes {
+		destination := b.destinations[template]
+		if arguments, ok := destination.resolve(destination.pattern.match(route)); ok {
+			return destination, arguments, true
+		}
+	}
+	return nil, nil, false
+}
+
+// matchDeepLink returns the route of the destination that uri links to.
+func (b *NavGraphBuilder) matchDeepLink(uri string) (string, bool) {
+	for _, template := range b.routes {
+		destination := b.destinations[template]
+		for _, deepLink := range destination.deepLinks {
+			if arguments, ok := destination.resolve(deepLink.match(uri)); ok {
+				return destination.pattern.build(arguments), true
+			}
+		}
+	}
+	return "", false
+}
+
+// resolve applies the arguments declared by the destination to arguments
+// matched from a route.
+func (d *navDestination) resolve(arguments NavArguments, matched bool) (NavArguments, bool) {
+	if !matched || !arguments.resolve(d.arguments) {
+		return nil, false
+	}
+	return arguments, true
 }
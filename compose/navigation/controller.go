@@ -2,6 +2,9 @@ package navigation
 
 import (
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
 	"github.com/zodimo/go-compose/pkg/api"
@@ -11,49 +14,259 @@ import (
 type BackStackEntry struct {
 	Route string
 	ID    string
+	// Destination is the route template of the destination of the entry, once
+	// the controller knows its graph.
+	Destination string       `json:",omitempty"`
+	Arguments   NavArguments `json:",omitempty"`
+}
+
+// destinationKey identifies the destination of the entry, by its template when
+// it is known.
+func (e BackStackEntry) destinationKey() string {
+	if e.Destination != "" {
+		return e.Destination
+	}
+	return e.Route
+}
+
+// NavOption is a functional option for configuring a navigation.
+type NavOption func(*NavOptions)
+
+type NavOptions struct {
+	// PopUpTo pops the entries above the last entry of the destination, given
+	// by its route template or route, before navigating.
+	PopUpTo string
+	// PopUpToInclusive also pops the entry of PopUpTo.
+	PopUpToInclusive bool
+	// PopUpToSaveState saves the entries popped by PopUpTo, for a navigation
+	// with RestoreState to their first destination to bring them back.
+	PopUpToSaveState bool
+	// LaunchSingleTop replaces the top entry rather than adding another one
+	// when it is of the same destination, keeping its ID.
+	LaunchSingleTop bool
+	// RestoreState brings back the entries saved by PopUpToSaveState for the
+	// destination, rather than adding a new entry.
+	RestoreState bool
+}
+
+func DefaultNavOptions() NavOptions {
+	return NavOptions{}
+}
+
+func WithPopUpTo(route string, inclusive bool) NavOption {
+	return func(o *NavOptions) {
+		o.PopUpTo = route
+		o.PopUpToInclusive = inclusive
+	}
+}
+
+func WithPopUpToSaveState(saveState bool) NavOption {
+	return func(o *NavOptions) {
+		o.PopUpToSaveState = saveState
+	}
+}
+
+func WithLaunchSingleTop(singleTop bool) NavOption {
+	return func(o *NavOptions) {
+		o.LaunchSingleTop = singleTop
+	}
+}
+
+func WithRestoreState(restoreState bool) NavOption {
+	return func(o *NavOptions) {
+		o.RestoreState = restoreState
+	}
 }
 
 type NavController struct {
-	backStack state.MutableValue
+	// mu guards the graph, and makes each change of the back stack and the
+	// saved stacks a single read-modify-write, as effects navigate from their
+	// goroutines while the NavHost composes.
+	mu sync.Mutex
+
+	backStack state.TypedMutableValue[[]BackStackEntry]
+	// savedStacks holds the entries saved by PopUpToSaveState, by the
+	// destination of the first of them. It is saved along with the back stack
+	// by RememberNavController.
+	savedStacks state.TypedMutableValue[map[string][]BackStackEntry]
+
+	// graph is the graph of the NavHost showing the back stack, once composed.
+	graph          *NavGraphBuilder
+	onUnknownRoute func(route string)
 }
 
 func NewNavController(backStack state.MutableValue) *NavController {
-	return &NavController{backStack: backStack}
+	return &NavController{
+		backStack:   state.Typed[[]BackStackEntry](backStack),
+		savedStacks: state.NewMutableState(map[string][]BackStackEntry{}, nil),
+	}
 }
 
+// RememberNavController returns the NavController remembered at the call site.
+// The back stack is scoped to the caller, so several NavHosts keep separate stacks,
+// and it is saveable, so it is restored after a restart, along with the entries
+// saved by PopUpToSaveState.
 func RememberNavController(c api.Composer) *NavController {
-	backStack := c.State("nav_backstack", func() any {
+	backStack := state.RememberSaveable(c, "nav_backstack", func() []BackStackEntry {
 		return []BackStackEntry{}
-	})
+	}, state.JSONSaver[[]BackStackEntry]()).Unwrap()
+	savedStacks := state.RememberSaveable(c, "nav_saved_stacks", func() map[string][]BackStackEntry {
+		return map[string][]BackStackEntry{}
+	}, state.JSONSaver[map[string][]BackStackEntry]())
 
 	nc := c.Remember("nav_controller", func() any {
-		return NewNavController(backStack)
+		nc := NewNavController(backStack)
+		nc.savedStacks = savedStacks
+		return nc
 	})
 
 	return nc.(*NavController)
 }
 
-func (nc *NavController) Navigate(route string) {
-	stack := nc.backStack.Get().([]BackStackEntry)
-    // simple ID generation using time
-    id := fmt.Sprintf("%s-%d", route, time.Now().UnixNano())
-	stack = append(stack, BackStackEntry{Route: route, ID: id})
+// setGraph sets the graph that routes are matched against, and the callback
+// of the routes that match none of its destinations.
+func (nc *NavController) setGraph(graph *NavGraphBuilder, onUnknownRoute func(route string)) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	if nc.graph != graph {
+		nc.graph, nc.onUnknownRoute = graph, onUnknownRoute
+	}
+}
+
+// routing returns the graph and the callback of the unknown routes.
+func (nc *NavController) routing() (*NavGraphBuilder, func(route string)) {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	return nc.graph, nc.onUnknownRoute
+}
+
+// Navigate adds an entry for route to the back stack. Once the controller is
+// shown by a NavHost, route is matched against the destinations of its graph,
+// and a route that matches none of them is reported to its OnUnknownRoute
+// rather than navigated to.
+func (nc *NavController) Navigate(route string, options ...NavOption) {
+	opts := DefaultNavOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+
+	// simple ID generation using time
+	entry := BackStackEntry{Route: route, ID: fmt.Sprintf("%s-%d", route, time.Now().UnixNano())}
+	if graph, onUnknownRoute := nc.routing(); graph != nil {
+		destination, arguments, ok := graph.match(route)
+		if !ok {
+			if onUnknownRoute != nil {
+				onUnknownRoute(route)
+			}
+			return
+		}
+		entry.Destination, entry.Arguments = destination.route, arguments
+	}
+
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	stack := slices.Clone(nc.backStack.Get())
+	if opts.PopUpTo != "" {
+		stack = nc.popUpTo(stack, opts.PopUpTo, opts.PopUpToInclusive, opts.PopUpToSaveState)
+	}
+	if opts.RestoreState {
+		if saved, ok := nc.savedStacks.Get()[entry.destinationKey()]; ok {
+			savedStacks := maps.Clone(nc.savedStacks.Get())
+			delete(savedStacks, entry.destinationKey())
+			nc.savedStacks.Set(savedStacks)
+			nc.backStack.Set(append(stack, saved...))
+			return
+		}
+	}
+	if top := len(stack) - 1; opts.LaunchSingleTop && top >= 0 && stack[top].destinationKey() == entry.destinationKey() {
+		entry.ID = stack[top].ID
+		stack[top] = entry
+	} else {
+		stack = append(stack, entry)
+	}
 	nc.backStack.Set(stack)
 }
 
+// NavigateDeepLink navigates to the destination with a deep link matching uri,
+// with the arguments of uri. It reports whether a destination matched.
+func (nc *NavController) NavigateDeepLink(uri string, options ...NavOption) bool {
+	graph, _ := nc.routing()
+	if graph == nil {
+		return false
+	}
+	route, ok := graph.matchDeepLink(uri)
+	if !ok {
+		return false
+	}
+	nc.Navigate(route, options...)
+	return true
+}
+
 func (nc *NavController) PopBackStack() bool {
-	stack := nc.backStack.Get().([]BackStackEntry)
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	stack := nc.backStack.Get()
 	if len(stack) <= 0 {
 		return false
 	}
-    // Remove the last item
-    stack = stack[:len(stack)-1]
-    nc.backStack.Set(stack)
-    return true
+	// Remove the last item
+	nc.backStack.Set(slices.Clone(stack[:len(stack)-1]))
+	return true
+}
+
+// PopBackStackTo pops the entries above the last entry of the destination
+// given by its route template or route, and that entry too when inclusive. It
+// reports whether the destination is in the back stack.
+func (nc *NavController) PopBackStackTo(route string, inclusive bool) bool {
+	nc.mu.Lock()
+	defer nc.mu.Unlock()
+	stack := nc.backStack.Get()
+	if nc.lastIndexOf(stack, route) < 0 {
+		return false
+	}
+	nc.backStack.Set(nc.popUpTo(slices.Clone(stack), route, inclusive, false))
+	return true
+}
+
+// popUpTo returns stack without the entries above the last entry of route,
+// saving them when saveState is set. Nothing is popped when route is not in
+// the stack. It is called with mu held.
+func (nc *NavController) popUpTo(stack []BackStackEntry, route string, inclusive, saveState bool) []BackStackEntry {
+	i := nc.lastIndexOf(stack, route)
+	if i < 0 {
+		return stack
+	}
+	if !inclusive {
+		i++
+	}
+	if saveState && i < len(stack) {
+		savedStacks := maps.Clone(nc.savedStacks.Get())
+		savedStacks[stack[i].destinationKey()] = slices.Clone(stack[i:])
+		nc.savedStacks.Set(savedStacks)
+	}
+	return stack[:i]
+}
+
+func (nc *NavController) lastIndexOf(stack []BackStackEntry, route string) int {
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i].Route == route || stack[i].Destination == route {
+			return i
+		}
+	}
+	return -1
+}
+
+func (nc *NavController) unknownRoute(route string) {
+	if _, onUnknownRoute := nc.routing(); onUnknownRoute != nil {
+		onUnknownRoute(route)
+	}
 }
 
 func (nc *NavController) CurrentEntry() *BackStackEntry {
-	stack := nc.backStack.Get().([]BackStackEntry)
+	stack := nc.backStack.Get()
 	if len(stack) == 0 {
 		return nil
 	}
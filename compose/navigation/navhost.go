@@ -1,29 +1,77 @@
 package navigation
 
 import (
-	"fmt"
+	"log"
+
 	"github.com/zodimo/go-compose/pkg/api"
 )
 
+// NavHostOption is a functional option for configuring a NavHost.
+type NavHostOption func(*NavHostOptions)
+
+type NavHostOptions struct {
+	// OnUnknownRoute is called with the routes that match no destination of the
+	// graph, when navigated to or found at the top of the back stack.
+	OnUnknownRoute func(route string)
+}
+
+func DefaultNavHostOptions() NavHostOptions {
+	return NavHostOptions{
+		OnUnknownRoute: func(route string) {
+			log.Printf("navigation: no destination for route %q", route)
+		},
+	}
+}
+
+func WithOnUnknownRoute(onUnknownRoute func(route string)) NavHostOption {
+	return func(o *NavHostOptions) {
+		o.OnUnknownRoute = onUnknownRoute
+	}
+}
+
 func NavHost(
 	navController *NavController,
 	startDestination string,
 	builder func(*NavGraphBuilder),
+	options ...NavHostOption,
 ) api.Composable {
 	return func(c api.Composer) api.Composer {
+		opts := DefaultNavHostOptions()
+		for _, option := range options {
+			if option == nil {
+				continue
+			}
+			option(&opts)
+		}
+
 		graphBuilder := NewNavGraphBuilder()
 		builder(graphBuilder)
+		navController.setGraph(graphBuilder, opts.OnUnknownRoute)
+
+		// The unknown routes found by the composition are reported once, rather
+		// than by every recomposition, once the composition is applied.
+		reported := c.State("unknown_route", func() any { return "" })
+		reportUnknown := func(route string) {
+			c.SideEffect(func() {
+				if reported.Get() != route {
+					reported.Set(route)
+					navController.unknownRoute(route)
+				}
+			})
+		}
 
-		stack := navController.backStack.Get().([]BackStackEntry)
+		stack := navController.backStack.Get()
 		if len(stack) == 0 {
 			// Initialize with startDestination
-            // We use Navigate, but we must be careful about side effects during composition.
-            // Since this only happens when stack is empty (initial state), it should be safe enough
-            // provided the framework handles state updates.
-            // The update will trigger a recompose.
+			// We use Navigate, but we must be careful about side effects during composition.
+			// Since this only happens when stack is empty (initial state), it should be safe enough
+			// provided the framework handles state updates.
+			// The update will trigger a recompose.
+			if _, _, ok := graphBuilder.match(startDestination); !ok {
+				reportUnknown(startDestination)
+				return c
+			}
 			navController.Navigate(startDestination)
-            // Re-fetch stack after update to ensure we render the frame correctly if synchronous
-            stack = navController.backStack.Get().([]BackStackEntry)
 		}
 
 		currentEntry := navController.CurrentEntry()
@@ -31,16 +79,23 @@ func NavHost(
 			return c
 		}
 
-		destination, ok := graphBuilder.destinations[currentEntry.Route]
+		// The route is matched again, as the entry may have been added or
+		// restored before the graph was known.
+		destination, arguments, ok := graphBuilder.match(currentEntry.Route)
 		if !ok {
-            // Log warning or handle error?
-            // For now, print to stdout as we don't have a logger
-            fmt.Printf("Warning: Destination not found for route: %s\n", currentEntry.Route)
+			reportUnknown(currentEntry.Route)
 			return c
 		}
+		c.SideEffect(func() {
+			if reported.Get() != "" {
+				reported.Set("")
+			}
+		})
+		entry := *currentEntry
+		entry.Destination, entry.Arguments = destination.route, arguments
 
-		// We invoke the destination composable.
-        // It modifies the current composer.
-		return destination(c)
+		// Each entry is composed in its own group, so that entries of the same
+		// destination do not share their state.
+		return c.Key(entry.ID, destination.content(entry))(c)
 	}
 }
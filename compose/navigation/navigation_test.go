@@ -1,9 +1,15 @@
 package navigation
 
 import (
+	"bytes"
+	"fmt"
+	"sync"
 	"testing"
 
+	"github.com/zodimo/go-compose/compose"
 	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
 )
 
 // Mock MutableValue for testing
@@ -77,3 +83,210 @@ func TestNavGraphBuilder(t *testing.T) {
 		t.Error("Expected route1 to be present")
 	}
 }
+
+func newTestGraph() *NavGraphBuilder {
+	builder := NewNavGraphBuilder()
+	testDestinations(builder)
+	return builder
+}
+
+func testDestinations(builder *NavGraphBuilder) {
+	dummy := func(c api.Composer) api.Composer { return c }
+	builder.Composable("home", dummy)
+	builder.Composable("user/me", dummy)
+	builder.Composable("user/{id}?tab={tab}", dummy,
+		WithArguments(
+			NavArgument{Name: "id", Type: NavTypeInt},
+			NavArgument{Name: "tab", Type: NavTypeString, DefaultValue: "posts"},
+		),
+		WithDeepLinks("app://example.com/user/{id}?tab={tab}"),
+	)
+	builder.Composable("settings", dummy)
+}
+
+func TestNavGraphMatchesRouteTemplates(t *testing.T) {
+	graph := newTestGraph()
+
+	destination, arguments, ok := graph.match("user/42?tab=likes")
+	if !ok || destination.route != "user/{id}?tab={tab}" {
+		t.Fatalf("user/42?tab=likes matched %v, want user/{id}?tab={tab}", ok)
+	}
+	if id, ok := arguments.Int("id"); !ok || id != 42 {
+		t.Errorf("id = %d, %v; want 42", id, ok)
+	}
+	if tab, _ := arguments.String("tab"); tab != "likes" {
+		t.Errorf("tab = %q, want likes", tab)
+	}
+
+	_, arguments, _ = graph.match("user/7")
+	if tab, _ := arguments.String("tab"); tab != "posts" {
+		t.Errorf("tab = %q, want the default posts", tab)
+	}
+
+	if destination, _, _ := graph.match("user/me"); destination == nil || destination.route != "user/me" {
+		t.Error("Expected user/me to match its own destination before the template")
+	}
+	if _, _, ok := graph.match("user/abc"); ok {
+		t.Error("Expected an id that is not an int not to match")
+	}
+	if _, _, ok := graph.match("unknown"); ok {
+		t.Error("Expected an unknown route not to match")
+	}
+}
+
+func TestNavControllerNavOptions(t *testing.T) {
+	nc := NewNavController(&mockMutableValue{value: []BackStackEntry{}})
+	nc.setGraph(newTestGraph(), nil)
+	routes := func() []string {
+		var routes []string
+		for _, entry := range nc.backStack.Get() {
+			routes = append(routes, entry.Route)
+		}
+		return routes
+	}
+
+	nc.Navigate("home")
+	nc.Navigate("user/1")
+	first := nc.CurrentEntry().ID
+	nc.Navigate("user/2", WithLaunchSingleTop(true))
+	if got := routes(); len(got) != 2 || got[1] != "user/2" {
+		t.Errorf("back stack = %v, want [home user/2]", got)
+	}
+	if nc.CurrentEntry().ID != first {
+		t.Error("Expected a single top navigation to keep the entry")
+	}
+	if id, _ := nc.CurrentEntry().Arguments.Int("id"); id != 2 {
+		t.Errorf("id = %d, want 2", id)
+	}
+
+	// Switch to settings, saving the user entries, and back.
+	nc.Navigate("user/3")
+	nc.Navigate("settings", WithPopUpTo("home", false), WithPopUpToSaveState(true), WithRestoreState(true))
+	if got := routes(); len(got) != 2 || got[1] != "settings" {
+		t.Errorf("back stack = %v, want [home settings]", got)
+	}
+	nc.Navigate("user/9", WithPopUpTo("home", false), WithPopUpToSaveState(true), WithRestoreState(true))
+	if got := routes(); len(got) != 3 || got[1] != "user/2" || got[2] != "user/3" {
+		t.Errorf("back stack = %v, want the restored [home user/2 user/3]", got)
+	}
+
+	if !nc.PopBackStackTo("user/{id}?tab={tab}", true) {
+		t.Error("Expected the user destination to be in the back stack")
+	}
+	if got := routes(); len(got) != 2 || got[1] != "user/2" {
+		t.Errorf("back stack = %v, want [home user/2]", got)
+	}
+	if nc.PopBackStackTo("settings", false) {
+		t.Error("Expected settings not to be in the back stack")
+	}
+}
+
+func TestNavControllerDeepLinksAndUnknownRoutes(t *testing.T) {
+	nc := NewNavController(&mockMutableValue{value: []BackStackEntry{}})
+	var unknown []string
+	nc.setGraph(newTestGraph(), func(route string) { unknown = append(unknown, route) })
+
+	if !nc.NavigateDeepLink("app://example.com/user/5?tab=likes") {
+		t.Fatal("Expected the deep link to match the user destination")
+	}
+	entry := nc.CurrentEntry()
+	if id, _ := entry.Arguments.Int("id"); id != 5 || entry.Arguments["tab"] != "likes" || entry.Destination != "user/{id}?tab={tab}" {
+		t.Errorf("entry = %+v, want the user destination with id 5 and tab likes", entry)
+	}
+	if nc.NavigateDeepLink("app://example.com/post/5") {
+		t.Error("Expected a deep link of no destination not to match")
+	}
+
+	nc.Navigate("missing")
+	if len(unknown) != 1 || unknown[0] != "missing" {
+		t.Errorf("unknown routes = %v, want [missing]", unknown)
+	}
+	if nc.CurrentEntry().Route == "missing" {
+		t.Error("Expected an unknown route not to be navigated to")
+	}
+}
+
+// navHostFrame composes a NavHost of the test graph, as the content of a frame.
+func navHostFrame(ps state.PersistentState, startDestination string, onUnknownRoute func(string)) *NavController {
+	c := compose.NewComposer(ps)
+	c.StartBlock("Root")
+	nc := RememberNavController(c)
+	NavHost(nc, startDestination, testDestinations, WithOnUnknownRoute(onUnknownRoute))(c)
+	c.EndBlock()
+	c.Build()
+	return nc
+}
+
+func TestNavControllerSavedStacksSurviveRestart(t *testing.T) {
+	first := state.NewSaveableStateRegistry()
+	ps := store.NewPersistentState(map[string]state.MutableValue{}, store.WithSaveableStateRegistry(first))
+	nc := navHostFrame(ps, "home", nil)
+	nc.Navigate("user/1")
+	nc.Navigate("settings", WithPopUpTo("home", false), WithPopUpToSaveState(true))
+	navHostFrame(ps, "home", nil)
+
+	var saved bytes.Buffer
+	if err := first.Save(&saved); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	second, err := state.RestoreSaveableStateRegistry(&saved)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	ps = store.NewPersistentState(map[string]state.MutableValue{}, store.WithSaveableStateRegistry(second))
+	nc = navHostFrame(ps, "home", nil)
+	if got := nc.CurrentEntry().Route; got != "settings" {
+		t.Fatalf("current route = %q, want the restored settings", got)
+	}
+	nc.Navigate("user/9", WithPopUpTo("home", false), WithRestoreState(true))
+	if got := nc.CurrentEntry().Route; got != "user/1" {
+		t.Errorf("current route = %q, want the saved user/1", got)
+	}
+}
+
+func TestNavHostReportsUnknownStartDestinationOnce(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	var unknown []string
+	for range 3 {
+		navHostFrame(ps, "missing", func(route string) { unknown = append(unknown, route) })
+	}
+	if len(unknown) != 1 || unknown[0] != "missing" {
+		t.Errorf("unknown routes = %v, want [missing] once", unknown)
+	}
+}
+
+func TestNavControllerNavigatesWhileNavHostComposes(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	nc := navHostFrame(ps, "home", nil)
+
+	// Two effects navigate while the NavHost composes; none of their entries
+	// is lost.
+	const navigations = 500
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range navigations {
+				nc.Navigate(fmt.Sprintf("user/%d", i))
+			}
+		}()
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	for composing := true; composing; {
+		select {
+		case <-done:
+			composing = false
+		default:
+			navHostFrame(ps, "home", nil)
+		}
+	}
+	if got := len(nc.backStack.Get()); got != 1+2*navigations {
+		t.Errorf("back stack has %d entries, want %d", got, 1+2*navigations)
+	}
+}
@@ -0,0 +1,130 @@
+package navigation
+
+import (
+	"net/url"
+	"strings"
+)
+
+// routePattern is a parsed route template, such as "user/{id}?tab={tab}".
+// Path segments in braces are required arguments; query parameters whose
+// value is in braces are optional arguments. Deep link templates, such as
+// "app://example.com/user/{id}", are parsed the same way.
+type routePattern struct {
+	template string
+	segments []routeSegment
+	query    []queryParameter
+}
+
+// routeSegment is a literal path segment, or an argument when argument is set.
+type routeSegment struct {
+	literal  string
+	argument string
+}
+
+// queryParameter is a query parameter whose value is the argument named
+// argument.
+type queryParameter struct {
+	key      string
+	argument string
+}
+
+func parseRoutePattern(template string) routePattern {
+	pattern := routePattern{template: template}
+	path, query, _ := strings.Cut(template, "?")
+	for _, segment := range strings.Split(path, "/") {
+		if name, ok := argumentName(segment); ok {
+			pattern.segments = append(pattern.segments, routeSegment{argument: name})
+		} else {
+			pattern.segments = append(pattern.segments, routeSegment{literal: segment})
+		}
+	}
+	if query == "" {
+		return pattern
+	}
+	for _, parameter := range strings.Split(query, "&") {
+		key, value, _ := strings.Cut(parameter, "=")
+		if name, ok := argumentName(value); ok {
+			pattern.query = append(pattern.query, queryParameter{key: key, argument: name})
+		}
+	}
+	return pattern
+}
+
+// argumentName returns name for a segment "{name}".
+func argumentName(segment string) (string, bool) {
+	if len(segment) < 2 || segment[0] != '{' || segment[len(segment)-1] != '}' {
+		return "", false
+	}
+	return segment[1 : len(segment)-1], true
+}
+
+// literal reports whether the pattern has no arguments.
+func (p routePattern) literal() bool {
+	for _, segment := range p.segments {
+		if segment.argument != "" {
+			return false
+		}
+	}
+	return len(p.query) == 0
+}
+
+// match returns the arguments of route when it matches the pattern. Query
+// parameters missing from route are missing from the arguments.
+func (p routePattern) match(route string) (NavArguments, bool) {
+	path, query, _ := strings.Cut(route, "?")
+	segments := strings.Split(path, "/")
+	if len(segments) != len(p.segments) {
+		return nil, false
+	}
+	arguments := NavArguments{}
+	for i, segment := range p.segments {
+		if segment.argument == "" {
+			if segments[i] != segment.literal {
+				return nil, false
+			}
+			continue
+		}
+		value, err := url.PathUnescape(segments[i])
+		if err != nil || value == "" {
+			return nil, false
+		}
+		arguments[segment.argument] = value
+	}
+	if len(p.query) == 0 {
+		return arguments, true
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return nil, false
+	}
+	for _, parameter := range p.query {
+		if values.Has(parameter.key) {
+			arguments[parameter.argument] = values.Get(parameter.key)
+		}
+	}
+	return arguments, true
+}
+
+// build returns the route of the pattern with arguments. Query parameters
+// whose argument is missing are left out.
+func (p routePattern) build(arguments NavArguments) string {
+	segments := make([]string, len(p.segments))
+	for i, segment := range p.segments {
+		if segment.argument == "" {
+			segments[i] = segment.literal
+		} else {
+			segments[i] = url.PathEscape(arguments[segment.argument])
+		}
+	}
+	route := strings.Join(segments, "/")
+	query := url.Values{}
+	for _, parameter := range p.query {
+		if value, ok := arguments[parameter.argument]; ok {
+			query.Set(parameter.key, value)
+		}
+	}
+	if len(query) > 0 {
+		route += "?" + query.Encode()
+	}
+	return route
+}
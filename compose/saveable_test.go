@@ -0,0 +1,41 @@
+package compose_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+func TestRememberSaveableSurvivesRestart(t *testing.T) {
+	run := func(registry state.SaveableStateRegistry, update func(state.TypedMutableValue[int])) {
+		ps := store.NewPersistentState(map[string]state.MutableValue{}, store.WithSaveableStateRegistry(registry))
+		c := compose.NewComposer(ps)
+		c.StartBlock("Root")
+		c.StartBlock("Tabs")
+		update(state.RememberSaveable(c, "selected", func() int { return 0 }, state.JSONSaver[int]()))
+		c.EndBlock()
+		c.EndBlock()
+		c.Build()
+	}
+
+	first := state.NewSaveableStateRegistry()
+	run(first, func(selected state.TypedMutableValue[int]) { selected.Set(3) })
+
+	var saved bytes.Buffer
+	if err := first.Save(&saved); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	second, err := state.RestoreSaveableStateRegistry(&saved)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+
+	run(second, func(selected state.TypedMutableValue[int]) {
+		if got := selected.Get(); got != 3 {
+			t.Errorf("Expected the selection to be restored, got %d", got)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+package compose_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+func TestCompositionReadsASnapshot(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	value := state.NewMutableState(1, nil)
+	written := state.NewMutableState(0, nil)
+
+	c := compose.NewComposer(ps)
+	c.StartBlock("Root")
+	before := value.Get()
+	done := make(chan struct{})
+	go func() {
+		// An effect writing while the frame is composed.
+		value.Set(2)
+		close(done)
+	}()
+	<-done
+	if after := value.Get(); after != before {
+		t.Errorf("Expected composition to keep reading %v, got %v", before, after)
+	}
+	written.Set(1)
+	if got := <-readElsewhere(written); got != 0 {
+		t.Errorf("Expected writes of the composition to be applied in Build, got %v", got)
+	}
+	c.EndBlock()
+	c.Build()
+
+	if got := value.Get(); got != 2 {
+		t.Errorf("Expected the effect write to survive the frame, got %v", got)
+	}
+	if got := written.Get(); got != 1 {
+		t.Errorf("Expected the composition write to be applied, got %v", got)
+	}
+}
+
+func readElsewhere(value state.MutableState[int]) <-chan int {
+	read := make(chan int, 1)
+	go func() { read <- value.Get() }()
+	return read
+}
+
+func TestPanickingCompositionReleasesItsSnapshot(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	written := state.NewMutableState(0, nil)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("Expected the composition to panic")
+			}
+		}()
+		compose.Compose(ps, func(c compose.Composer) compose.Composer {
+			c.StartBlock("Root")
+			written.Set(1)
+			panic("composable failed")
+		})
+	}()
+	if snapshot := state.CurrentSnapshot(); snapshot != nil {
+		t.Errorf("Expected the snapshot of the composition to be left, still in %v", snapshot.ID())
+	}
+	if got := written.Get(); got != 0 {
+		t.Errorf("Expected the writes of the abandoned composition to be dropped, got %v", got)
+	}
+
+	compose.Compose(ps, func(c compose.Composer) compose.Composer {
+		c.StartBlock("Root")
+		written.Set(2)
+		return c.EndBlock()
+	})
+	if got := written.Get(); got != 2 {
+		t.Errorf("Expected the next composition to be applied, got %v", got)
+	}
+}
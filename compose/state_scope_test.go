@@ -0,0 +1,59 @@
+package compose_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+// Counter reads a state value with a fixed key, like a reusable component would.
+func Counter(value *state.MutableValue) compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		c.StartBlock("Counter")
+		*value = c.State("count", func() any { return 0 })
+		return c.EndBlock()
+	}
+}
+
+func TestStateKeysAreScoped(t *testing.T) {
+	scopes := map[string]state.MutableValue{}
+	mockStore := store.NewPersistentState(scopes)
+
+	var first, second, global1, global2 state.MutableValue
+	frame := func(showSecond bool) {
+		c := compose.NewComposer(mockStore)
+		c.StartBlock("Root")
+		Counter(&first)(c)
+		c.When(showSecond, Counter(&second))(c)
+		global1 = c.State(state.GlobalKey("shared"), func() any { return 0 })
+		global2 = c.State(state.GlobalKey("shared"), func() any { return 0 })
+		c.EndBlock()
+		c.Build()
+	}
+
+	frame(true)
+	if first == second {
+		t.Error("Expected equal keys in different groups to address different values")
+	}
+	if global1 != global2 {
+		t.Error("Expected global keys to address the same value")
+	}
+
+	second.Set(5)
+	frame(true)
+	if second.Get() != 5 {
+		t.Errorf("Expected scoped state to survive recomposition, got %v", second.Get())
+	}
+
+	stored := len(scopes)
+	frame(false)
+	if len(scopes) != stored-1 {
+		t.Errorf("Expected state of the removed group to be collected, had %d values, now %d", stored, len(scopes))
+	}
+	frame(true)
+	if second.Get() != 0 {
+		t.Errorf("Expected re-entering group to start from the initial value, got %v", second.Get())
+	}
+}
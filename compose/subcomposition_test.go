@@ -0,0 +1,69 @@
+package compose_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+type forgettable struct{ forgotten *int }
+
+func (f forgettable) OnRemembered() {}
+func (f forgettable) OnForgotten()  { *f.forgotten++ }
+
+var LocalName = compose.CompositionLocalOf(func() string { return "default" })
+
+func TestSubcomposition(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+
+	var sub compose.Subcomposition
+	frame := func(keep bool) {
+		c := compose.NewComposer(ps)
+		c.StartBlock("Root")
+		if keep {
+			compose.CompositionLocalProvider1(LocalName, "provided", func(c compose.Composer) compose.Composer {
+				c.StartBlock("Layout")
+				sub = c.Subcomposition()
+				return c.EndBlock()
+			})(c)
+		}
+		c.EndBlock()
+		c.Build()
+	}
+
+	calculations, forgotten := 0, 0
+	var name string
+	slot := func(c compose.Composer) compose.Composer {
+		c.StartBlock("Slot")
+		c.Remember("value", func() any { calculations++; return forgettable{&forgotten} })
+		name = LocalName.Current(c)
+		return c.EndBlock()
+	}
+
+	frame(true)
+	if root := sub.Compose("slot", slot); len(root.LayoutNodeChildren()) != 1 {
+		t.Fatalf("Expected the slot to emit one node, got %d", len(root.LayoutNodeChildren()))
+	}
+	if name != "provided" {
+		t.Errorf("Expected the slot to see the locals of its group, got %q", name)
+	}
+
+	frame(true)
+	sub.Compose("slot", slot)
+	if calculations != 1 {
+		t.Errorf("Expected the slot to keep its remembered values, got %d calculations", calculations)
+	}
+
+	sub.Dispose("slot")
+	if forgotten != 1 {
+		t.Errorf("Expected the disposed slot to forget its values, got %d", forgotten)
+	}
+
+	sub.Compose("slot", slot)
+	frame(false)
+	if forgotten != 2 {
+		t.Errorf("Expected the slots to be disposed with their group, got %d", forgotten)
+	}
+}
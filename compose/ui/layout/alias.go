@@ -0,0 +1,33 @@
+package layout
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+	"github.com/zodimo/go-compose/pkg/api"
+)
+
+type Modifier = modifier.Modifier
+
+var EmptyModifier = modifier.EmptyModifier
+
+type Composable = api.Composable
+type Composer = api.Composer
+
+type Constraints = unit.Constraints
+
+type Measurable = layoutnode.Measurable
+type IntrinsicMeasurable = layoutnode.IntrinsicMeasurable
+type Placeable = layoutnode.Placeable
+type MeasureScope = layoutnode.MeasureScope
+type PlacementScope = layoutnode.PlacementScope
+type MeasureResult = layoutnode.MeasureResult
+type MeasurePolicy = layoutnode.MeasurePolicy
+type IntrinsicMeasurePolicy = layoutnode.IntrinsicMeasurePolicy
+
+type LayoutDirection = layoutnode.LayoutDirection
+
+const (
+	LayoutDirectionLTR = layoutnode.LayoutDirectionLTR
+	LayoutDirectionRTL = layoutnode.LayoutDirectionRTL
+)
@@ -0,0 +1,55 @@
+package layout
+
+import (
+	"github.com/zodimo/go-compose/internal/layoutnode"
+)
+
+// MeasurePolicyFunc measures the children of a layout and places them.
+//
+//	func(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult {
+//		placeables := make([]Placeable, len(measurables))
+//		for i, m := range measurables {
+//			placeables[i] = m.Measure(constraints)
+//		}
+//		return scope.Layout(constraints.MaxWidth(), constraints.MaxHeight(), func(scope PlacementScope) {
+//			for _, p := range placeables {
+//				scope.PlaceRelative(p, 0, 0)
+//			}
+//		})
+//	}
+type MeasurePolicyFunc func(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult
+
+// NewMeasurePolicy returns a MeasurePolicy that measures with measure.
+func NewMeasurePolicy(measure MeasurePolicyFunc) MeasurePolicy {
+	return layoutnode.NewMeasurePolicy(measure)
+}
+
+// Layout lays out the nodes emitted by content with measurePolicy.
+//
+// The policy is given a Measurable for each child, in the order they were
+// emitted, and the constraints of the layout. It measures each child, as
+// often as it needs, and returns the size of the layout with the placement of
+// the children. Children that are not placed are not drawn.
+//
+// The intrinsic size of the layout is computed by running the policy with
+// children that take their intrinsic size, unless the policy implements
+// IntrinsicMeasurePolicy.
+func Layout(content Composable, measurePolicy MeasurePolicy, options ...LayoutOption) Composable {
+	opts := DefaultLayoutOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return func(c Composer) Composer {
+		c.StartBlock("Layout")
+		c.Modifier(func(modifier Modifier) Modifier {
+			return modifier.Then(opts.Modifier)
+		})
+		c.WithComposable(content)
+		c.SetWidgetConstructor(layoutnode.NewMeasurePolicyWidgetConstructor(measurePolicy))
+
+		return c.EndBlock()
+	}
+}
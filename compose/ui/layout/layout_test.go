@@ -0,0 +1,73 @@
+package layout_test
+
+import (
+	"image"
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/compose/ui/layout"
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/modifiers/size"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+
+	"gioui.org/op"
+)
+
+type placement struct{ x, y int }
+
+// flowRow places its children in rows, starting a new row when a child does
+// not fit in the current one.
+func flowRow(placements *[]placement) layout.MeasurePolicy {
+	return layout.NewMeasurePolicy(func(scope layout.MeasureScope, measurables []layout.Measurable, constraints layout.Constraints) layout.MeasureResult {
+		childConstraints := unit.NewConstraints(0, constraints.MaxWidth(), 0, constraints.MaxHeight())
+		positions := make([]placement, len(measurables))
+		placeables := make([]layout.Placeable, len(measurables))
+		x, y, rowHeight, width := 0, 0, 0, 0
+		for i, m := range measurables {
+			p := m.Measure(childConstraints)
+			if x > 0 && x+p.Width() > constraints.MaxWidth() {
+				x, y, rowHeight = 0, y+rowHeight, 0
+			}
+			placeables[i], positions[i] = p, placement{x, y}
+			x += p.Width()
+			width = max(width, x)
+			rowHeight = max(rowHeight, p.Height())
+		}
+		return scope.Layout(width, y+rowHeight, func(scope layout.PlacementScope) {
+			for i, p := range placeables {
+				scope.PlaceRelative(p, positions[i].x, positions[i].y)
+			}
+			*placements = positions
+		})
+	})
+}
+
+func item(width, height int) compose.Composable {
+	return box.Box(compose.Id(), box.WithModifier(size.Size(width, height)))
+}
+
+func TestLayout(t *testing.T) {
+	c := compose.NewComposer(store.NewPersistentState(map[string]state.MutableValue{}))
+	var placements []placement
+	layout.Layout(compose.Sequence(item(40, 10), item(40, 15), item(40, 10)), flowRow(&placements))(c)
+
+	gtx := layoutnode.LayoutContext{Ops: new(op.Ops)}
+	gtx.Constraints.Max = image.Pt(100, 100)
+	dims := layoutnode.NewNodeCoordinator(c.Build()).Layout(gtx)
+
+	if dims.Size != image.Pt(80, 25) {
+		t.Errorf("size = %v, want (80,25)", dims.Size)
+	}
+	want := []placement{{0, 0}, {40, 0}, {0, 15}}
+	if len(placements) != len(want) {
+		t.Fatalf("placements = %v, want %v", placements, want)
+	}
+	for i := range want {
+		if placements[i] != want[i] {
+			t.Errorf("placement %d = %v, want %v", i, placements[i], want[i])
+		}
+	}
+}
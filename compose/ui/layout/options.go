@@ -0,0 +1,19 @@
+package layout
+
+type LayoutOptions struct {
+	Modifier Modifier
+}
+
+type LayoutOption func(*LayoutOptions)
+
+func DefaultLayoutOptions() LayoutOptions {
+	return LayoutOptions{
+		Modifier: EmptyModifier,
+	}
+}
+
+func WithModifier(m Modifier) LayoutOption {
+	return func(o *LayoutOptions) {
+		o.Modifier = m
+	}
+}
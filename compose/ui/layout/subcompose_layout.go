@@ -0,0 +1,86 @@
+package layout
+
+import (
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/pkg/api"
+)
+
+// SubcomposeMeasureScope is the scope of a SubcomposeMeasurePolicy.
+type SubcomposeMeasureScope interface {
+	MeasureScope
+
+	// Subcompose composes content as the slot slotID and returns the nodes it
+	// emitted as measurables. A slot keeps its state between frames for as long
+	// as it is subcomposed by every measurement; slotID must be comparable.
+	Subcompose(slotID any, content Composable) []Measurable
+}
+
+// SubcomposeMeasurePolicy composes the children of a layout while measuring it,
+// and places them.
+type SubcomposeMeasurePolicy func(scope SubcomposeMeasureScope, constraints Constraints) MeasureResult
+
+// SubcomposeLayout is a Layout that composes its children during measurement,
+// so that they can depend on the constraints of the layout or on the size of
+// other children:
+//
+//	layout.SubcomposeLayout(func(scope layout.SubcomposeMeasureScope, constraints layout.Constraints) layout.MeasureResult {
+//		header := scope.Subcompose("header", Header())[0].Measure(constraints)
+//		body := scope.Subcompose("body", Body(header.Height()))[0].Measure(constraints)
+//		return scope.Layout(constraints.MaxWidth(), header.Height()+body.Height(), func(scope layout.PlacementScope) {
+//			scope.PlaceRelative(header, 0, 0)
+//			scope.PlaceRelative(body, 0, header.Height())
+//		})
+//	})
+//
+// The slots inherit the composition locals of the layout. A slot that is not
+// subcomposed by a measurement is disposed at its end.
+func SubcomposeLayout(measurePolicy SubcomposeMeasurePolicy, options ...LayoutOption) Composable {
+	opts := DefaultLayoutOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+	return func(c Composer) Composer {
+		c.StartBlock("SubcomposeLayout")
+		c.Modifier(func(modifier Modifier) Modifier {
+			return modifier.Then(opts.Modifier)
+		})
+		subcomposition := c.Subcomposition()
+		state := c.Remember("slots", func() any {
+			return &subcomposeLayoutState{slots: layoutnode.NewSubcomposeSlots(subcomposition.Dispose)}
+		}).(*subcomposeLayoutState)
+
+		c.SetWidgetConstructor(layoutnode.NewSubcomposeWidgetConstructor(state.slots, layoutnode.NewSubcomposeMeasurePolicy(
+			func(scope layoutnode.SubcomposeMeasureScope, constraints Constraints) MeasureResult {
+				return measurePolicy(subcomposeMeasureScope{SubcomposeMeasureScope: scope, subcomposition: subcomposition}, constraints)
+			},
+		)))
+
+		return c.EndBlock()
+	}
+}
+
+// subcomposeLayoutState keeps the slots of a SubcomposeLayout for as long as
+// the layout is in the composition.
+type subcomposeLayoutState struct {
+	slots layoutnode.SubcomposeSlots
+}
+
+func (s *subcomposeLayoutState) OnRemembered() {}
+
+func (s *subcomposeLayoutState) OnForgotten() {
+	s.slots.Dispose()
+}
+
+type subcomposeMeasureScope struct {
+	layoutnode.SubcomposeMeasureScope
+	subcomposition api.Subcomposition
+}
+
+func (s subcomposeMeasureScope) Subcompose(slotID any, content Composable) []Measurable {
+	return s.SubcomposeMeasureScope.Subcompose(slotID, func() layoutnode.LayoutNode {
+		return s.subcomposition.Compose(slotID, content)
+	})
+}
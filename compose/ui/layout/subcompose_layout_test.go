@@ -0,0 +1,131 @@
+package layout_test
+
+import (
+	"image"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/compose/ui/layout"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+
+	"gioui.org/op"
+)
+
+// host composes content once and lays it out at the given maximum sizes.
+type host struct {
+	coordinator layoutnode.NodeCoordinator
+	now         time.Time
+}
+
+func newHost(content compose.Composable) *host {
+	c := compose.NewComposer(store.NewPersistentState(map[string]state.MutableValue{}))
+	content(c)
+	return &host{coordinator: layoutnode.NewNodeCoordinator(c.Build())}
+}
+
+func (h *host) layout(maxWidth, maxHeight int) image.Point {
+	gtx := layoutnode.LayoutContext{Ops: new(op.Ops), Now: h.now}
+	gtx.Constraints.Max = image.Pt(maxWidth, maxHeight)
+	return h.coordinator.Layout(gtx).Size
+}
+
+func TestBoxWithConstraints(t *testing.T) {
+	h := newHost(box.BoxWithConstraints(func(scope box.BoxWithConstraintsScope) compose.Composable {
+		if scope.MaxWidth() < 100 {
+			return item(10, 10)
+		}
+		return item(50, 50)
+	}))
+
+	if got := h.layout(80, 200); got != image.Pt(10, 10) {
+		t.Errorf("narrow size = %v, want (10,10)", got)
+	}
+	if got := h.layout(200, 200); got != image.Pt(50, 50) {
+		t.Errorf("wide size = %v, want (50,50)", got)
+	}
+}
+
+type forgettable struct{ forgotten *int }
+
+func (f forgettable) OnRemembered() {}
+func (f forgettable) OnForgotten()  { *f.forgotten++ }
+
+func TestSubcomposeLayoutDisposesUnusedSlots(t *testing.T) {
+	forgotten := 0
+	detail := func(c compose.Composer) compose.Composer {
+		c.Remember("detail", func() any { return forgettable{&forgotten} })
+		return item(20, 20)(c)
+	}
+	h := newHost(layout.SubcomposeLayout(func(scope layout.SubcomposeMeasureScope, constraints layout.Constraints) layout.MeasureResult {
+		var placeables []layout.Placeable
+		for _, m := range scope.Subcompose("list", item(30, 30)) {
+			placeables = append(placeables, m.Measure(constraints))
+		}
+		if constraints.MaxWidth() > 100 {
+			for _, m := range scope.Subcompose("detail", detail) {
+				placeables = append(placeables, m.Measure(constraints))
+			}
+		}
+		width, height := 0, 0
+		for _, p := range placeables {
+			width += p.Width()
+			height = max(height, p.Height())
+		}
+		return scope.Layout(width, height, func(scope layout.PlacementScope) {
+			x := 0
+			for _, p := range placeables {
+				scope.PlaceRelative(p, x, 0)
+				x += p.Width()
+			}
+		})
+	}))
+
+	if got := h.layout(200, 200); got != image.Pt(50, 30) {
+		t.Errorf("wide size = %v, want (50,30)", got)
+	}
+	h.layout(200, 200)
+	if forgotten != 0 {
+		t.Fatalf("Expected the detail slot to be kept, forgotten %d", forgotten)
+	}
+	if got := h.layout(80, 200); got != image.Pt(30, 30) {
+		t.Errorf("narrow size = %v, want (30,30)", got)
+	}
+	if forgotten != 1 {
+		t.Errorf("Expected the detail slot to be disposed, forgotten %d", forgotten)
+	}
+}
+
+func TestSubcomposeLayoutComposesSlotsOncePerFrame(t *testing.T) {
+	composed := 0
+	slot := func(c compose.Composer) compose.Composer {
+		composed++
+		return item(20, 20)(c)
+	}
+	h := newHost(layout.SubcomposeLayout(func(scope layout.SubcomposeMeasureScope, constraints layout.Constraints) layout.MeasureResult {
+		p := scope.Subcompose("slot", slot)[0].Measure(constraints)
+		return scope.Layout(p.Width(), p.Height(), func(scope layout.PlacementScope) {
+			scope.PlaceRelative(p, 0, 0)
+		})
+	}))
+
+	// A frame lays the layout out once per pass.
+	for range 3 {
+		h.layout(200, 200)
+	}
+	if composed != 1 {
+		t.Errorf("composed the slot %d times in a frame, want 1", composed)
+	}
+	h.layout(100, 200)
+	if composed != 2 {
+		t.Errorf("composed the slot %d times after the constraints changed, want 2", composed)
+	}
+	h.now = h.now.Add(time.Second)
+	h.layout(100, 200)
+	if composed != 3 {
+		t.Errorf("composed the slot %d times after the next frame started, want 3", composed)
+	}
+}
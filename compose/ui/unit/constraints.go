@@ -326,7 +326,7 @@ func maxInt(a, b int) int {
 // FitPrioritizingWidth creates constraints favoring width bit allocation.
 func FitPrioritizingWidth(minWidth, maxWidth, minHeight, maxHeight int) Constraints {
 	minW := min(minWidth, maxFocusMask-1)
-	maxW := minW
+	maxW := Infinity
 	if maxWidth != Infinity {
 		maxW = min(maxWidth, maxFocusMask-1)
 	}
@@ -337,7 +337,7 @@ func FitPrioritizingWidth(minWidth, maxWidth, minHeight, maxHeight int) Constrai
 	}
 	maxAllowed := maxAllowedForSize(consumed)
 
-	maxH := maxAllowed
+	maxH := Infinity
 	if maxHeight != Infinity {
 		maxH = min(maxAllowed, maxHeight)
 	}
@@ -349,7 +349,7 @@ func FitPrioritizingWidth(minWidth, maxWidth, minHeight, maxHeight int) Constrai
 // FitPrioritizingHeight creates constraints favoring height bit allocation.
 func FitPrioritizingHeight(minWidth, maxWidth, minHeight, maxHeight int) Constraints {
 	minH := min(minHeight, maxFocusMask-1)
-	maxH := minH
+	maxH := Infinity
 	if maxHeight != Infinity {
 		maxH = min(maxHeight, maxFocusMask-1)
 	}
@@ -360,7 +360,7 @@ func FitPrioritizingHeight(minWidth, maxWidth, minHeight, maxHeight int) Constra
 	}
 	maxAllowed := maxAllowedForSize(consumed)
 
-	maxW := maxAllowed
+	maxW := Infinity
 	if maxWidth != Infinity {
 		maxW = min(maxAllowed, maxWidth)
 	}
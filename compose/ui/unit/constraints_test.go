@@ -174,3 +174,10 @@ func TestConstraints_Offset(t *testing.T) {
 		t.Errorf("Offset clamping failed")
 	}
 }
+
+func TestFitPrioritizingWidth_KeepsUnboundedMax(t *testing.T) {
+	c := FitPrioritizingWidth(10, Infinity, 0, 100)
+	if c.MinWidth() != 10 || c.MaxWidth() != Infinity || c.MaxHeight() != 100 {
+		t.Errorf("got %v", c)
+	}
+}
@@ -1,6 +1,9 @@
 package unit
 
 import (
+	"image"
+
+	"gioui.org/layout"
 	gioUnit "gioui.org/unit"
 )
 
@@ -17,3 +20,38 @@ func TextUnitToGioSp(tu TextUnit) gioUnit.Sp {
 	}
 	return gioUnit.Sp(tu.Value())
 }
+
+// GioInfinity is the size Gio layouts use for unbounded constraints, as in
+// layout.List.
+const GioInfinity = 1e6
+
+// GioConstraintsToConstraints converts Gio constraints. Maximums of GioInfinity
+// or more are unbounded, and sizes too large to be represented are reduced.
+func GioConstraintsToConstraints(c layout.Constraints) Constraints {
+	maxWidth, maxHeight := c.Max.X, c.Max.Y
+	if maxWidth >= GioInfinity {
+		maxWidth = Infinity
+	}
+	if maxHeight >= GioInfinity {
+		maxHeight = Infinity
+	}
+	minWidth := min(max(c.Min.X, 0), maxWidth)
+	minHeight := min(max(c.Min.Y, 0), maxHeight)
+	return FitPrioritizingWidth(minWidth, max(maxWidth, 0), minHeight, max(maxHeight, 0))
+}
+
+// ConstraintsToGio converts constraints to Gio constraints, with unbounded
+// maximums set to GioInfinity.
+func ConstraintsToGio(c Constraints) layout.Constraints {
+	maxWidth, maxHeight := c.MaxWidth(), c.MaxHeight()
+	if maxWidth == Infinity {
+		maxWidth = GioInfinity
+	}
+	if maxHeight == Infinity {
+		maxHeight = GioInfinity
+	}
+	return layout.Constraints{
+		Min: image.Pt(c.MinWidth(), c.MinHeight()),
+		Max: image.Pt(maxWidth, maxHeight),
+	}
+}
@@ -0,0 +1,19 @@
+package unit
+
+import (
+	"image"
+	"testing"
+
+	"gioui.org/layout"
+)
+
+func TestGioConstraintsRoundTrip(t *testing.T) {
+	gio := layout.Constraints{Min: image.Pt(10, 0), Max: image.Pt(400, GioInfinity)}
+	c := GioConstraintsToConstraints(gio)
+	if c.MinWidth() != 10 || c.MaxWidth() != 400 || c.HasBoundedHeight() {
+		t.Fatalf("GioConstraintsToConstraints(%v) = %v", gio, c)
+	}
+	if got := ConstraintsToGio(c); got != gio {
+		t.Errorf("ConstraintsToGio(%v) = %v, want %v", c, got, gio)
+	}
+}
@@ -0,0 +1,106 @@
+package composetest
+
+import (
+	"time"
+
+	"github.com/zodimo/go-compose/compose/frameclock"
+)
+
+// FrameDuration is the time between the frames rendered by a TestClock.
+const FrameDuration = frameclock.FrameDuration
+
+// TestClock is the clock of the frames of a ComposeTestRule. It only advances
+// when told to, so that animations and effects are deterministic: it is the
+// frame clock of the content, which animations and effects wait on.
+//
+// With auto advance, the default, WaitForIdle advances the clock frame by frame
+// while animations or effects wait on it, so that they run to completion.
+// Without it, they only run as the test advances the clock:
+//
+//	clock := rule.MainClock()
+//	clock.SetAutoAdvance(false)
+//	rule.OnNodeWithText("Open").PerformClick()
+//	clock.AdvanceTimeBy(150 * time.Millisecond)
+//	// The drawer is half open.
+type TestClock interface {
+	// Now returns the time of the frames.
+	Now() time.Time
+	// AdvanceTimeByFrame advances the clock by a frame, and renders it.
+	AdvanceTimeByFrame()
+	// AdvanceTimeBy advances the clock by duration, rendering a frame every
+	// FrameDuration, and a last one at the end of duration.
+	AdvanceTimeBy(duration time.Duration)
+	// AdvanceTimeUntil advances the clock frame by frame until condition holds,
+	// failing the test if it does not within timeout.
+	AdvanceTimeUntil(condition func() bool, timeout time.Duration)
+	// AutoAdvance reports whether WaitForIdle advances the clock.
+	AutoAdvance() bool
+	SetAutoAdvance(autoAdvance bool)
+}
+
+var _ TestClock = (*testClock)(nil)
+
+type testClock struct {
+	rule        *composeTestRule
+	frames      frameclock.BroadcastFrameClock
+	autoAdvance bool
+}
+
+func newTestClock(rule *composeTestRule, start time.Time) *testClock {
+	// The test clock is only advanced by the test, so waiting on it requests
+	// nothing.
+	frames := frameclock.NewBroadcastFrameClock(nil)
+	frames.SendFrame(start)
+	return &testClock{
+		rule:        rule,
+		frames:      frames,
+		autoAdvance: true,
+	}
+}
+
+func (c *testClock) Now() time.Time {
+	return c.frames.Now()
+}
+
+func (c *testClock) AdvanceTimeByFrame() {
+	c.AdvanceTimeBy(FrameDuration)
+}
+
+func (c *testClock) AdvanceTimeBy(duration time.Duration) {
+	c.rule.t.Helper()
+	end := c.Now().Add(duration)
+	for c.Now().Before(end) {
+		c.advance(min(FrameDuration, end.Sub(c.Now())))
+	}
+	c.rule.settle()
+}
+
+func (c *testClock) AdvanceTimeUntil(condition func() bool, timeout time.Duration) {
+	c.rule.t.Helper()
+	end := c.Now().Add(timeout)
+	for !condition() {
+		if !c.Now().Before(end) {
+			c.rule.t.Fatalf("condition not met after advancing the clock by %v", timeout)
+		}
+		c.advance(FrameDuration)
+		c.rule.settle()
+	}
+}
+
+func (c *testClock) AutoAdvance() bool {
+	return c.autoAdvance
+}
+
+func (c *testClock) SetAutoAdvance(autoAdvance bool) {
+	c.autoAdvance = autoAdvance
+}
+
+// advance sends a frame d after the last one to the animations and effects
+// waiting for it, and renders it once they are idle.
+func (c *testClock) advance(d time.Duration) {
+	c.rule.t.Helper()
+	c.frames.SendFrame(c.Now().Add(d))
+	c.rule.awaitEffects()
+	c.rule.dirty.Store(false)
+	c.rule.frame()
+}
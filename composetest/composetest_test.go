@@ -0,0 +1,156 @@
+package composetest_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/effect"
+	"github.com/zodimo/go-compose/compose/foundation/layout/box"
+	"github.com/zodimo/go-compose/compose/foundation/layout/column"
+	"github.com/zodimo/go-compose/compose/foundation/lazy"
+	"github.com/zodimo/go-compose/compose/foundation/text"
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/compose/material3/button"
+	"github.com/zodimo/go-compose/compose/material3/textfield"
+	"github.com/zodimo/go-compose/composetest"
+	"github.com/zodimo/go-compose/modifiers/semantics"
+	"github.com/zodimo/go-compose/modifiers/size"
+	"github.com/zodimo/go-compose/screenshot/golden"
+)
+
+func counter() compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		count := c.State("count", func() any { return 0 })
+		return column.Column(c.Sequence(
+			text.Text(fmt.Sprint(count.Get()), text.WithModifier(semantics.TestTag("count"))),
+			button.Filled(func() { count.Set(count.Get().(int) + 1) }, "Increment"),
+			// Reset is disabled: a click that reached it would set the count to 0.
+			button.Filled(func() { count.Set(0) }, "Reset", button.WithEnabled(false)),
+		))(c)
+	}
+}
+
+func TestComposeTestRuleClick(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	rule.SetContent(counter())
+
+	rule.OnNodeWithTag("count").AssertIsDisplayed().AssertTextEquals("0")
+	rule.OnNodeWithText("Increment").AssertIsEnabled().PerformClick()
+	rule.OnNodeWithTag("count").AssertTextEquals("1")
+	rule.OnNodeWithText("Reset").AssertIsNotEnabled().PerformClick()
+	rule.OnNodeWithTag("count").AssertTextEquals("1")
+	rule.OnNodeWithText("Decrement").AssertDoesNotExist()
+}
+
+func TestComposeTestRuleContentDescription(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	rule.SetContent(box.Box(compose.Id(), box.WithModifier(
+		size.Size(40, 40).Then(semantics.ContentDescription("avatar")),
+	)))
+
+	node := rule.OnNodeWithContentDescription("avatar").AssertIsDisplayed().FetchSemanticsNode()
+	if got := node.Bounds.Size(); got.X != 40 || got.Y != 40 {
+		t.Errorf("avatar is %v, want 40x40", got)
+	}
+}
+
+func TestComposeTestRuleTextInput(t *testing.T) {
+	var value string
+	rule := composetest.NewComposeTestRule(t)
+	rule.SetContent(func(c compose.Composer) compose.Composer {
+		name := c.State("name", func() any { return "" })
+		return textfield.Filled(name.Get().(string), func(s string) {
+			value = s
+			name.Set(s)
+		}, textfield.WithModifier(semantics.TestTag("name")))(c)
+	})
+
+	rule.OnNodeWithTag("name").PerformTextInput("Ada")
+	if value != "Ada" {
+		t.Errorf("value changed to %q, want %q", value, "Ada")
+	}
+}
+
+func TestComposeTestRuleScroll(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	rule.SetContent(lazy.LazyColumn(func(scope lazy.LazyListScope) {
+		scope.Items(100, func(index int) any { return index }, func(index int) compose.Composable {
+			return box.Box(
+				text.Text(fmt.Sprintf("Item %d", index)),
+				box.WithModifier(size.Size(100, 50)),
+			)
+		})
+	}, lazy.WithModifier(semantics.TestTag("list"))))
+
+	rule.OnNodeWithText("Item 0").AssertIsDisplayed()
+	rule.OnNodeWithText("Item 20").AssertDoesNotExist()
+
+	rule.OnNodeWithTag("list").PerformScroll(0, 1000)
+	rule.OnNodeWithText("Item 0").AssertDoesNotExist()
+	rule.OnNodeWithText("Item 20").AssertIsDisplayed()
+}
+
+func TestComposeTestRuleCaptureToImage(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t, composetest.WithSize(200, 120))
+	rule.SetContent(counter())
+	rule.OnNodeWithText("Increment").PerformClick()
+
+	golden.Assert(t, "counter", rule.CaptureToImage())
+}
+
+func TestComposeTestRuleMainClock(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	clock := rule.MainClock()
+	start := clock.Now()
+	rule.SetContent(counter())
+
+	clock.AdvanceTimeByFrame()
+	clock.AdvanceTimeBy(100 * time.Millisecond)
+	if got, want := clock.Now().Sub(start), composetest.FrameDuration+100*time.Millisecond; got < want {
+		t.Errorf("clock advanced by %v, want at least %v", got, want)
+	}
+}
+
+// delayed shows "waiting" until its effect has waited for a second on the
+// frame clock.
+func delayed() compose.Composable {
+	return func(c compose.Composer) compose.Composer {
+		status := c.State("status", func() any { return "waiting" })
+		return column.Column(c.Sequence(
+			effect.LaunchedEffect(func(ctx context.Context) {
+				if frameclock.Delay(ctx, time.Second) == nil {
+					status.Set("done")
+				}
+			}),
+			text.Text(status.Get().(string), text.WithModifier(semantics.TestTag("status"))),
+		))(c)
+	}
+}
+
+func TestComposeTestRuleAutoAdvance(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	start := rule.MainClock().Now()
+	rule.SetContent(delayed())
+
+	rule.OnNodeWithTag("status").AssertTextEquals("done")
+	if got := rule.MainClock().Now().Sub(start); got < time.Second || got > time.Second+composetest.FrameDuration {
+		t.Errorf("clock advanced by %v, want a second", got)
+	}
+}
+
+func TestComposeTestRuleWithoutAutoAdvance(t *testing.T) {
+	rule := composetest.NewComposeTestRule(t)
+	clock := rule.MainClock()
+	clock.SetAutoAdvance(false)
+	rule.SetContent(delayed())
+
+	rule.OnNodeWithTag("status").AssertTextEquals("waiting")
+	clock.AdvanceTimeBy(900 * time.Millisecond)
+	rule.WaitForIdle()
+	rule.OnNodeWithTag("status").AssertTextEquals("waiting")
+	clock.AdvanceTimeBy(100 * time.Millisecond)
+	rule.OnNodeWithTag("status").AssertTextEquals("done")
+}
@@ -0,0 +1,21 @@
+/*
+Package composetest runs composables in tests, without a window or a display,
+as the compose-ui-test library of Compose.
+
+A ComposeTestRule renders its content at a fixed size and on a test clock.
+Its nodes are found by test tag, text or content description, acted on with
+pointer and key events, and checked:
+
+	rule := composetest.NewComposeTestRule(t)
+	rule.SetContent(Counter())
+	rule.OnNodeWithText("Increment").PerformClick()
+	rule.OnNodeWithTag("count").AssertTextEquals("1")
+
+Test tags are set with the TestTag modifier of the semantics package.
+
+The test clock is the frame clock of the content, of the frameclock package:
+animations and effects that wait on it only run as it advances. WaitForIdle
+advances it until they are done, unless its auto advance is turned off for
+the test to step through them.
+*/
+package composetest
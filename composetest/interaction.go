@@ -0,0 +1,188 @@
+package composetest
+
+import (
+	"image"
+	"slices"
+	"strings"
+	"time"
+
+	"gioui.org/f32"
+	"gioui.org/io/key"
+	"gioui.org/io/pointer"
+)
+
+// SemanticsNodeInteraction acts on and checks the node matched by a
+// SemanticsMatcher. Its methods fail the test when the matcher does not match
+// exactly one node, or when their check fails; they return the interaction,
+// to be chained.
+type SemanticsNodeInteraction interface {
+	// FetchSemanticsNode returns the node, as of the last frame.
+	FetchSemanticsNode() SemanticsNode
+
+	AssertExists() SemanticsNodeInteraction
+	// AssertDoesNotExist checks that no node matches.
+	AssertDoesNotExist()
+	// AssertIsDisplayed checks that part of the node is within the window and
+	// the bounds of its ancestors.
+	AssertIsDisplayed() SemanticsNodeInteraction
+	AssertIsNotDisplayed() SemanticsNodeInteraction
+	// AssertTextEquals checks the texts of the node, in order.
+	AssertTextEquals(values ...string) SemanticsNodeInteraction
+	AssertIsEnabled() SemanticsNodeInteraction
+	AssertIsNotEnabled() SemanticsNodeInteraction
+
+	// PerformClick taps the center of the node.
+	PerformClick() SemanticsNodeInteraction
+	// PerformTextInput taps the node to focus it, and types text at its
+	// selection, as an input method does.
+	PerformTextInput(text string) SemanticsNodeInteraction
+	// PerformScroll scrolls the content under the center of the node by dx
+	// and dy pixels, as a mouse wheel does.
+	PerformScroll(dx, dy float32) SemanticsNodeInteraction
+}
+
+var _ SemanticsNodeInteraction = (*semanticsNodeInteraction)(nil)
+
+type semanticsNodeInteraction struct {
+	rule    *composeTestRule
+	matcher SemanticsMatcher
+}
+
+// fetch returns the node matched, failing the test unless there is exactly one.
+func (i *semanticsNodeInteraction) fetch() *SemanticsNode {
+	t := i.rule.t
+	t.Helper()
+	nodes := i.rule.find(i.matcher)
+	switch len(nodes) {
+	case 0:
+		t.Fatalf("no node matches (%s)", i.matcher.Description)
+	case 1:
+		return nodes[0]
+	default:
+		t.Fatalf("%d nodes match (%s), want 1", len(nodes), i.matcher.Description)
+	}
+	return nil
+}
+
+func (i *semanticsNodeInteraction) FetchSemanticsNode() SemanticsNode {
+	i.rule.t.Helper()
+	return *i.fetch()
+}
+
+func (i *semanticsNodeInteraction) AssertExists() SemanticsNodeInteraction {
+	i.rule.t.Helper()
+	i.fetch()
+	return i
+}
+
+func (i *semanticsNodeInteraction) AssertDoesNotExist() {
+	t := i.rule.t
+	t.Helper()
+	if nodes := i.rule.find(i.matcher); len(nodes) > 0 {
+		t.Fatalf("%d nodes match (%s), want none", len(nodes), i.matcher.Description)
+	}
+}
+
+func (i *semanticsNodeInteraction) AssertIsDisplayed() SemanticsNodeInteraction {
+	t := i.rule.t
+	t.Helper()
+	if bounds := i.visibleBounds(i.fetch()); bounds.Empty() {
+		t.Fatalf("node (%s) is not displayed", i.matcher.Description)
+	}
+	return i
+}
+
+func (i *semanticsNodeInteraction) AssertIsNotDisplayed() SemanticsNodeInteraction {
+	t := i.rule.t
+	t.Helper()
+	if bounds := i.visibleBounds(i.fetch()); !bounds.Empty() {
+		t.Fatalf("node (%s) is displayed at %v", i.matcher.Description, bounds)
+	}
+	return i
+}
+
+func (i *semanticsNodeInteraction) AssertTextEquals(values ...string) SemanticsNodeInteraction {
+	t := i.rule.t
+	t.Helper()
+	if node := i.fetch(); !slices.Equal(node.Text, values) {
+		t.Fatalf("node (%s) has text [%s], want [%s]", i.matcher.Description, strings.Join(node.Text, ", "), strings.Join(values, ", "))
+	}
+	return i
+}
+
+func (i *semanticsNodeInteraction) AssertIsEnabled() SemanticsNodeInteraction {
+	t := i.rule.t
+	t.Helper()
+	if !i.fetch().Enabled {
+		t.Fatalf("node (%s) is not enabled", i.matcher.Description)
+	}
+	return i
+}
+
+func (i *semanticsNodeInteraction) AssertIsNotEnabled() SemanticsNodeInteraction {
+	t := i.rule.t
+	t.Helper()
+	if i.fetch().Enabled {
+		t.Fatalf("node (%s) is enabled", i.matcher.Description)
+	}
+	return i
+}
+
+func (i *semanticsNodeInteraction) PerformClick() SemanticsNodeInteraction {
+	i.rule.t.Helper()
+	position := i.center()
+	i.rule.queue(
+		pointer.Event{Kind: pointer.Press, Source: pointer.Touch, Position: position, Time: i.eventTime()},
+		pointer.Event{Kind: pointer.Release, Source: pointer.Touch, Position: position, Time: i.eventTime()},
+	)
+	i.rule.frame()
+	i.rule.WaitForIdle()
+	return i
+}
+
+func (i *semanticsNodeInteraction) PerformTextInput(text string) SemanticsNodeInteraction {
+	i.rule.t.Helper()
+	i.PerformClick()
+	selection := i.rule.router.EditorState().Selection
+	i.rule.queue(key.EditEvent{Range: selection.Range, Text: text})
+	i.rule.frame()
+	i.rule.WaitForIdle()
+	return i
+}
+
+func (i *semanticsNodeInteraction) PerformScroll(dx, dy float32) SemanticsNodeInteraction {
+	i.rule.t.Helper()
+	i.rule.queue(pointer.Event{
+		Kind:     pointer.Scroll,
+		Source:   pointer.Mouse,
+		Position: i.center(),
+		Scroll:   f32.Pt(dx, dy),
+		Time:     i.eventTime(),
+	})
+	i.rule.frame()
+	i.rule.WaitForIdle()
+	return i
+}
+
+// visibleBounds returns the part of node within the window and the bounds of
+// its ancestors.
+func (i *semanticsNodeInteraction) visibleBounds(node *SemanticsNode) image.Rectangle {
+	return node.visible.Intersect(image.Rectangle{Max: i.rule.size()})
+}
+
+// center returns the center of the visible part of the node, failing the test
+// when it is not displayed.
+func (i *semanticsNodeInteraction) center() f32.Point {
+	t := i.rule.t
+	t.Helper()
+	bounds := i.visibleBounds(i.fetch())
+	if bounds.Empty() {
+		t.Fatalf("node (%s) is not displayed", i.matcher.Description)
+	}
+	return f32.Pt(float32(bounds.Min.X+bounds.Max.X)/2, float32(bounds.Min.Y+bounds.Max.Y)/2)
+}
+
+// eventTime returns the time of the clock, as the time of an input event.
+func (i *semanticsNodeInteraction) eventTime() time.Duration {
+	return i.rule.clock.Now().Sub(i.rule.opts.StartTime)
+}
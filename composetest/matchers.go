@@ -0,0 +1,66 @@
+package composetest
+
+import (
+	"fmt"
+	"slices"
+)
+
+// SemanticsMatcher selects semantics nodes, for a ComposeTestRule to find.
+type SemanticsMatcher struct {
+	Description string
+	matches     func(node SemanticsNode) bool
+}
+
+// NewSemanticsMatcher returns a matcher of the nodes for which matches returns
+// true, described by description in failures.
+func NewSemanticsMatcher(description string, matches func(node SemanticsNode) bool) SemanticsMatcher {
+	return SemanticsMatcher{Description: description, matches: matches}
+}
+
+func (m SemanticsMatcher) Matches(node SemanticsNode) bool {
+	return m.matches(node)
+}
+
+// And matches the nodes matched by both m and other.
+func (m SemanticsMatcher) And(other SemanticsMatcher) SemanticsMatcher {
+	return NewSemanticsMatcher(m.Description+" && "+other.Description, func(node SemanticsNode) bool {
+		return m.matches(node) && other.matches(node)
+	})
+}
+
+func HasTestTag(tag string) SemanticsMatcher {
+	return NewSemanticsMatcher(fmt.Sprintf("TestTag = %q", tag), func(node SemanticsNode) bool {
+		return node.TestTag == tag
+	})
+}
+
+// HasText matches the nodes that show text, as one of their labels.
+func HasText(text string) SemanticsMatcher {
+	return NewSemanticsMatcher(fmt.Sprintf("Text contains %q", text), func(node SemanticsNode) bool {
+		return slices.Contains(node.Text, text)
+	})
+}
+
+func HasContentDescription(description string) SemanticsMatcher {
+	return NewSemanticsMatcher(fmt.Sprintf("ContentDescription = %q", description), func(node SemanticsNode) bool {
+		return node.ContentDescription == description
+	})
+}
+
+func HasClickAction() SemanticsMatcher {
+	return NewSemanticsMatcher("has click action", func(node SemanticsNode) bool {
+		return node.Clickable
+	})
+}
+
+func IsEnabled() SemanticsMatcher {
+	return NewSemanticsMatcher("is enabled", func(node SemanticsNode) bool {
+		return node.Enabled
+	})
+}
+
+func IsNotEnabled() SemanticsMatcher {
+	return NewSemanticsMatcher("is not enabled", func(node SemanticsNode) bool {
+		return !node.Enabled
+	})
+}
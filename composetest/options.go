@@ -0,0 +1,56 @@
+package composetest
+
+import (
+	"time"
+
+	"gioui.org/io/system"
+	"gioui.org/unit"
+)
+
+// ComposeTestRuleOption is a functional option for configuring a ComposeTestRule.
+type ComposeTestRuleOption func(*ComposeTestRuleOptions)
+
+type ComposeTestRuleOptions struct {
+	// Width and Height are the size of the window the content is laid out in,
+	// in pixels.
+	Width, Height int
+	Metric        unit.Metric
+	Locale        system.Locale
+	// StartTime is the time of the test clock when the content is set.
+	StartTime time.Time
+}
+
+func DefaultComposeTestRuleOptions() ComposeTestRuleOptions {
+	return ComposeTestRuleOptions{
+		Width:     400,
+		Height:    700,
+		Metric:    unit.Metric{PxPerDp: 1, PxPerSp: 1},
+		Locale:    system.Locale{Language: "en", Direction: system.LTR},
+		StartTime: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func WithSize(width, height int) ComposeTestRuleOption {
+	return func(o *ComposeTestRuleOptions) {
+		o.Width = width
+		o.Height = height
+	}
+}
+
+func WithMetric(metric unit.Metric) ComposeTestRuleOption {
+	return func(o *ComposeTestRuleOptions) {
+		o.Metric = metric
+	}
+}
+
+func WithLocale(locale system.Locale) ComposeTestRuleOption {
+	return func(o *ComposeTestRuleOptions) {
+		o.Locale = locale
+	}
+}
+
+func WithStartTime(startTime time.Time) ComposeTestRuleOption {
+	return func(o *ComposeTestRuleOptions) {
+		o.StartTime = startTime
+	}
+}
@@ -0,0 +1,252 @@
+package composetest
+
+import (
+	"image"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/internal/frame"
+	"github.com/zodimo/go-compose/modifiers/semantics"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/screenshot"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+	"github.com/zodimo/go-compose/theme"
+
+	"gioui.org/io/event"
+	"gioui.org/io/input"
+	"gioui.org/layout"
+	"gioui.org/op"
+)
+
+const (
+	// maxIdleFrames is the number of frames WaitForIdle renders without
+	// advancing the clock before failing, when the state of the content keeps
+	// changing.
+	maxIdleFrames = 100
+	// maxIdleTime is the time WaitForIdle advances the clock by before
+	// failing, when animations or effects keep waiting on it.
+	maxIdleTime = 10 * time.Second
+	// effectTimeout is how long WaitForIdle waits for running effects, in the
+	// time of the system.
+	effectTimeout = 5 * time.Second
+)
+
+// ComposeTestRule hosts a composable in a window of a fixed size without a
+// display, for tests to find its elements, act on them and check them, as
+// the ComposeTestRule of Compose:
+//
+//	func TestCounter(t *testing.T) {
+//		rule := composetest.NewComposeTestRule(t)
+//		rule.SetContent(Counter())
+//		rule.OnNodeWithText("Increment").PerformClick()
+//		rule.OnNodeWithTag("count").AssertTextEquals("1")
+//	}
+//
+// Frames are rendered when the content is set, after each action and as the
+// test clock advances. Elements are found in the semantics of the last frame;
+// test tags are part of it.
+type ComposeTestRule interface {
+	// SetContent composes content and renders it, until it is idle.
+	SetContent(content api.Composable)
+
+	// OnNode returns the node matched by matcher. The node is found when the
+	// interaction is used, and must be the only one matched.
+	OnNode(matcher SemanticsMatcher) SemanticsNodeInteraction
+	OnNodeWithTag(tag string) SemanticsNodeInteraction
+	OnNodeWithText(text string) SemanticsNodeInteraction
+	OnNodeWithContentDescription(description string) SemanticsNodeInteraction
+	// OnAllNodes returns the nodes of the last frame matched by matcher.
+	OnAllNodes(matcher SemanticsMatcher) []SemanticsNode
+
+	// MainClock returns the clock of the frames.
+	MainClock() TestClock
+	// WaitForIdle renders frames until the content is idle: the state it reads
+	// stops changing, and no effect runs. With the auto advance of the clock,
+	// the clock also advances until no animation or effect waits on it.
+	WaitForIdle()
+	// CaptureToImage draws the last frame on the CPU, as screenshot.Render.
+	CaptureToImage() *image.RGBA
+}
+
+var _ ComposeTestRule = (*composeTestRule)(nil)
+
+type composeTestRule struct {
+	t       testing.TB
+	opts    ComposeTestRuleOptions
+	clock   *testClock
+	store   state.PersistentState
+	runtime frame.Runtime
+	router  input.Router
+	ops     op.Ops
+	content api.Composable
+	// drawing is the drawing of the last frame.
+	drawing op.CallOp
+	// dirty is set when state read by the content changes, from any goroutine.
+	dirty atomic.Bool
+	tree  *SemanticsNode
+}
+
+// NewComposeTestRule returns a rule whose composition is disposed at the end
+// of the test.
+func NewComposeTestRule(t testing.TB, options ...ComposeTestRuleOption) ComposeTestRule {
+	opts := DefaultComposeTestRuleOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+
+	r := &composeTestRule{
+		t:       t,
+		opts:    opts,
+		store:   store.NewPersistentState(map[string]state.MutableValue{}),
+		runtime: frame.NewRuntime(),
+	}
+	r.clock = newTestClock(r, opts.StartTime)
+	r.store.SetOnStateChange(func() { r.dirty.Store(true) })
+	t.Cleanup(func() {
+		r.store.SetOnStateChange(nil)
+		r.runtime.Dispose()
+		compose.DisposeComposition(r.store)
+		r.store.Observer().Stop()
+	})
+	return r
+}
+
+func (r *composeTestRule) SetContent(content api.Composable) {
+	r.t.Helper()
+	r.content = compose.CompositionLocalProvider1(frameclock.LocalFrameClock, frameclock.MonotonicFrameClock(r.clock.frames), content)
+	r.frame()
+	r.WaitForIdle()
+}
+
+func (r *composeTestRule) OnNode(matcher SemanticsMatcher) SemanticsNodeInteraction {
+	return &semanticsNodeInteraction{rule: r, matcher: matcher}
+}
+
+func (r *composeTestRule) OnNodeWithTag(tag string) SemanticsNodeInteraction {
+	return r.OnNode(HasTestTag(tag))
+}
+
+func (r *composeTestRule) OnNodeWithText(text string) SemanticsNodeInteraction {
+	return r.OnNode(HasText(text))
+}
+
+func (r *composeTestRule) OnNodeWithContentDescription(description string) SemanticsNodeInteraction {
+	return r.OnNode(HasContentDescription(description))
+}
+
+func (r *composeTestRule) OnAllNodes(matcher SemanticsMatcher) []SemanticsNode {
+	var nodes []SemanticsNode
+	for _, node := range r.find(matcher) {
+		nodes = append(nodes, *node)
+	}
+	return nodes
+}
+
+func (r *composeTestRule) find(matcher SemanticsMatcher) []*SemanticsNode {
+	if r.tree == nil {
+		return nil
+	}
+	return r.tree.find(matcher, nil)
+}
+
+func (r *composeTestRule) MainClock() TestClock {
+	return r.clock
+}
+
+func (r *composeTestRule) WaitForIdle() {
+	r.t.Helper()
+	end := r.clock.Now().Add(maxIdleTime)
+	for {
+		r.settle()
+		if !r.clock.autoAdvance || !r.clock.frames.Pending() {
+			return
+		}
+		if !r.clock.Now().Before(end) {
+			r.t.Fatalf("animations or effects still waiting on the clock after %v; use SetAutoAdvance(false) for those that do not end", maxIdleTime)
+		}
+		r.clock.advance(FrameDuration)
+	}
+}
+
+// settle waits for the effects, and renders frames at the time of the clock
+// until the state read by the content stops changing.
+func (r *composeTestRule) settle() {
+	r.t.Helper()
+	for range maxIdleFrames {
+		r.awaitEffects()
+		if !r.dirty.Swap(false) {
+			return
+		}
+		r.frame()
+	}
+	r.t.Fatalf("content still changing after %d frames", maxIdleFrames)
+}
+
+// awaitEffects waits until no effect runs, as they all wait on the clock or
+// have returned.
+func (r *composeTestRule) awaitEffects() {
+	r.t.Helper()
+	timer := time.NewTimer(effectTimeout)
+	defer timer.Stop()
+	select {
+	case <-r.clock.frames.Idle():
+	case <-timer.C:
+		r.t.Fatalf("effects still running after %v; effects that wait on something else than the clock should do it in frameclock.Await", effectTimeout)
+	}
+}
+
+// frame composes the content and renders it at the time of the clock, with
+// the events queued since the last frame.
+func (r *composeTestRule) frame() {
+	if r.content == nil {
+		r.t.Fatal("no content: SetContent must be called first")
+	}
+	r.ops.Reset()
+	gtx := layout.Context{
+		Ops:         &r.ops,
+		Metric:      r.opts.Metric,
+		Constraints: layout.Exact(r.size()),
+		Now:         r.clock.Now(),
+		Locale:      r.opts.Locale,
+		Source:      r.router.Source(),
+	}
+	gtx = theme.GetThemeManager().Material3ThemeInit(gtx)
+	gtx = semantics.ExposeTestTags(gtx)
+	gtx = frameclock.Provide(gtx, r.clock.frames)
+
+	layoutNode := compose.Compose(r.store, r.content)
+	r.drawing = r.runtime.Run(gtx, layoutNode)
+	r.drawing.Add(gtx.Ops)
+	r.router.Frame(&r.ops)
+
+	if nodes := r.router.AppendSemantics(nil); len(nodes) > 0 {
+		r.tree = newSemanticsTree(nodes[0])
+	} else {
+		r.tree = nil
+	}
+}
+
+func (r *composeTestRule) CaptureToImage() *image.RGBA {
+	r.t.Helper()
+	if r.content == nil {
+		r.t.Fatal("no content: SetContent must be called first")
+	}
+	size := r.size()
+	return screenshot.Render(size.X, size.Y, r.drawing)
+}
+
+// queue queues events for the next frame.
+func (r *composeTestRule) queue(events ...event.Event) {
+	r.router.Queue(events...)
+}
+
+func (r *composeTestRule) size() image.Point {
+	return image.Pt(r.opts.Width, r.opts.Height)
+}
@@ -0,0 +1,123 @@
+package composetest
+
+import (
+	"image"
+
+	"github.com/zodimo/go-compose/modifiers/semantics"
+
+	"gioui.org/io/input"
+	"gioui.org/io/semantic"
+)
+
+// SemanticsNode is an element of the content as seen by accessibility services:
+// its bounds in the window and what it shows and does.
+//
+// The nodes are those of the semantics of a frame, merged like the merged
+// semantics tree of Compose: the nodes of the same element, which have the
+// same bounds, are merged into one, and a clickable element merges the text
+// and descriptions of its content.
+type SemanticsNode struct {
+	Bounds             image.Rectangle
+	TestTag            string
+	ContentDescription string
+	// Text holds the labels of the node, such as the texts it shows.
+	Text       []string
+	Class      semantic.ClassOp
+	Clickable  bool
+	Scrollable bool
+	Enabled    bool
+	Selected   bool
+	Children   []*SemanticsNode
+
+	// visible is the part of Bounds within the bounds of the ancestors of the
+	// node, which include the window.
+	visible image.Rectangle
+}
+
+// newSemanticsTree returns the root of the merged semantics of root, a node
+// of the semantics of a frame. Nodes found twice, because the frame holds the
+// operations of both the layout and the drawing of the content, are skipped.
+func newSemanticsTree(root input.SemanticNode) *SemanticsNode {
+	seen := map[input.SemanticDesc]bool{}
+	var convert func(n input.SemanticNode, clip image.Rectangle) *SemanticsNode
+	convert = func(n input.SemanticNode, clip image.Rectangle) *SemanticsNode {
+		desc := n.Desc
+		node := &SemanticsNode{
+			Bounds:             desc.Bounds,
+			visible:            desc.Bounds.Intersect(clip),
+			ContentDescription: desc.Description,
+			Class:              desc.Class,
+			Clickable:          desc.Gestures&input.ClickGesture != 0,
+			Scrollable:         desc.Gestures&input.ScrollGesture != 0,
+			Enabled:            !desc.Disabled,
+			Selected:           desc.Selected,
+		}
+		if tag, ok := semantics.TestTagOf(desc.Description); ok {
+			node.TestTag, node.ContentDescription = tag, ""
+		}
+		if desc.Label != "" {
+			node.Text = []string{desc.Label}
+		}
+		for _, child := range n.Children {
+			if seen[child.Desc] {
+				continue
+			}
+			seen[child.Desc] = true
+			node.Children = append(node.Children, convert(child, node.visible))
+		}
+		return node
+	}
+	tree := convert(root, root.Desc.Bounds)
+	tree.merge()
+	return tree
+}
+
+// merge merges into n the children of the same element as n, which cover the
+// same part of the window, or all of them when n merges its descendants.
+func (n *SemanticsNode) merge() {
+	children := n.Children
+	n.Children = nil
+	for _, child := range children {
+		child.merge()
+		sameElement := child.visible == n.visible
+		if !sameElement && !n.mergesDescendants() {
+			n.Children = append(n.Children, child)
+			continue
+		}
+		n.Text = append(n.Text, child.Text...)
+		if n.ContentDescription == "" {
+			n.ContentDescription = child.ContentDescription
+		}
+		if n.TestTag == "" {
+			n.TestTag = child.TestTag
+		}
+		if sameElement {
+			if n.Class == semantic.Unknown {
+				n.Class = child.Class
+			}
+			n.Clickable = n.Clickable || child.Clickable
+			n.Scrollable = n.Scrollable || child.Scrollable
+			n.Enabled = n.Enabled && child.Enabled
+			n.Selected = n.Selected || child.Selected
+		}
+		for _, grandchild := range child.Children {
+			n.Children = append(n.Children, grandchild)
+		}
+	}
+}
+
+// mergesDescendants reports whether n is a control, whose content describes it.
+func (n *SemanticsNode) mergesDescendants() bool {
+	return n.Clickable || n.Class != semantic.Unknown
+}
+
+// find appends the nodes of the tree of n that match to nodes.
+func (n *SemanticsNode) find(matcher SemanticsMatcher, nodes []*SemanticsNode) []*SemanticsNode {
+	if matcher.Matches(*n) {
+		nodes = append(nodes, n)
+	}
+	for _, child := range n.Children {
+		nodes = child.find(matcher, nodes)
+	}
+	return nodes
+}
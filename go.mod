@@ -5,7 +5,7 @@ go 1.24.4
 toolchain go1.24.11
 
 require (
-	gioui.org v0.9.0
+	gioui.org v0.9.0 // upgrades require updating the operation reader of screenshot/ops.go
 	git.sr.ht/~schnwalter/gio-mw v0.0.0-20250713180710-9d8d98474447
 	github.com/go-text/typesetting v0.3.2
 	github.com/zodimo/go-maybe v0.1.3
@@ -52,7 +52,9 @@ func (cn *chainNode) Attach(node TreeNode) {
 
 // Detach is called when the node is detached from a node tree
 func (cn *chainNode) Detach() {
-	cn.onDetach()
+	if cn.onDetach != nil {
+		cn.onDetach()
+	}
 }
 
 // Next returns the next node in the chain
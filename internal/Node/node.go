@@ -69,7 +69,8 @@ const (
 	LayoutPhase Phases = 1 << iota
 	DrawPhase
 	PointerInputPhase
-	// SemanticsPhase, ParentDataPhase, etc.
+	SemanticsPhase
+	// ParentDataPhase, etc.
 )
 
 type Node interface {
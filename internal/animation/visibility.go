@@ -4,8 +4,9 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/zodimo/go-compose/compose/frameclock"
+
 	"gioui.org/layout"
-	"gioui.org/op"
 )
 
 // VisibilityAnimation holds the animation state for animations that transition between a
@@ -25,17 +26,22 @@ type VisibilityAnimation struct {
 // If the animation reaches its end this frame, Revealed will transition it to a non-animating
 // state automatically.
 //
-// If the animation is in the process of animating, calling Revealed will automatically add
-// an InvalidateOp to the provided layout.Context to ensure that the next frame will be generated
-// promptly.
+// If the animation is in the process of animating, calling Revealed will automatically request
+// a frame from the frame clock of the provided layout.Context to ensure that the next frame will
+// be generated promptly. The time of the animation is that of the frame clock.
 func (v *VisibilityAnimation) Revealed(gtx layout.Context) float32 {
+	clock := frameclock.Current(gtx)
 	if v.Animating() {
-		gtx.Execute(op.InvalidateCmd{})
+		clock.RequestFrame()
 	}
 	if v.Duration == time.Duration(0) {
 		v.Duration = time.Second
 	}
-	progress := float32(gtx.Now.Sub(v.Started).Milliseconds()) / float32(v.Duration.Milliseconds())
+	now := clock.Now()
+	if v.Animating() && v.Started.IsZero() {
+		v.Started = now
+	}
+	progress := float32(now.Sub(v.Started).Milliseconds()) / float32(v.Duration.Milliseconds())
 	if progress >= 1 {
 		if v.State == Appearing {
 			v.State = Visible
@@ -68,8 +74,9 @@ func (v VisibilityAnimation) Animating() bool {
 	return v.State == Appearing || v.State == Disappearing
 }
 
-// Appear triggers the animation to begin becoming visible at the provided time. It is
-// a no-op if the animation is already visible.
+// Appear triggers the animation to begin becoming visible at the provided time, usually
+// that of the frame clock. The zero time begins it at the next frame it is revealed in.
+// It is a no-op if the animation is already visible.
 func (v *VisibilityAnimation) Appear(now time.Time) {
 	if !v.Visible() && !v.Animating() {
 		v.State = Appearing
@@ -77,8 +84,8 @@ func (v *VisibilityAnimation) Appear(now time.Time) {
 	}
 }
 
-// Disappear triggers the animation to begin becoming invisible at the provided time.
-// It is a no-op if the animation is already invisible.
+// Disappear triggers the animation to begin becoming invisible at the provided time, as
+// Appear. It is a no-op if the animation is already invisible.
 func (v *VisibilityAnimation) Disappear(now time.Time) {
 	if v.Visible() && !v.Animating() {
 		v.State = Disappearing
@@ -16,6 +16,15 @@ type Memo = state.MemoTyped[any]
 type ElementMemo = state.MemoTyped[Element]
 
 type PersistentState = state.PersistentState
+type StateObserver = state.StateObserver
+type RememberObserver = state.RememberObserver
+type Saver[T any] = state.Saver[T]
+type MutableSnapshot = state.MutableSnapshot
+
+var SendApplyNotifications = state.SendApplyNotifications
+var IsGlobalKey = state.IsGlobalKey
+var TakeMutableSnapshot = state.TakeMutableSnapshot
+var EnterSnapshot = state.EnterSnapshot
 
 var EmptyMemo = state.EmptyMemo[any]()
 var EmptyElementMemo = state.EmptyMemo[Element]()
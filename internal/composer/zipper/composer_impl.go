@@ -9,6 +9,9 @@ import (
 
 var _ Composer = (*composer)(nil)
 
+// keyGroupKey is the group key of the virtual groups opened by Key.
+const keyGroupKey = "Key"
+
 type pathItem struct {
 	parent LayoutNode   // the parent node
 	before []LayoutNode // children left of the focus (in order)
@@ -18,16 +21,31 @@ type pathItem struct {
 type composer struct {
 	focus          LayoutNode // group we are currently inside
 	path           []pathItem // how to climb back to root
-	memo           Memo       // remember cache for this composition run
+	table          *slotTable // groups of the previous composition, updated in place
+	group          *group     // slot group we are currently inside
 	state          PersistentState
+	observer       *StateObserver
 	idManager      IdentityManager
+	idCount        int         // IDs generated so far in this composition
 	overrideID     *Identifier // single override ID for c.Key (one Key affects one component)
 	locals         map[interface{}]interface{}
 	providersStack []map[interface{}]interface{}
+
+	// snapshot isolates the composition from writes made meanwhile by other
+	// goroutines; its own writes are applied in Build.
+	snapshot      MutableSnapshot
+	leaveSnapshot func()
+
+	// applied in Build, once the composition is complete
+	rememberedObservers []RememberObserver
+	forgottenObservers  []RememberObserver
+	sideEffects         []func()
 }
 
 // Tree Builder operations
 func (c *composer) StartBlock(key string) Composer {
+	g := c.startGroup(key, "", true)
+	c.observer.Clear(g)
 
 	newNode := layoutnode.NewLayoutNode(c.GenerateID(), key, EmptyMemo, EmptyMemo, c.state)
 
@@ -50,7 +68,11 @@ func (c *composer) StartBlock(key string) Composer {
 }
 
 func (c *composer) EndBlock() Composer {
-	return c.up()
+	c.up()
+	if c.group != c.table.root {
+		c.endGroup()
+	}
+	return c
 }
 
 // Root climbs the zipper to the top and returns the finished tree.
@@ -59,6 +81,12 @@ func (c *composer) Build() LayoutNode {
 	for len(c.path) > 0 {
 		c.up()
 	}
+	for c.group != nil {
+		c.endGroup()
+	}
+	c.observer.StopObserving()
+	c.applySnapshot()
+	c.applyEffects()
 	if c.focus == nil {
 		panic("No root layout node found")
 	}
@@ -98,8 +126,53 @@ func (c *composer) GenerateID() Identifier {
 		c.overrideID = nil
 		return id
 	}
+	c.idCount++
 	return c.idManager.GenerateID()
 }
+
+// enterSnapshot composes in a snapshot of its own: the composition reads the
+// state as of its start, however effects change it in the meantime.
+func (c *composer) enterSnapshot() {
+	c.snapshot = TakeMutableSnapshot()
+	c.leaveSnapshot = EnterSnapshot(c.snapshot)
+}
+
+// applySnapshot leaves the snapshot of the composition and applies the writes
+// made while composing. When an effect changed the same state in the meantime,
+// the writes are dropped and the composition is invalidated, to be composed
+// again on the next frame.
+func (c *composer) applySnapshot() {
+	if c.snapshot == nil {
+		return
+	}
+	c.leaveSnapshot()
+	if err := c.snapshot.Apply(); err != nil {
+		c.snapshot.Dispose()
+		c.observer.Invalidate(c.table.root)
+	}
+	c.snapshot, c.leaveSnapshot = nil, nil
+}
+
+// abandon leaves and disposes the snapshot of a composition that did not reach
+// Build, as when a composable panicked: its writes are dropped and the
+// composition is invalidated, to be composed again on the next frame. It does
+// nothing once Build applied the snapshot.
+func (c *composer) abandon() {
+	if c.snapshot == nil {
+		return
+	}
+	c.observer.StopObserving()
+	c.leaveSnapshot()
+	c.snapshot.Dispose()
+	c.observer.Invalidate(c.table.root)
+	c.snapshot, c.leaveSnapshot = nil, nil
+}
+
+// currentScope is the scope state reads are attributed to: the innermost group.
+func (c *composer) currentScope() any {
+	return c.group
+}
+
 func (c *composer) GetID() Identifier {
 	return c.focus.GetID()
 }
@@ -121,21 +194,91 @@ func (c *composer) ModifierThen(modifier Modifier) Composer {
 	return c
 }
 
-// Remember caches a value for the current composition run.
-// The cache lives in Composer.memo and is discarded on recompose.
+// Remember caches a value in the slot of the current group.
+// The value survives recomposition for as long as the group stays in the
+// composition; key only needs to be unique among the Remember calls of the group,
+// and repeated calls with the same key get their own slot in call order.
 func (c *composer) Remember(key string, calc func() any) any {
-	if v, ok := c.memo.Find(key); ok {
+	g := c.group
+	slot := g.rememberSlotKey(key)
+	if v, ok := g.prevRemembered[slot]; ok {
+		g.remember(slot, v)
 		return v
 	}
 	v := calc()
-	c.memo = c.memo.Assoc(key, v)
+	g.remember(slot, v)
+	if observer, ok := v.(RememberObserver); ok {
+		c.rememberedObservers = append(c.rememberedObservers, observer)
+	}
 	return v
 }
 
+// SideEffect schedules effect to run once the current composition has been
+// applied, after the RememberObserver callbacks, in the order of the calls.
+func (c *composer) SideEffect(effect func()) {
+	c.sideEffects = append(c.sideEffects, effect)
+}
+
+// applyEffects runs the lifecycle callbacks collected while composing:
+// forgotten values in reverse order, then remembered values and side effects in order.
+func (c *composer) applyEffects() {
+	forgotten, remembered, sideEffects := c.forgottenObservers, c.rememberedObservers, c.sideEffects
+	c.forgottenObservers, c.rememberedObservers, c.sideEffects = nil, nil, nil
+
+	for i := len(forgotten) - 1; i >= 0; i-- {
+		forgotten[i].OnForgotten()
+	}
+	for _, observer := range remembered {
+		observer.OnRemembered()
+	}
+	for _, effect := range sideEffects {
+		effect()
+	}
+}
+
+func (c *composer) forget(values []any) {
+	for _, v := range values {
+		if observer, ok := v.(RememberObserver); ok {
+			c.forgottenObservers = append(c.forgottenObservers, observer)
+		}
+	}
+}
+
+// Changed records value in the next slot of the current group and reports whether
+// it differs from the value recorded at the same position in the previous frame.
+func (c *composer) Changed(value any) bool {
+	g := c.group
+	i := len(g.changed)
+	g.changed = append(g.changed, value)
+	if i >= len(g.prevChanged) {
+		return true
+	}
+	return !equalInput(g.prevChanged[i], value)
+}
+
 // State creates a MutableValue from the persistent state.
-// In a real runtime this would be a Snapshot with observers.
+// Reads of the value during composition are recorded against the current group.
+//
+// The key is scoped to the current group, so equal keys in different places of
+// the composition, or repeated in one group, address different values. The value
+// is removed once the group leaves the composition or stops asking for it.
+// Keys made with state.GlobalKey, and calls made outside of a composition, address
+// the persistent state directly.
 func (c *composer) State(key string, initial func() any) MutableValue {
-	return c.state.GetState(key, initial)
+	return c.state.GetState(c.stateKey(key), initial)
+}
+
+// SaveableState is State for a value that is also kept by the SaveableStateRegistry
+// of the persistent state. Its scoped key doubles as the key in the registry.
+func (c *composer) SaveableState(key string, initial func() any, saver Saver[any]) MutableValue {
+	return c.state.GetSaveableState(c.stateKey(key), initial, saver)
+}
+
+func (c *composer) stateKey(key string) string {
+	if c.group == nil || IsGlobalKey(key) {
+		return key
+	}
+	return c.group.scopedStateKey(key)
 }
 
 func (c *composer) WithComposable(composable Composable) Composer {
@@ -185,8 +328,37 @@ func (c *composer) Key(key any, content Composable) Composable {
 		identity := composerImpl.idManager.CreateID(stringKey)
 		// Set the override ID - will be consumed by the next GenerateID call
 		composerImpl.overrideID = &identity
-		// Compose content directly - no wrapper node
-		return content(comp)
+		// Compose content in a keyed group - no wrapper node
+		g := composerImpl.startGroup(keyGroupKey, stringKey, false)
+		composerImpl.observer.Clear(g)
+		comp = content(comp)
+		composerImpl.endGroup()
+		return comp
+	}
+}
+
+// Skippable composes content in its own group, unless none of inputs changed,
+// the group read no state that changed and the composition locals are the same
+// as in the previous frame. In that case the nodes content emitted last frame
+// are reused as they are.
+func (c *composer) Skippable(key string, content Composable, inputs ...any) Composable {
+	return func(comp Composer) Composer {
+		composerImpl := comp.(*composer)
+		g := composerImpl.startGroup(key, "", false)
+		changed := false
+		for _, input := range inputs {
+			if composerImpl.Changed(input) {
+				changed = true
+			}
+		}
+		if !changed && composerImpl.canSkip(g) {
+			composerImpl.skipGroup(g)
+		} else {
+			composerImpl.observer.Clear(g)
+			comp = content(comp)
+		}
+		composerImpl.endGroup()
+		return comp
 	}
 }
 
@@ -225,6 +397,99 @@ func (c *composer) Consume(key interface{}) interface{} {
 	return c.locals[key]
 }
 
+// startGroup opens the child group of the current group matching key, reusing
+// the group of the previous frame when there is one.
+func (c *composer) startGroup(key string, explicitKey string, isNode bool) *group {
+	parent := c.group
+	slotKey := parent.childSlotKey(key, explicitKey)
+	g := parent.findPrevChild(slotKey)
+	if g == nil {
+		g = newGroup(parent, key, slotKey, isNode)
+	}
+	parent.matched[g] = struct{}{}
+	parent.children = append(parent.children, g)
+
+	g.begin()
+	g.startLocals = c.locals
+	g.idStart = c.idCount
+	g.emitStart = -1
+	if c.focus != nil {
+		g.emitStart = len(c.focus.LayoutNodeChildren())
+	}
+	c.group = g
+	return g
+}
+
+// endGroup closes the current group and removes the groups of the previous
+// frame that were not composed again.
+func (c *composer) endGroup() {
+	g := c.group
+	for _, child := range g.prevChildren {
+		if _, ok := g.matched[child]; !ok {
+			c.disposeGroup(child)
+		}
+	}
+	for _, key := range g.staleStateKeys() {
+		c.state.RemoveState(key)
+	}
+	c.forget(g.droppedRemembered())
+	if !g.isNode && g.emitStart >= 0 && c.focus != nil {
+		children := c.focus.LayoutNodeChildren()
+		if g.emitStart <= len(children) {
+			g.emitted = append([]LayoutNode{}, children[g.emitStart:]...)
+		}
+	}
+	g.idCount = c.idCount - g.idStart
+	g.locals = g.startLocals
+	g.composed = g.emitStart >= 0 || g.isNode
+
+	g.prevChildren = nil
+	g.prevRemembered = nil
+	g.prevRememberOrder = nil
+	g.prevStateKeys = nil
+	g.prevChanged = nil
+	g.prevEmitted = nil
+	g.matched = nil
+	c.group = g.parent
+}
+
+// disposeGroup forgets everything recorded for a group that left the composition,
+// including the state it created and the values it remembered.
+func (c *composer) disposeGroup(g *group) {
+	g.walk(func(removed *group) {
+		c.observer.Clear(removed)
+		c.forget(removed.rememberedValues())
+		for _, key := range removed.stateKeys {
+			c.state.RemoveState(key)
+		}
+	})
+}
+
+func (c *composer) canSkip(g *group) bool {
+	if !g.composed || g.emitStart < 0 || !equalLocals(g.locals, c.locals) {
+		return false
+	}
+	invalid := c.observer.IsInvalid(g)
+	for _, child := range g.prevChildren {
+		child.walk(func(inner *group) {
+			invalid = invalid || c.observer.IsInvalid(inner)
+		})
+	}
+	return !invalid
+}
+
+// skipGroup reuses the previous frame of g: its slots, its child groups and the
+// layout nodes it emitted. The IDs its content generated are consumed again so
+// the IDs of the following siblings stay stable.
+func (c *composer) skipGroup(g *group) {
+	g.restorePrevious()
+	children := append([]LayoutNode{}, c.focus.LayoutNodeChildren()...)
+	c.focus.WithChildren(append(children, g.prevEmitted...))
+	for i := 0; i < g.prevIDCount; i++ {
+		c.GenerateID()
+	}
+}
+
 func emptyComposable() Composable {
 	return func(c Composer) Composer {
 		return c
@@ -5,13 +5,54 @@ func NewComposer(state PersistentState) Composer {
 	idManager := GetScopedIdentityManager("composer")
 	idManager.ResetKeyCounter()
 
-	return &composer{
+	// A new composer marks a frame boundary: publish the writes made since the
+	// previous frame so the observer can invalidate the scopes that read them.
+	SendApplyNotifications()
+	observer := state.Observer()
+
+	// The slot table is read before observation starts so the composition does
+	// not depend on it.
+	table := state.GetState(slotTableStateKey, func() any { return newSlotTable() }).Get().(*slotTable)
+	table.root.begin()
+	table.root.emitStart = -1
+
+	c := &composer{
 		focus:          nil,
 		path:           []pathItem{},
-		memo:           EmptyMemo,
+		table:          table,
+		group:          table.root,
 		state:          state,
+		observer:       observer,
 		idManager:      idManager,
 		locals:         make(map[interface{}]interface{}),
 		providersStack: []map[interface{}]interface{}{},
 	}
+	observer.Clear(table.root)
+	observer.Observe(c.currentScope)
+	c.enterSnapshot()
+	return c
+}
+
+// Compose composes content as a frame of the composition kept by state and
+// returns the root of the nodes it emitted. The snapshot of the composition is
+// released even when content panics, rather than staying entered.
+func Compose(state PersistentState, content Composable) LayoutNode {
+	c := NewComposer(state).(*composer)
+	defer c.abandon()
+	return content(c).Build()
+}
+
+// DisposeComposition removes the composition kept by state, as when its window
+// is closed: every remembered value is forgotten, which cancels the effects, and
+// the state of every group is removed. State made with a GlobalKey is kept.
+func DisposeComposition(state PersistentState) {
+	table := state.GetState(slotTableStateKey, func() any { return newSlotTable() }).Get().(*slotTable)
+	c := &composer{
+		table:    table,
+		state:    state,
+		observer: state.Observer(),
+	}
+	c.disposeGroup(table.root)
+	state.RemoveState(slotTableStateKey)
+	c.applyEffects()
 }
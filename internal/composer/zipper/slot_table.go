@@ -0,0 +1,242 @@
+package zipper
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// slotTableStateKey is where a PersistentState keeps the composition of the
+// previous frame.
+const slotTableStateKey = "__zipper_slot_table__"
+
+// slotTable keeps the group tree of the last completed composition so that the
+// next frame can match its groups by position or key.
+type slotTable struct {
+	root *group
+}
+
+func newSlotTable() *slotTable {
+	return &slotTable{root: newGroup(nil, "root", "root", false)}
+}
+
+// group is the unit of positional memoization.
+//
+// StartBlock opens a node group, which emits a LayoutNode; Key and Skippable
+// open virtual groups, which only scope the slots of their content. A group is
+// reused across frames for as long as its parent composes a child with the same
+// slot key, so its pointer doubles as the scope of the state reads it made.
+type group struct {
+	parent  *group
+	key     string
+	slotKey string
+	path    string // slot keys from the root, the namespace of the group's state keys
+	isNode  bool
+
+	children      []*group
+	remembered    map[string]any
+	rememberOrder []string
+	stateKeys     []string
+	changed       []any
+	emitted       []LayoutNode
+	idCount       int
+	locals        map[interface{}]interface{}
+	composed      bool // completed at least one frame
+
+	// per-frame bookkeeping, valid between begin and end
+	prevChildren      []*group
+	prevRemembered    map[string]any
+	prevRememberOrder []string
+	prevStateKeys     []string
+	prevChanged       []any
+	prevEmitted       []LayoutNode
+	prevIDCount       int
+	matched           map[*group]struct{}
+	ordinals          map[string]int
+	rememberCount     map[string]int
+	stateCount        map[string]int
+	startLocals       map[interface{}]interface{}
+	emitStart         int
+	idStart           int
+}
+
+func newGroup(parent *group, key string, slotKey string, isNode bool) *group {
+	path := ""
+	if parent != nil {
+		path = parent.path + "/" + slotKey
+	}
+	return &group{
+		parent:     parent,
+		key:        key,
+		slotKey:    slotKey,
+		path:       path,
+		isNode:     isNode,
+		remembered: map[string]any{},
+	}
+}
+
+// begin moves the slots of the previous frame aside so they can be matched.
+func (g *group) begin() {
+	g.prevChildren = g.children
+	g.prevRemembered = g.remembered
+	g.prevRememberOrder = g.rememberOrder
+	g.prevStateKeys = g.stateKeys
+	g.prevChanged = g.changed
+	g.prevEmitted = g.emitted
+	g.prevIDCount = g.idCount
+
+	g.children = nil
+	g.remembered = map[string]any{}
+	g.rememberOrder = nil
+	g.stateKeys = nil
+	g.changed = nil
+	g.emitted = nil
+	g.matched = map[*group]struct{}{}
+	g.ordinals = map[string]int{}
+	g.rememberCount = map[string]int{}
+	g.stateCount = map[string]int{}
+}
+
+// childSlotKey is the key used to match a child across frames: explicit keys
+// are used as-is, everything else by its position among siblings of the same key.
+func (g *group) childSlotKey(key string, explicitKey string) string {
+	base := key
+	if explicitKey != "" {
+		base = "key:" + explicitKey
+	}
+	ordinal := g.ordinals[base]
+	g.ordinals[base] = ordinal + 1
+	if ordinal == 0 {
+		return base
+	}
+	return fmt.Sprintf("%s#%d", base, ordinal)
+}
+
+func (g *group) findPrevChild(slotKey string) *group {
+	for _, child := range g.prevChildren {
+		if child.slotKey != slotKey {
+			continue
+		}
+		if _, taken := g.matched[child]; taken {
+			continue
+		}
+		return child
+	}
+	return nil
+}
+
+// rememberSlotKey makes repeated Remember calls with the same key inside one
+// group address different slots, in call order.
+func (g *group) rememberSlotKey(key string) string {
+	n := g.rememberCount[key]
+	g.rememberCount[key] = n + 1
+	if n == 0 {
+		return key
+	}
+	return fmt.Sprintf("%s#%d", key, n)
+}
+
+// remember stores value in slot, keeping the order of the Remember calls.
+func (g *group) remember(slot string, value any) {
+	g.remembered[slot] = value
+	g.rememberOrder = append(g.rememberOrder, slot)
+}
+
+// droppedRemembered returns, in call order, the values remembered in the previous
+// frame whose slot was not asked for in this one.
+func (g *group) droppedRemembered() []any {
+	var dropped []any
+	for _, slot := range g.prevRememberOrder {
+		if _, ok := g.remembered[slot]; !ok {
+			dropped = append(dropped, g.prevRemembered[slot])
+		}
+	}
+	return dropped
+}
+
+// rememberedValues returns the values remembered in the last frame, in call order.
+func (g *group) rememberedValues() []any {
+	values := make([]any, 0, len(g.rememberOrder))
+	for _, slot := range g.rememberOrder {
+		values = append(values, g.remembered[slot])
+	}
+	return values
+}
+
+// scopedStateKey turns a State key into a key unique to this group and call.
+func (g *group) scopedStateKey(key string) string {
+	n := g.stateCount[key]
+	g.stateCount[key] = n + 1
+	scoped := g.path + "/" + key
+	if n > 0 {
+		scoped = fmt.Sprintf("%s#%d", scoped, n)
+	}
+	g.stateKeys = append(g.stateKeys, scoped)
+	return scoped
+}
+
+// staleStateKeys returns the state keys used in the previous frame but not in this one.
+func (g *group) staleStateKeys() []string {
+	if len(g.prevStateKeys) == 0 {
+		return nil
+	}
+	current := make(map[string]struct{}, len(g.stateKeys))
+	for _, key := range g.stateKeys {
+		current[key] = struct{}{}
+	}
+	var stale []string
+	for _, key := range g.prevStateKeys {
+		if _, ok := current[key]; !ok {
+			stale = append(stale, key)
+		}
+	}
+	return stale
+}
+
+// restorePrevious keeps the whole previous frame of a skipped group.
+func (g *group) restorePrevious() {
+	g.children = g.prevChildren
+	g.remembered = g.prevRemembered
+	g.rememberOrder = g.prevRememberOrder
+	g.stateKeys = g.prevStateKeys
+	g.changed = g.prevChanged
+	for _, child := range g.children {
+		g.matched[child] = struct{}{}
+	}
+}
+
+// walk calls fn for g and every group below it.
+func (g *group) walk(fn func(*group)) {
+	fn(g)
+	for _, child := range g.children {
+		child.walk(fn)
+	}
+}
+
+// equalLocals compares composition locals by identity, as the values are often
+// functions or pointers that deep equality cannot handle.
+func equalLocals(a, b map[interface{}]interface{}) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, va := range a {
+		vb, ok := b[k]
+		if !ok || !sameValue(va, vb) {
+			return false
+		}
+	}
+	return true
+}
+
+func sameValue(a, b any) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// equalInput decides whether a Changed input is unchanged since the last frame.
+func equalInput(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,102 @@
+package zipper
+
+import (
+	"fmt"
+
+	"github.com/zodimo/go-compose/pkg/api"
+)
+
+// subcompositionKey is the Remember key of the subcomposition of a group.
+const subcompositionKey = "__subcomposition__"
+
+var _ api.Subcomposition = (*subcomposition)(nil)
+var _ RememberObserver = (*subcomposition)(nil)
+
+// subcomposition keeps a slot table per slot. The root group of a slot is
+// namespaced below the group that owns the subcomposition, so the state keys of
+// its content do not collide with those of the composition.
+type subcomposition struct {
+	state  PersistentState
+	path   string
+	locals map[interface{}]interface{}
+	slots  map[any]*slotTable
+}
+
+func (c *composer) Subcomposition() api.Subcomposition {
+	g := c.group
+	sub := c.Remember(subcompositionKey, func() any {
+		return &subcomposition{
+			state: c.state,
+			path:  g.path,
+			slots: map[any]*slotTable{},
+		}
+	}).(*subcomposition)
+	sub.locals = c.locals
+	return sub
+}
+
+// Compose composes content in a composer of its own, on the slot table of slotID.
+// The reads of the content are observed like those of the composition, so
+// writes to them schedule a frame.
+func (s *subcomposition) Compose(slotID any, content Composable) LayoutNode {
+	table, ok := s.slots[slotID]
+	if !ok {
+		table = newSlotTable()
+		table.root.path = s.path + "/subcomposition:" + slotPath(slotID)
+		s.slots[slotID] = table
+	}
+	table.root.begin()
+	table.root.emitStart = -1
+
+	c := &composer{
+		table:          table,
+		group:          table.root,
+		state:          s.state,
+		observer:       s.state.Observer(),
+		idManager:      GetScopedIdentityManager("composer"),
+		locals:         s.locals,
+		providersStack: []map[interface{}]interface{}{},
+	}
+	c.observer.Clear(table.root)
+	c.observer.Observe(c.currentScope)
+	c.enterSnapshot()
+	defer c.abandon()
+
+	c.StartBlock("Subcomposition")
+	content(c)
+	c.EndBlock()
+	return c.Build()
+}
+
+// slotPath names the root group of a slot. The type of the slot ID is part of
+// the name, so the slots 1 and "1" keep their state apart.
+func slotPath(slotID any) string {
+	return fmt.Sprintf("%T:%v", slotID, slotID)
+}
+
+func (s *subcomposition) Dispose(slotID any) {
+	if table, ok := s.slots[slotID]; ok {
+		delete(s.slots, slotID)
+		s.disposeTable(table)
+	}
+}
+
+func (s *subcomposition) disposeTable(table *slotTable) {
+	c := &composer{
+		table:    table,
+		state:    s.state,
+		observer: s.state.Observer(),
+	}
+	c.disposeGroup(table.root)
+	c.applyEffects()
+}
+
+func (s *subcomposition) OnRemembered() {}
+
+// OnForgotten disposes every slot once the owning group leaves the composition.
+func (s *subcomposition) OnForgotten() {
+	for key, table := range s.slots {
+		delete(s.slots, key)
+		s.disposeTable(table)
+	}
+}
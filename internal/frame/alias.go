@@ -0,0 +1,11 @@
+package frame
+
+import (
+	"github.com/zodimo/go-compose/internal/layoutnode"
+
+	"gioui.org/layout"
+)
+
+type LayoutNode = layoutnode.LayoutNode
+
+type LayoutContext = layout.Context
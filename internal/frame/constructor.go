@@ -1,4 +1,4 @@
-package runtime
+package frame
 
 func NewRuntime() Runtime {
 	return &runtime{}
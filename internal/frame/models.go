@@ -0,0 +1,37 @@
+package frame
+
+import (
+	"image"
+
+	"github.com/zodimo/go-compose/internal/layoutnode"
+
+	"gioui.org/op"
+)
+
+var _ Runtime = (*runtime)(nil)
+
+type runtime struct {
+	root layoutnode.NodeCoordinator
+}
+
+func (r *runtime) Run(gtx LayoutContext, node LayoutNode) op.CallOp {
+
+	gtx.Constraints.Min = image.Point{X: 0, Y: 0}
+	if r.root == nil || r.root.GetKey() != node.GetKey() {
+		r.Dispose()
+		r.root = layoutnode.NewNodeCoordinator(node)
+	} else {
+		r.root.Update(node)
+	}
+
+	r.root.Layout(gtx)
+	r.root.PointerPhase(gtx)
+	return r.root.Draw(gtx)
+}
+
+func (r *runtime) Dispose() {
+	if r.root != nil {
+		r.root.Dispose()
+		r.root = nil
+	}
+}
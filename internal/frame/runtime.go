@@ -0,0 +1,13 @@
+// Package frame lays out and draws the frames of a composition, for the
+// windows of runtime and the headless tests of composetest.
+package frame
+
+import "gioui.org/op"
+
+type Runtime interface {
+	// Run lays out and draws the tree of a composition. The modifier nodes of
+	// the previous frame are kept for the nodes that are still in the tree.
+	Run(LayoutContext, LayoutNode) op.CallOp
+	// Dispose detaches the modifier nodes kept from the last frame.
+	Dispose()
+}
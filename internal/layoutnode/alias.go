@@ -1,6 +1,7 @@
 package layoutnode
 
 import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
 	node "github.com/zodimo/go-compose/internal/Node"
 	"github.com/zodimo/go-compose/internal/identity"
 	"github.com/zodimo/go-compose/internal/immap"
@@ -46,3 +47,6 @@ type PersistentState = state.PersistentState
 type MutableValue = state.MutableValue
 
 type Slots = immap.ImmutableMap[any]
+
+type Constraints = unit.Constraints
+type Density = unit.Density
@@ -29,10 +29,6 @@ func NewNodeCoordinator(node LayoutNode) NodeCoordinator {
 		elementStore:    EmptyElementStore,
 		wrappedChildren: []TreeNode{},
 	}
-	widget := outNode.GetWidget()
-
-	outNode.layoutCallChain = NewLayoutWidget(widget)
-	outNode.pointerCallChain = NewLayoutWidget(widget)
 	outNode.WrapChildren()
 	return outNode
 }
@@ -75,3 +71,59 @@ func NewLayoutContextReceiver(widget GioLayoutWidget) LayoutContextReceiver {
 		widget(gtx)
 	}
 }
+
+// NewMeasurePolicy returns a MeasurePolicy that measures with measure.
+func NewMeasurePolicy(measure func(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult) MeasurePolicy {
+	return measurePolicy{measure: measure}
+}
+
+// NewMeasurePolicyWidgetConstructor returns a constructor that measures and
+// places the children of the node with policy. The intrinsic size of the node
+// comes from policy when it is an IntrinsicMeasurePolicy.
+func NewMeasurePolicyWidgetConstructor(policy MeasurePolicy) IntrinsicWidgetConstructor {
+	intrinsics, ok := policy.(Intrinsics)
+	if !ok {
+		intrinsics = defaultIntrinsics{policy: policy}
+	}
+	return measurePolicyWidgetConstructor{
+		Intrinsics: intrinsics,
+		policy:     policy,
+	}
+}
+
+// NewIntrinsicWidgetConstructor adds intrinsics to constructor, for nodes laid
+// out by Gio layouts.
+func NewIntrinsicWidgetConstructor(constructor LayoutNodeWidgetConstructor, intrinsics Intrinsics) IntrinsicWidgetConstructor {
+	return intrinsicWidgetConstructor{
+		LayoutNodeWidgetConstructor: constructor,
+		Intrinsics:                  intrinsics,
+	}
+}
+
+// NewSubcomposeMeasurePolicy returns a SubcomposeMeasurePolicy that measures with measure.
+func NewSubcomposeMeasurePolicy(measure func(scope SubcomposeMeasureScope, constraints Constraints) MeasureResult) SubcomposeMeasurePolicy {
+	return subcomposeMeasurePolicy{measure: measure}
+}
+
+// NewSubcomposeSlots returns the slots of a subcompose layout. onDispose is
+// called with the ID of each slot that is disposed, so that its composition can
+// be disposed as well.
+func NewSubcomposeSlots(onDispose func(slotID any)) SubcomposeSlots {
+	return &subcomposeSlots{
+		slots:     map[any]*subcomposeSlot{},
+		composed:  map[any]struct{}{},
+		onDispose: onDispose,
+	}
+}
+
+// NewSubcomposeWidgetConstructor returns a constructor that measures and places
+// the slots composed by policy. The slots must have been made by NewSubcomposeSlots.
+// It is called by each composition of the layout, whose slots are composed anew.
+func NewSubcomposeWidgetConstructor(slots SubcomposeSlots, policy SubcomposeMeasurePolicy) LayoutNodeWidgetConstructor {
+	s := slots.(*subcomposeSlots)
+	s.update()
+	return subcomposeWidgetConstructor{
+		slots:  s,
+		policy: policy,
+	}
+}
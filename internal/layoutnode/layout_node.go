@@ -12,6 +12,9 @@ type LayoutNodeWidgetConstructor interface {
 type LayoutNode interface {
 	TreeNode
 
+	// GetKey returns the key the node was started with.
+	GetKey() string
+
 	LayoutNodeChildren() []LayoutNode
 
 	WithChildren(children []LayoutNode) LayoutNode
@@ -72,5 +75,21 @@ type NodeCoordinator interface {
 
 	Elements() ElementStore
 
+	// Expand attaches the modifier chain of the node and of its children.
 	Expand()
+	// Update points the coordinator at node, the same node in a new
+	// composition, so that its modifier nodes and children are kept.
+	Update(node LayoutNode)
+	// Dispose detaches every modifier node of the coordinator and its children.
+	Dispose()
+
+	// Intrinsic size of the node with its modifiers, in the context of the
+	// parent layout gtx.
+	MinIntrinsicWidth(gtx LayoutContext, height int) int
+	MaxIntrinsicWidth(gtx LayoutContext, height int) int
+	MinIntrinsicHeight(gtx LayoutContext, width int) int
+	MaxIntrinsicHeight(gtx LayoutContext, width int) int
+	// ContentIntrinsics returns the intrinsic size of the content modifier
+	// is applied to: the node with the modifiers inside modifier.
+	ContentIntrinsics(gtx LayoutContext, modifier ChainNode) IntrinsicMeasurable
 }
@@ -24,6 +24,10 @@ func (ln *layoutNode) GetID() NodeID {
 	return ln.id
 }
 
+func (ln *layoutNode) GetKey() string {
+	return ln.key
+}
+
 // TreeNode
 func (ln *layoutNode) Children() []TreeNode {
 	treeNodeChildren := []TreeNode{}
@@ -0,0 +1,109 @@
+package layoutnode
+
+// IntrinsicMeasurable is a child of a layout whose intrinsic size can be
+// queried without measuring it.
+type IntrinsicMeasurable interface {
+	// ParentData returns the elements set on the child by parent data modifiers.
+	ParentData() ElementStore
+
+	// MinIntrinsicWidth returns the smallest width the child can be laid out
+	// at without clipping its content, given height.
+	MinIntrinsicWidth(height int) int
+	// MaxIntrinsicWidth returns the width beyond which the child cannot grow
+	// without adding space, given height.
+	MaxIntrinsicWidth(height int) int
+	// MinIntrinsicHeight returns the smallest height the child can be laid out
+	// at without clipping its content, given width.
+	MinIntrinsicHeight(width int) int
+	// MaxIntrinsicHeight returns the height beyond which the child cannot grow
+	// without adding space, given width.
+	MaxIntrinsicHeight(width int) int
+}
+
+// Measurable is a child of a layout that can be measured.
+// A child can be measured more than once; the last Placeable is the one to place.
+type Measurable interface {
+	IntrinsicMeasurable
+
+	// Measure lays out the child within constraints.
+	Measure(constraints Constraints) Placeable
+}
+
+// Placeable is a measured child, ready to be placed.
+type Placeable interface {
+	Width() int
+	Height() int
+	// Baseline returns the distance from the bottom of the child to its baseline.
+	Baseline() int
+}
+
+// MeasureScope is the scope of MeasurePolicy.Measure.
+type MeasureScope interface {
+	Density() Density
+	LayoutDirection() LayoutDirection
+
+	// Layout returns the result of a measurement: the size of the layout and
+	// the placement of its children.
+	Layout(width, height int, placement func(scope PlacementScope)) MeasureResult
+}
+
+// PlacementScope places the children of a layout, relative to its top left corner.
+type PlacementScope interface {
+	LayoutDirection() LayoutDirection
+
+	Place(placeable Placeable, x, y int)
+	// PlaceRelative places placeable at x from the start of the layout, which
+	// is its right edge in right-to-left layouts.
+	PlaceRelative(placeable Placeable, x, y int)
+}
+
+// MeasureResult is the size of a measured layout and the placement of its children.
+type MeasureResult interface {
+	Width() int
+	Height() int
+	PlaceChildren(scope PlacementScope)
+}
+
+// MeasurePolicy measures and places the children of a layout.
+type MeasurePolicy interface {
+	Measure(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult
+}
+
+// Intrinsics computes the intrinsic size of a layout from those of its children.
+type Intrinsics interface {
+	MinIntrinsicWidth(scope MeasureScope, measurables []IntrinsicMeasurable, height int) int
+	MaxIntrinsicWidth(scope MeasureScope, measurables []IntrinsicMeasurable, height int) int
+	MinIntrinsicHeight(scope MeasureScope, measurables []IntrinsicMeasurable, width int) int
+	MaxIntrinsicHeight(scope MeasureScope, measurables []IntrinsicMeasurable, width int) int
+}
+
+// IntrinsicMeasurePolicy is a MeasurePolicy that computes its intrinsic sizes.
+// The intrinsic sizes of other policies are derived by running Measure with
+// children that take the size of their own intrinsic measurements.
+type IntrinsicMeasurePolicy interface {
+	MeasurePolicy
+	Intrinsics
+}
+
+// IntrinsicModifierNode is implemented by modifier nodes that change the
+// intrinsic size of the node they modify, such as padding or a fixed size.
+// measurable is the content the modifier is applied to.
+type IntrinsicModifierNode interface {
+	ChainNode
+
+	MinIntrinsicWidth(scope MeasureScope, measurable IntrinsicMeasurable, height int) int
+	MaxIntrinsicWidth(scope MeasureScope, measurable IntrinsicMeasurable, height int) int
+	MinIntrinsicHeight(scope MeasureScope, measurable IntrinsicMeasurable, width int) int
+	MaxIntrinsicHeight(scope MeasureScope, measurable IntrinsicMeasurable, width int) int
+}
+
+// IntrinsicWidgetConstructor is a LayoutNodeWidgetConstructor that computes
+// the intrinsic size of its node from the children.
+//
+// The intrinsic size of a node made by another constructor is measured by
+// laying it out, bounded by the constraints of its parent; its minimum and
+// maximum intrinsic sizes are then the same.
+type IntrinsicWidgetConstructor interface {
+	LayoutNodeWidgetConstructor
+	Intrinsics
+}
@@ -0,0 +1,364 @@
+package layoutnode
+
+import (
+	"image"
+
+	"github.com/zodimo/go-compose/compose/ui/unit"
+
+	"gioui.org/io/system"
+	"gioui.org/op"
+)
+
+var _ MeasureScope = (*measureScope)(nil)
+
+type measureScope struct {
+	gtx LayoutContext
+}
+
+func (s measureScope) Density() Density {
+	pxPerDp := s.gtx.Metric.PxPerDp
+	if pxPerDp == 0 {
+		return unit.NewDensity(1, 1)
+	}
+	return unit.NewDensity(pxPerDp, s.gtx.Metric.PxPerSp/pxPerDp)
+}
+
+func (s measureScope) LayoutDirection() LayoutDirection {
+	return layoutDirection(s.gtx)
+}
+
+func (s measureScope) Layout(width, height int, placement func(scope PlacementScope)) MeasureResult {
+	return measureResult{width: width, height: height, placement: placement}
+}
+
+func layoutDirection(gtx LayoutContext) LayoutDirection {
+	if gtx.Locale.Direction == system.RTL {
+		return LayoutDirectionRTL
+	}
+	return LayoutDirectionLTR
+}
+
+var _ MeasureResult = (*measureResult)(nil)
+
+type measureResult struct {
+	width, height int
+	placement     func(scope PlacementScope)
+}
+
+func (r measureResult) Width() int  { return r.width }
+func (r measureResult) Height() int { return r.height }
+
+func (r measureResult) PlaceChildren(scope PlacementScope) {
+	if r.placement != nil {
+		r.placement(scope)
+	}
+}
+
+var _ Placeable = (*placeable)(nil)
+
+// placeable is a child laid out into a macro, which placing adds at an offset.
+type placeable struct {
+	dimensions LayoutDimensions
+	call       op.CallOp
+}
+
+func (p *placeable) Width() int    { return p.dimensions.Size.X }
+func (p *placeable) Height() int   { return p.dimensions.Size.Y }
+func (p *placeable) Baseline() int { return p.dimensions.Baseline }
+
+var _ PlacementScope = (*placementScope)(nil)
+
+type placementScope struct {
+	gtx   LayoutContext
+	width int
+}
+
+func (s placementScope) LayoutDirection() LayoutDirection {
+	return layoutDirection(s.gtx)
+}
+
+func (s placementScope) Place(p Placeable, x, y int) {
+	measured, ok := p.(*placeable)
+	if !ok {
+		// Placeables of intrinsic measurements have nothing to draw.
+		return
+	}
+	defer op.Offset(image.Pt(x, y)).Push(s.gtx.Ops).Pop()
+	measured.call.Add(s.gtx.Ops)
+}
+
+func (s placementScope) PlaceRelative(p Placeable, x, y int) {
+	if s.LayoutDirection() == LayoutDirectionRTL {
+		x = s.width - x - p.Width()
+	}
+	s.Place(p, x, y)
+}
+
+var _ Measurable = (*measurable)(nil)
+
+// measurable measures a child coordinator in the context of its parent.
+type measurable struct {
+	gtx   LayoutContext
+	child NodeCoordinator
+}
+
+func (m measurable) ParentData() ElementStore {
+	return m.child.Elements()
+}
+
+func (m measurable) Measure(constraints Constraints) Placeable {
+	gtx := m.gtx
+	gtx.Constraints = unit.ConstraintsToGio(constraints)
+	macro := op.Record(gtx.Ops)
+	dimensions := m.child.Layout(gtx)
+	return &placeable{dimensions: dimensions, call: macro.Stop()}
+}
+
+func (m measurable) MinIntrinsicWidth(height int) int {
+	return m.child.MinIntrinsicWidth(m.gtx, height)
+}
+
+func (m measurable) MaxIntrinsicWidth(height int) int {
+	return m.child.MaxIntrinsicWidth(m.gtx, height)
+}
+
+func (m measurable) MinIntrinsicHeight(width int) int {
+	return m.child.MinIntrinsicHeight(m.gtx, width)
+}
+
+func (m measurable) MaxIntrinsicHeight(width int) int {
+	return m.child.MaxIntrinsicHeight(m.gtx, width)
+}
+
+// Measurables returns the children of node as measurables in the context gtx.
+func Measurables(gtx LayoutContext, node LayoutNode) []Measurable {
+	children := node.Children()
+	measurables := make([]Measurable, len(children))
+	for i, child := range children {
+		measurables[i] = measurable{gtx: gtx, child: child.(NodeCoordinator)}
+	}
+	return measurables
+}
+
+func intrinsicMeasurables(measurables []Measurable) []IntrinsicMeasurable {
+	out := make([]IntrinsicMeasurable, len(measurables))
+	for i, m := range measurables {
+		out[i] = m
+	}
+	return out
+}
+
+// widgetIntrinsics measures the intrinsic size of a widget by laying it out,
+// bounded by the constraints of gtx in the dimension that is not given.
+type widgetIntrinsics struct {
+	gtx      LayoutContext
+	widget   GioLayoutWidget
+	elements ElementStore
+}
+
+func (w widgetIntrinsics) ParentData() ElementStore {
+	return w.elements
+}
+
+func (w widgetIntrinsics) measure(maxWidth, maxHeight int) image.Point {
+	gtx := w.gtx
+	if maxWidth == unit.Infinity {
+		maxWidth = gtx.Constraints.Max.X
+	}
+	if maxHeight == unit.Infinity {
+		maxHeight = gtx.Constraints.Max.Y
+	}
+	gtx.Constraints.Min = image.Point{}
+	gtx.Constraints.Max = image.Pt(maxWidth, maxHeight)
+	macro := op.Record(gtx.Ops)
+	defer macro.Stop()
+	return w.widget(gtx).Size
+}
+
+func (w widgetIntrinsics) MinIntrinsicWidth(height int) int {
+	return w.measure(unit.Infinity, height).X
+}
+
+func (w widgetIntrinsics) MaxIntrinsicWidth(height int) int {
+	return w.measure(unit.Infinity, height).X
+}
+
+func (w widgetIntrinsics) MinIntrinsicHeight(width int) int {
+	return w.measure(width, unit.Infinity).Y
+}
+
+func (w widgetIntrinsics) MaxIntrinsicHeight(width int) int {
+	return w.measure(width, unit.Infinity).Y
+}
+
+// constructorIntrinsics asks an IntrinsicWidgetConstructor for the intrinsic
+// size of a node.
+type constructorIntrinsics struct {
+	scope       MeasureScope
+	intrinsics  Intrinsics
+	measurables []IntrinsicMeasurable
+	elements    ElementStore
+}
+
+func (c constructorIntrinsics) ParentData() ElementStore {
+	return c.elements
+}
+
+func (c constructorIntrinsics) MinIntrinsicWidth(height int) int {
+	return c.intrinsics.MinIntrinsicWidth(c.scope, c.measurables, height)
+}
+
+func (c constructorIntrinsics) MaxIntrinsicWidth(height int) int {
+	return c.intrinsics.MaxIntrinsicWidth(c.scope, c.measurables, height)
+}
+
+func (c constructorIntrinsics) MinIntrinsicHeight(width int) int {
+	return c.intrinsics.MinIntrinsicHeight(c.scope, c.measurables, width)
+}
+
+func (c constructorIntrinsics) MaxIntrinsicHeight(width int) int {
+	return c.intrinsics.MaxIntrinsicHeight(c.scope, c.measurables, width)
+}
+
+// modifiedIntrinsics applies an IntrinsicModifierNode to the intrinsic size
+// of the content it modifies.
+type modifiedIntrinsics struct {
+	scope   MeasureScope
+	node    IntrinsicModifierNode
+	content IntrinsicMeasurable
+}
+
+func (m modifiedIntrinsics) ParentData() ElementStore {
+	return m.content.ParentData()
+}
+
+func (m modifiedIntrinsics) MinIntrinsicWidth(height int) int {
+	return m.node.MinIntrinsicWidth(m.scope, m.content, height)
+}
+
+func (m modifiedIntrinsics) MaxIntrinsicWidth(height int) int {
+	return m.node.MaxIntrinsicWidth(m.scope, m.content, height)
+}
+
+func (m modifiedIntrinsics) MinIntrinsicHeight(width int) int {
+	return m.node.MinIntrinsicHeight(m.scope, m.content, width)
+}
+
+func (m modifiedIntrinsics) MaxIntrinsicHeight(width int) int {
+	return m.node.MaxIntrinsicHeight(m.scope, m.content, width)
+}
+
+type intrinsicKind int
+
+const (
+	minIntrinsicWidth intrinsicKind = iota
+	maxIntrinsicWidth
+	minIntrinsicHeight
+	maxIntrinsicHeight
+)
+
+// intrinsicPlaceable is the result of measuring a defaultIntrinsicMeasurable.
+type intrinsicPlaceable struct {
+	width, height int
+}
+
+func (p intrinsicPlaceable) Width() int    { return p.width }
+func (p intrinsicPlaceable) Height() int   { return p.height }
+func (p intrinsicPlaceable) Baseline() int { return 0 }
+
+// defaultIntrinsicMeasurable measures to the intrinsic size of a child in the
+// queried dimension, so that running MeasurePolicy.Measure with it computes
+// the intrinsic size of the layout.
+type defaultIntrinsicMeasurable struct {
+	IntrinsicMeasurable
+	kind intrinsicKind
+}
+
+func (m defaultIntrinsicMeasurable) Measure(constraints Constraints) Placeable {
+	switch m.kind {
+	case minIntrinsicWidth, maxIntrinsicWidth:
+		height := 0
+		if constraints.HasBoundedHeight() {
+			height = constraints.MaxHeight()
+		}
+		width := m.MaxIntrinsicWidth(constraints.MaxHeight())
+		if m.kind == minIntrinsicWidth {
+			width = m.MinIntrinsicWidth(constraints.MaxHeight())
+		}
+		return intrinsicPlaceable{width: width, height: height}
+	default:
+		width := 0
+		if constraints.HasBoundedWidth() {
+			width = constraints.MaxWidth()
+		}
+		height := m.MaxIntrinsicHeight(constraints.MaxWidth())
+		if m.kind == minIntrinsicHeight {
+			height = m.MinIntrinsicHeight(constraints.MaxWidth())
+		}
+		return intrinsicPlaceable{width: width, height: height}
+	}
+}
+
+// defaultIntrinsics derives the intrinsic size of a MeasurePolicy that does not
+// compute it by measuring defaultIntrinsicMeasurables.
+type defaultIntrinsics struct {
+	policy MeasurePolicy
+}
+
+func (d defaultIntrinsics) measure(scope MeasureScope, measurables []IntrinsicMeasurable, kind intrinsicKind, constraints Constraints) MeasureResult {
+	wrapped := make([]Measurable, len(measurables))
+	for i, m := range measurables {
+		wrapped[i] = defaultIntrinsicMeasurable{IntrinsicMeasurable: m, kind: kind}
+	}
+	return d.policy.Measure(scope, wrapped, constraints)
+}
+
+func (d defaultIntrinsics) MinIntrinsicWidth(scope MeasureScope, measurables []IntrinsicMeasurable, height int) int {
+	return d.measure(scope, measurables, minIntrinsicWidth, unit.FitPrioritizingHeight(0, unit.Infinity, 0, height)).Width()
+}
+
+func (d defaultIntrinsics) MaxIntrinsicWidth(scope MeasureScope, measurables []IntrinsicMeasurable, height int) int {
+	return d.measure(scope, measurables, maxIntrinsicWidth, unit.FitPrioritizingHeight(0, unit.Infinity, 0, height)).Width()
+}
+
+func (d defaultIntrinsics) MinIntrinsicHeight(scope MeasureScope, measurables []IntrinsicMeasurable, width int) int {
+	return d.measure(scope, measurables, minIntrinsicHeight, unit.FitPrioritizingWidth(0, width, 0, unit.Infinity)).Height()
+}
+
+func (d defaultIntrinsics) MaxIntrinsicHeight(scope MeasureScope, measurables []IntrinsicMeasurable, width int) int {
+	return d.measure(scope, measurables, maxIntrinsicHeight, unit.FitPrioritizingWidth(0, width, 0, unit.Infinity)).Height()
+}
+
+var _ IntrinsicWidgetConstructor = (*measurePolicyWidgetConstructor)(nil)
+
+// measurePolicyWidgetConstructor lays out the children of a node with a MeasurePolicy.
+type measurePolicyWidgetConstructor struct {
+	Intrinsics
+	policy MeasurePolicy
+}
+
+func (c measurePolicyWidgetConstructor) Make(node LayoutNode) GioLayoutWidget {
+	return func(gtx LayoutContext) LayoutDimensions {
+		result := c.policy.Measure(measureScope{gtx: gtx}, Measurables(gtx, node), unit.GioConstraintsToConstraints(gtx.Constraints))
+		size := gtx.Constraints.Constrain(image.Pt(result.Width(), result.Height()))
+		result.PlaceChildren(placementScope{gtx: gtx, width: size.X})
+		return LayoutDimensions{Size: size}
+	}
+}
+
+var _ IntrinsicWidgetConstructor = (*intrinsicWidgetConstructor)(nil)
+
+type intrinsicWidgetConstructor struct {
+	LayoutNodeWidgetConstructor
+	Intrinsics
+}
+
+var _ MeasurePolicy = (*measurePolicy)(nil)
+
+type measurePolicy struct {
+	measure func(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult
+}
+
+func (p measurePolicy) Measure(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult {
+	return p.measure(scope, measurables, constraints)
+}
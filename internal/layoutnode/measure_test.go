@@ -0,0 +1,117 @@
+package layoutnode
+
+import (
+	"image"
+	"testing"
+
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/modifier"
+
+	"gioui.org/op"
+)
+
+// fixedNode is a leaf that is w by h pixels, within its constraints.
+func fixedNode(w, h int) LayoutNode {
+	n := newTestNode("fixed", EmptyModifier)
+	n.SetWidgetConstructor(NewLayoutNodeWidgetConstructor(func(LayoutNode) GioLayoutWidget {
+		return func(gtx LayoutContext) LayoutDimensions {
+			return LayoutDimensions{Size: gtx.Constraints.Constrain(image.Pt(w, h))}
+		}
+	}))
+	return n
+}
+
+// horizontalPolicy places its children side by side, measuring each twice.
+var horizontalPolicy = NewMeasurePolicy(func(scope MeasureScope, measurables []Measurable, constraints Constraints) MeasureResult {
+	placeables := make([]Placeable, len(measurables))
+	width, height := 0, 0
+	for i, m := range measurables {
+		m.Measure(constraints)
+		placeables[i] = m.Measure(unit.NewConstraints(0, constraints.MaxWidth(), 0, constraints.MaxHeight()))
+		width += placeables[i].Width()
+		height = max(height, placeables[i].Height())
+	}
+	return scope.Layout(width, height, func(scope PlacementScope) {
+		x := 0
+		for _, p := range placeables {
+			scope.PlaceRelative(p, x, 0)
+			x += p.Width()
+		}
+	})
+})
+
+func measureContext() LayoutContext {
+	gtx := LayoutContext{Ops: new(op.Ops)}
+	gtx.Constraints.Max = image.Pt(500, 500)
+	return gtx
+}
+
+func TestMeasurePolicyWidgetConstructor(t *testing.T) {
+	root := newTestNode("root", EmptyModifier, fixedNode(10, 20), fixedNode(30, 5))
+	root.SetWidgetConstructor(NewMeasurePolicyWidgetConstructor(horizontalPolicy))
+	nc := NewNodeCoordinator(root)
+
+	dims := nc.Layout(measureContext())
+	if dims.Size != image.Pt(40, 20) {
+		t.Errorf("size = %v, want (40,20)", dims.Size)
+	}
+}
+
+func TestDefaultIntrinsicsOfMeasurePolicy(t *testing.T) {
+	root := newTestNode("root", EmptyModifier, fixedNode(10, 20), fixedNode(30, 5))
+	root.SetWidgetConstructor(NewMeasurePolicyWidgetConstructor(horizontalPolicy))
+	nc := NewNodeCoordinator(root)
+
+	gtx := measureContext()
+	if got := nc.MinIntrinsicWidth(gtx, unit.Infinity); got != 40 {
+		t.Errorf("MinIntrinsicWidth = %d, want 40", got)
+	}
+	if got := nc.MaxIntrinsicHeight(gtx, unit.Infinity); got != 20 {
+		t.Errorf("MaxIntrinsicHeight = %d, want 20", got)
+	}
+}
+
+type paddedElement struct {
+	padding int
+}
+
+type paddedNode struct {
+	ChainNode
+	padding int
+}
+
+func (e *paddedElement) Create() node.Node {
+	n := &paddedNode{padding: e.padding}
+	n.ChainNode = node.NewChainNode(node.NewNodeID(), node.NodeKindLayout, node.LayoutPhase, func(TreeNode) {})
+	return n
+}
+func (e *paddedElement) Update(n node.Node)                 { n.(*paddedNode).padding = e.padding }
+func (e *paddedElement) Equals(other modifier.Element) bool { return false }
+
+func (n *paddedNode) MinIntrinsicWidth(_ MeasureScope, content IntrinsicMeasurable, height int) int {
+	return content.MinIntrinsicWidth(height) + 2*n.padding
+}
+func (n *paddedNode) MaxIntrinsicWidth(_ MeasureScope, content IntrinsicMeasurable, height int) int {
+	return content.MaxIntrinsicWidth(height) + 2*n.padding
+}
+func (n *paddedNode) MinIntrinsicHeight(_ MeasureScope, content IntrinsicMeasurable, width int) int {
+	return content.MinIntrinsicHeight(width) + 2*n.padding
+}
+func (n *paddedNode) MaxIntrinsicHeight(_ MeasureScope, content IntrinsicMeasurable, width int) int {
+	return content.MaxIntrinsicHeight(width) + 2*n.padding
+}
+
+func TestIntrinsicModifierNode(t *testing.T) {
+	child := fixedNode(10, 20)
+	child.Modifier(func(Modifier) Modifier { return modifier.NewModifier(&paddedElement{padding: 3}) })
+	nc := NewNodeCoordinator(child)
+
+	gtx := measureContext()
+	if got := nc.MaxIntrinsicWidth(gtx, unit.Infinity); got != 16 {
+		t.Errorf("MaxIntrinsicWidth = %d, want 16", got)
+	}
+	if got := nc.ContentIntrinsics(gtx, nc.(*nodeCoordinator).modifierNodes[0].node).MaxIntrinsicWidth(unit.Infinity); got != 10 {
+		t.Errorf("content MaxIntrinsicWidth = %d, want 10", got)
+	}
+}
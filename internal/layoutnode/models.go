@@ -8,31 +8,109 @@ import (
 
 var _ NodeCoordinator = (*nodeCoordinator)(nil)
 
+// The coordinator is kept between frames: Update points it at the layout node
+// of the new composition, and Expand diffs the modifier chain of that node
+// against the nodes created for the previous one.
 type nodeCoordinator struct {
 	LayoutNode
 	layoutCallChain  LayoutWidget
 	pointerCallChain LayoutWidget
 	elementStore     ElementStore
 	wrappedChildren  []TreeNode
+	modifierNodes    []*modifierNodeEntry
+	attaching        *modifierNodeEntry
 	expanded         bool
 }
 
+// modifierNodeEntry is a node of the modifier chain together with the element
+// it was last updated from and the modifiers it attached, so that the call
+// chains can be rebuilt without attaching the node again.
+type modifierNodeEntry struct {
+	element         Element
+	node            ChainNode
+	widgetModifiers []func(widget LayoutWidget) LayoutWidget
+	parentData      []func(elements ElementStore) ElementStore
+}
+
+// WrapChildren wraps the children of the layout node in coordinators, reusing
+// the coordinators of the previous frame. A child is matched by its ID, or
+// else by its position, as long as it was started with the same key.
 func (nc *nodeCoordinator) WrapChildren() {
-	children := nc.LayoutNode.Children()
-	wrappedChildren := []TreeNode{}
+	previous := nc.wrappedChildren
+	used := make([]bool, len(previous))
+	byID := make(map[string]int, len(previous))
+	for i, child := range previous {
+		byID[child.GetID().String()] = i
+	}
 
-	for _, child := range children {
-		wrappedChild := NewNodeCoordinator(child.(LayoutNode))
-		wrappedChildren = append(wrappedChildren, wrappedChild)
+	match := func(index int, child LayoutNode) int {
+		if i, ok := byID[child.GetID().String()]; ok && !used[i] && previous[i].(LayoutNode).GetKey() == child.GetKey() {
+			return i
+		}
+		if index < len(previous) && !used[index] && previous[index].(LayoutNode).GetKey() == child.GetKey() {
+			return index
+		}
+		return -1
+	}
+
+	children := nc.LayoutNode.LayoutNodeChildren()
+	wrappedChildren := make([]TreeNode, 0, len(children))
+	for index, child := range children {
+		if i := match(index, child); i >= 0 {
+			used[i] = true
+			coordinator := previous[i].(NodeCoordinator)
+			coordinator.Update(child)
+			wrappedChildren = append(wrappedChildren, coordinator)
+			continue
+		}
+		wrappedChildren = append(wrappedChildren, NewNodeCoordinator(child))
+	}
+	for i, child := range previous {
+		if !used[i] {
+			child.(NodeCoordinator).Dispose()
+		}
 	}
 	nc.wrappedChildren = wrappedChildren
 }
 
+// Update points the coordinator at node, the same layout node in a new
+// composition, and reconciles the children. The modifier chain is diffed on the
+// next Expand.
+func (nc *nodeCoordinator) Update(node LayoutNode) {
+	nc.LayoutNode = node
+	nc.WrapChildren()
+	nc.expanded = false
+}
+
 func (nc *nodeCoordinator) Expand() {
+	nc.updateModifierNodes()
+	nc.rebuildCallChains()
 
-	modifierChain := nc.LayoutNode.UnwrapModifier().AsChain()
-	*nc = *modifier.FoldIn(modifierChain, nc, func(nc *nodeCoordinator, mod Modifier) *nodeCoordinator {
+	for _, child := range nc.wrappedChildren {
+		nodeCoordinatorChild := child.(NodeCoordinator)
+		nodeCoordinatorChild.Expand()
+	}
+	nc.expanded = true
+}
 
+// Dispose detaches the modifier nodes of the coordinator and of its children.
+func (nc *nodeCoordinator) Dispose() {
+	for _, entry := range nc.modifierNodes {
+		entry.node.Detach()
+	}
+	nc.modifierNodes = nil
+	for _, child := range nc.wrappedChildren {
+		child.(NodeCoordinator).Dispose()
+	}
+	nc.wrappedChildren = nil
+}
+
+// updateModifierNodes diffs the elements of the modifier chain against those of
+// the previous frame, position by position. A node is kept while its element
+// has the same type, and updated when the element is not equal to the previous
+// one; otherwise it is detached and a new node is created and attached.
+func (nc *nodeCoordinator) updateModifierNodes() {
+	elements := modifier.FoldIn(nc.LayoutNode.UnwrapModifier().AsChain(), []Element{}, func(elements []Element, mod Modifier) []Element {
 		if inspectable, ok := mod.(InspectableModifier); ok {
 			mod = inspectable.Unwrap()
 		}
@@ -40,23 +118,130 @@ func (nc *nodeCoordinator) Expand() {
 		modifierElement, ok := mod.(ModifierElement)
 		if !ok {
 			// probably EmptyModifier
-			return nc
+			return elements
+		}
+		return append(elements, modifierElement)
+	})
+
+	previous := nc.modifierNodes
+	entries := make([]*modifierNodeEntry, len(elements))
+	for i, element := range elements {
+		if i < len(previous) && modifier.CanReuse(previous[i].element, element) {
+			entry := previous[i]
+			if !modifier.ElementsEqual(entry.element, element) {
+				element.Update(entry.node)
+			}
+			entry.element = element
+			entries[i] = entry
+			continue
 		}
+		if i < len(previous) {
+			previous[i].node.Detach()
+		}
+		entries[i] = nc.attach(element)
+	}
+	for i := len(elements); i < len(previous); i++ {
+		previous[i].node.Detach()
+	}
+	nc.modifierNodes = entries
+}
 
-		modifierNode := modifierElement.Create()
-		modifierChainNode := modifierNode.(ChainNode)
+// attach creates the node of element and attaches it, recording the modifiers
+// it attaches to the coordinator.
+func (nc *nodeCoordinator) attach(element Element) *modifierNodeEntry {
+	entry := &modifierNodeEntry{
+		element: element,
+		node:    element.Create().(ChainNode),
+	}
+	nc.attaching = entry
+	defer func() { nc.attaching = nil }()
+	entry.node.Attach(nc)
+	return entry
+}
 
-		modifierChainNode.Attach(nc)
+// rebuildCallChains wraps the widget of the current layout node in the
+// modifiers of the chain. Modifiers read the state of their node when called,
+// so updated nodes take effect without being attached again.
+func (nc *nodeCoordinator) rebuildCallChains() {
+	widget := NewLayoutWidget(nc.GetWidget())
+	nc.layoutCallChain = widget
+	nc.pointerCallChain = widget
+	nc.elementStore = EmptyElementStore
+	for _, entry := range nc.modifierNodes {
+		for _, attach := range entry.widgetModifiers {
+			nc.wrapLayoutCallChain(attach)
+		}
+		for _, attach := range entry.parentData {
+			nc.elementStore = attach(nc.elementStore)
+		}
+	}
+}
 
-		return nc
+func (nc *nodeCoordinator) wrapLayoutCallChain(attach func(widget LayoutWidget) LayoutWidget) {
+	nc.layoutCallChain = nc.layoutCallChain.Map(func(in LayoutWidget) LayoutWidget {
+		return NewLayoutWidget(func(gtx LayoutContext) LayoutDimensions {
+			return attach(in).Layout(gtx)
+		})
 	})
+}
 
-	for _, child := range nc.wrappedChildren {
-		nodeCoordinatorChild := child.(NodeCoordinator)
-		nodeCoordinatorChild.Expand()
+func (nc *nodeCoordinator) attachWidgetModifier(attach func(widget LayoutWidget) LayoutWidget) {
+	if nc.attaching != nil {
+		nc.attaching.widgetModifiers = append(nc.attaching.widgetModifiers, attach)
+		return
 	}
-	nc.expanded = true
+	nc.wrapLayoutCallChain(attach)
+}
 
+// intrinsics returns the intrinsic size of the content of the node, adjusted by
+// the modifiers that implement IntrinsicModifierNode, innermost first, up to
+// but not including outer.
+func (nc *nodeCoordinator) intrinsics(gtx LayoutContext, outer ChainNode) IntrinsicMeasurable {
+	if !nc.expanded {
+		nc.Expand()
+	}
+	scope := measureScope{gtx: gtx}
+
+	var content IntrinsicMeasurable
+	if intrinsics, ok := nc.GetWidgetConstructor().(Intrinsics); ok && nc.GetLayoutResult().IsNone() {
+		content = constructorIntrinsics{
+			scope:       scope,
+			intrinsics:  intrinsics,
+			measurables: intrinsicMeasurables(Measurables(gtx, nc)),
+			elements:    nc.elementStore,
+		}
+	} else {
+		content = widgetIntrinsics{gtx: gtx, widget: nc.GetWidget(), elements: nc.elementStore}
+	}
+	for _, entry := range nc.modifierNodes {
+		if outer != nil && entry.node == outer {
+			break
+		}
+		if node, ok := entry.node.(IntrinsicModifierNode); ok {
+			content = modifiedIntrinsics{scope: scope, node: node, content: content}
+		}
+	}
+	return content
+}
+
+func (nc *nodeCoordinator) MinIntrinsicWidth(gtx LayoutContext, height int) int {
+	return nc.intrinsics(gtx, nil).MinIntrinsicWidth(height)
+}
+
+func (nc *nodeCoordinator) MaxIntrinsicWidth(gtx LayoutContext, height int) int {
+	return nc.intrinsics(gtx, nil).MaxIntrinsicWidth(height)
+}
+
+func (nc *nodeCoordinator) MinIntrinsicHeight(gtx LayoutContext, width int) int {
+	return nc.intrinsics(gtx, nil).MinIntrinsicHeight(width)
+}
+
+func (nc *nodeCoordinator) MaxIntrinsicHeight(gtx LayoutContext, width int) int {
+	return nc.intrinsics(gtx, nil).MaxIntrinsicHeight(width)
+}
+
+func (nc *nodeCoordinator) ContentIntrinsics(gtx LayoutContext, modifier ChainNode) IntrinsicMeasurable {
+	return nc.intrinsics(gtx, modifier)
 }
 
 func (nc *nodeCoordinator) Children() []TreeNode {
@@ -64,28 +249,19 @@ func (nc *nodeCoordinator) Children() []TreeNode {
 }
 
 func (nc *nodeCoordinator) AttachLayoutModifier(attach func(widget LayoutWidget) LayoutWidget) {
-	nc.layoutCallChain = nc.layoutCallChain.Map(func(in LayoutWidget) LayoutWidget {
-		return NewLayoutWidget(func(gtx LayoutContext) LayoutDimensions {
-			return attach(in).Layout(gtx)
-		})
-	})
+	nc.attachWidgetModifier(attach)
 }
 func (nc *nodeCoordinator) AttachDrawModifier(attach func(widget LayoutWidget) LayoutWidget) {
-	nc.layoutCallChain = nc.layoutCallChain.Map(func(in LayoutWidget) LayoutWidget {
-		return NewLayoutWidget(func(gtx LayoutContext) LayoutDimensions {
-			return attach(in).Layout(gtx)
-		})
-	})
-
+	nc.attachWidgetModifier(attach)
 }
 func (nc *nodeCoordinator) AttachPointerInputModifier(attach func(widget LayoutWidget) LayoutWidget) {
-	nc.layoutCallChain = nc.layoutCallChain.Map(func(in LayoutWidget) LayoutWidget {
-		return NewLayoutWidget(func(gtx LayoutContext) LayoutDimensions {
-			return attach(in).Layout(gtx)
-		})
-	})
+	nc.attachWidgetModifier(attach)
 }
 func (nc *nodeCoordinator) AttachParentDataModifier(attach func(elements ElementStore) ElementStore) {
+	if nc.attaching != nil {
+		nc.attaching.parentData = append(nc.attaching.parentData, attach)
+		return
+	}
 	nc.elementStore = attach(nc.elementStore)
 }
 func (nc *nodeCoordinator) PointerPhase(gtx LayoutContext) {
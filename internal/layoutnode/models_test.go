@@ -0,0 +1,143 @@
+package layoutnode
+
+import (
+	"image"
+	"testing"
+
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/immap"
+	"github.com/zodimo/go-compose/internal/modifier"
+
+	"gioui.org/op"
+)
+
+type lifecycle struct {
+	created, updated, detached int
+}
+
+type sizeElement struct {
+	size      int
+	lifecycle *lifecycle
+}
+
+type sizeNode struct {
+	ChainNode
+	size int
+}
+
+func (e *sizeElement) Create() node.Node {
+	e.lifecycle.created++
+	n := &sizeNode{size: e.size}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		func(t TreeNode) {
+			t.(LayoutModifierNode).AttachLayoutModifier(func(widget LayoutWidget) LayoutWidget {
+				return NewLayoutWidget(func(gtx LayoutContext) LayoutDimensions {
+					widget.Layout(gtx)
+					return LayoutDimensions{Size: image.Pt(n.size, n.size)}
+				})
+			})
+		},
+		node.NewChainNodeWithOnDetach(func() { e.lifecycle.detached++ }),
+	)
+	return n
+}
+
+func (e *sizeElement) Update(n node.Node) {
+	e.lifecycle.updated++
+	n.(*sizeNode).size = e.size
+}
+
+func (e *sizeElement) Equals(other modifier.Element) bool {
+	o, ok := other.(*sizeElement)
+	return ok && o.size == e.size
+}
+
+func sized(size int, l *lifecycle) Modifier {
+	return modifier.NewModifier(&sizeElement{size: size, lifecycle: l})
+}
+
+func newTestNode(key string, mod Modifier, children ...LayoutNode) LayoutNode {
+	n := NewLayoutNode(node.NewNodeID(), key, immap.EmptyImmutableMap[any](), immap.EmptyImmutableMap[any](), nil)
+	n.SetWidgetConstructor(EmptyWidgetConstructor)
+	n.Modifier(func(Modifier) Modifier { return mod })
+	return n.WithChildren(children)
+}
+
+func layoutSize(nc NodeCoordinator) int {
+	gtx := LayoutContext{Ops: new(op.Ops)}
+	return nc.Layout(gtx).Size.X
+}
+
+func TestNodeCoordinatorKeepsModifierNodes(t *testing.T) {
+	l := &lifecycle{}
+	nc := NewNodeCoordinator(newTestNode("root", sized(10, l)))
+	if got := layoutSize(nc); got != 10 {
+		t.Fatalf("size = %d, want 10", got)
+	}
+
+	nc.Update(newTestNode("root", sized(10, l)))
+	layoutSize(nc)
+	if l.created != 1 || l.updated != 0 {
+		t.Fatalf("equal element: created %d, updated %d; want 1, 0", l.created, l.updated)
+	}
+
+	nc.Update(newTestNode("root", sized(20, l)))
+	if got := layoutSize(nc); got != 20 {
+		t.Fatalf("size after update = %d, want 20", got)
+	}
+	if l.created != 1 || l.updated != 1 {
+		t.Fatalf("changed element: created %d, updated %d; want 1, 1", l.created, l.updated)
+	}
+
+	nc.Update(newTestNode("root", EmptyModifier))
+	layoutSize(nc)
+	if l.detached != 1 {
+		t.Fatalf("removed element: detached %d, want 1", l.detached)
+	}
+}
+
+func TestNodeCoordinatorReplacesNodeOfOtherType(t *testing.T) {
+	l := &lifecycle{}
+	other := &lifecycle{}
+	nc := NewNodeCoordinator(newTestNode("root", sized(10, l)))
+	layoutSize(nc)
+
+	nc.Update(newTestNode("root", modifier.NewModifier(&otherSizeElement{sizeElement{size: 5, lifecycle: other}})))
+	if got := layoutSize(nc); got != 5 {
+		t.Fatalf("size = %d, want 5", got)
+	}
+	if l.detached != 1 || other.created != 1 || l.updated != 0 {
+		t.Fatalf("detached %d, created %d, updated %d; want 1, 1, 0", l.detached, other.created, l.updated)
+	}
+}
+
+type otherSizeElement struct{ sizeElement }
+
+func TestNodeCoordinatorKeepsChildren(t *testing.T) {
+	first, second := &lifecycle{}, &lifecycle{}
+	nc := NewNodeCoordinator(newTestNode("root", EmptyModifier,
+		newTestNode("A", sized(1, first)),
+		newTestNode("B", sized(2, second)),
+	))
+	layoutSize(nc)
+
+	nc.Update(newTestNode("root", EmptyModifier,
+		newTestNode("A", sized(1, first)),
+	))
+	layoutSize(nc)
+
+	if first.created != 1 || first.detached != 0 {
+		t.Fatalf("kept child: created %d, detached %d; want 1, 0", first.created, first.detached)
+	}
+	if second.detached != 1 {
+		t.Fatalf("removed child: detached %d, want 1", second.detached)
+	}
+
+	nc.Dispose()
+	if first.detached != 1 {
+		t.Fatalf("disposed child: detached %d, want 1", first.detached)
+	}
+}
@@ -0,0 +1,24 @@
+package layoutnode
+
+// SubcomposeMeasureScope is the scope of SubcomposeMeasurePolicy.Measure.
+type SubcomposeMeasureScope interface {
+	MeasureScope
+
+	// Subcompose composes the slot slotID with compose, which returns the root
+	// of the nodes it emitted, and returns the children of that root as
+	// measurables. slotID must be comparable.
+	Subcompose(slotID any, compose func() LayoutNode) []Measurable
+}
+
+// SubcomposeMeasurePolicy measures a layout whose children are composed while
+// it is measured.
+type SubcomposeMeasurePolicy interface {
+	Measure(scope SubcomposeMeasureScope, constraints Constraints) MeasureResult
+}
+
+// SubcomposeSlots keeps the coordinators of the slots of a layout between
+// frames, so that the modifier nodes of the slot content are kept.
+type SubcomposeSlots interface {
+	// Dispose disposes the coordinators of every slot.
+	Dispose()
+}
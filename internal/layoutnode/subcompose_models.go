@@ -0,0 +1,150 @@
+package layoutnode
+
+import (
+	"image"
+
+	"github.com/zodimo/go-compose/compose/ui/unit"
+)
+
+var _ SubcomposeSlots = (*subcomposeSlots)(nil)
+
+// subcomposeSlots holds a coordinator per slot, wrapping the root returned by
+// the composition of the slot. The slots not composed during a measurement are
+// disposed at its end.
+//
+// A frame lays a node out once per pass, so a slot is composed at most once per
+// frame for the same constraints: a later pass reuses its coordinator.
+type subcomposeSlots struct {
+	slots      map[any]*subcomposeSlot
+	order      []any
+	composed   map[any]struct{}
+	onDispose  func(slotID any)
+	generation int
+	frame      subcomposeFrame
+}
+
+type subcomposeSlot struct {
+	coordinator NodeCoordinator
+	composed    subcomposeFrame
+}
+
+// subcomposeFrame identifies the measurements of a frame: the composition that
+// set the measure policy, the time of the layout pass and the constraints of
+// the layout.
+type subcomposeFrame struct {
+	generation  int
+	now         int64
+	constraints Constraints
+}
+
+// update starts a new generation, for the measure policy set by the current
+// composition.
+func (s *subcomposeSlots) update() {
+	s.generation++
+}
+
+// startPass starts a measurement in the frame of gtx.
+func (s *subcomposeSlots) startPass(gtx LayoutContext, constraints Constraints) {
+	s.frame = subcomposeFrame{generation: s.generation, now: gtx.Now.UnixNano(), constraints: constraints}
+}
+
+func (s *subcomposeSlots) subcompose(gtx LayoutContext, slotID any, compose func() LayoutNode) []Measurable {
+	slot, ok := s.slots[slotID]
+	if !ok || slot.composed != s.frame {
+		root := compose()
+		if ok && slot.coordinator.GetKey() == root.GetKey() {
+			slot.coordinator.Update(root)
+		} else {
+			if ok {
+				slot.coordinator.Dispose()
+			} else {
+				s.order = append(s.order, slotID)
+			}
+			slot = &subcomposeSlot{coordinator: NewNodeCoordinator(root)}
+			s.slots[slotID] = slot
+		}
+		slot.composed = s.frame
+	}
+	s.composed[slotID] = struct{}{}
+	coordinator := slot.coordinator
+
+	children := coordinator.Children()
+	measurables := make([]Measurable, len(children))
+	for i, child := range children {
+		measurables[i] = measurable{gtx: gtx, child: child.(NodeCoordinator)}
+	}
+	return measurables
+}
+
+// disposeUnused disposes the slots that were not composed since the last call.
+func (s *subcomposeSlots) disposeUnused() {
+	order := s.order[:0]
+	for _, slotID := range s.order {
+		if _, ok := s.composed[slotID]; ok {
+			order = append(order, slotID)
+			continue
+		}
+		s.dispose(slotID)
+	}
+	s.order = order
+	s.composed = map[any]struct{}{}
+}
+
+func (s *subcomposeSlots) dispose(slotID any) {
+	s.slots[slotID].coordinator.Dispose()
+	delete(s.slots, slotID)
+	if s.onDispose != nil {
+		s.onDispose(slotID)
+	}
+}
+
+func (s *subcomposeSlots) Dispose() {
+	for _, slotID := range s.order {
+		s.dispose(slotID)
+	}
+	s.order = nil
+	s.composed = map[any]struct{}{}
+}
+
+var _ SubcomposeMeasureScope = (*subcomposeMeasureScope)(nil)
+
+type subcomposeMeasureScope struct {
+	measureScope
+	slots *subcomposeSlots
+}
+
+func (s subcomposeMeasureScope) Subcompose(slotID any, compose func() LayoutNode) []Measurable {
+	return s.slots.subcompose(s.gtx, slotID, compose)
+}
+
+var _ LayoutNodeWidgetConstructor = (*subcomposeWidgetConstructor)(nil)
+
+// subcomposeWidgetConstructor lays out a node with a SubcomposeMeasurePolicy.
+// The children of the node itself are not laid out.
+type subcomposeWidgetConstructor struct {
+	slots  *subcomposeSlots
+	policy SubcomposeMeasurePolicy
+}
+
+func (c subcomposeWidgetConstructor) Make(node LayoutNode) GioLayoutWidget {
+	return func(gtx LayoutContext) LayoutDimensions {
+		constraints := unit.GioConstraintsToConstraints(gtx.Constraints)
+		c.slots.startPass(gtx, constraints)
+		scope := subcomposeMeasureScope{measureScope: measureScope{gtx: gtx}, slots: c.slots}
+		result := c.policy.Measure(scope, constraints)
+		c.slots.disposeUnused()
+		size := gtx.Constraints.Constrain(image.Pt(result.Width(), result.Height()))
+		result.PlaceChildren(placementScope{gtx: gtx, width: size.X})
+		return LayoutDimensions{Size: size}
+	}
+}
+
+var _ SubcomposeMeasurePolicy = (*subcomposeMeasurePolicy)(nil)
+
+type subcomposeMeasurePolicy struct {
+	measure func(scope SubcomposeMeasureScope, constraints Constraints) MeasureResult
+}
+
+func (p subcomposeMeasurePolicy) Measure(scope SubcomposeMeasureScope, constraints Constraints) MeasureResult {
+	return p.measure(scope, constraints)
+}
@@ -0,0 +1,27 @@
+package modifier
+
+import "reflect"
+
+// UnwrapElement returns the element wrapped by a modifier created with
+// NewModifier, or element itself.
+func UnwrapElement(element Element) Element {
+	switch m := element.(type) {
+	case *modifier:
+		return m.element
+	case modifier:
+		return m.element
+	}
+	return element
+}
+
+// CanReuse reports whether the node created by prev can be updated by next,
+// which is the case when both elements are of the same type.
+func CanReuse(prev, next Element) bool {
+	return reflect.TypeOf(UnwrapElement(prev)) == reflect.TypeOf(UnwrapElement(next))
+}
+
+// ElementsEqual reports whether prev and next are equivalent, so that a node
+// created by prev needs no update for next.
+func ElementsEqual(prev, next Element) bool {
+	return UnwrapElement(prev).Equals(UnwrapElement(next))
+}
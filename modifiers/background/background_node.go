@@ -15,45 +15,47 @@ var _ ChainNode = (*BackgroundNode)(nil)
 
 // NodeKind should also implement the interface of the LayoutNode for that phase
 
-func NewBackGroundNode(background BackgroundData) ChainNode {
-	return BackgroundNode{
-		ChainNode: node.NewChainNode(
-			node.NewNodeID(),
-			node.NodeKindDraw,
-			node.DrawPhase,
-			//OnAttach
-			func(n TreeNode) {
-				// how should the tree now be updated when attached
-				// tree nde is the layout tree
-
-				no := n.(layoutnode.DrawModifierNode)
-				// we can now work with the layoutNode
-				no.AttachDrawModifier(func(widget LayoutWidget) layoutnode.LayoutWidget {
-
-					return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
-						nrgba := graphics.ColorToNRGBA(background.Color)
-						return layout.Background{}.Layout(gtx,
-							func(gtx layout.Context) layout.Dimensions {
-								// shape
-								// color
-								defer background.Shape.CreateOutline(gtx.Constraints.Min, gtx.Metric).Push(gtx.Ops).Pop()
-
-								paint.Fill(gtx.Ops, nrgba)
-
-								return layout.Dimensions{Size: gtx.Constraints.Min}
-
-							},
-							func(gtx layout.Context) layout.Dimensions {
-								return widget.Layout(gtx)
-							},
-						)
-					})
-				})
-
-			},
-		),
+func NewBackGroundNode(background BackgroundData) *BackgroundNode {
+	n := &BackgroundNode{
 		background: background,
 	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindDraw,
+		node.DrawPhase,
+		//OnAttach
+		func(t TreeNode) {
+			// how should the tree now be updated when attached
+			// tree nde is the layout tree
+
+			no := t.(layoutnode.DrawModifierNode)
+			// we can now work with the layoutNode
+			no.AttachDrawModifier(func(widget LayoutWidget) layoutnode.LayoutWidget {
+
+				return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+					background := n.background
+					nrgba := graphics.ColorToNRGBA(background.Color)
+					return layout.Background{}.Layout(gtx,
+						func(gtx layout.Context) layout.Dimensions {
+							// shape
+							// color
+							defer background.Shape.CreateOutline(gtx.Constraints.Min, gtx.Metric).Push(gtx.Ops).Pop()
+
+							paint.Fill(gtx.Ops, nrgba)
+
+							return layout.Dimensions{Size: gtx.Constraints.Min}
+
+						},
+						func(gtx layout.Context) layout.Dimensions {
+							return widget.Layout(gtx)
+						},
+					)
+				})
+			})
+
+		},
+	)
+	return n
 }
 
 type BackgroundNode struct {
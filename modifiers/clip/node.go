@@ -16,44 +16,45 @@ type ClipNode struct {
 
 var _ ChainNode = (*ClipNode)(nil)
 
-func NewClipNode(element ClipElement) ChainNode {
-	return ClipNode{
-		ChainNode: node.NewChainNode(
-			node.NewNodeID(),
-			node.NodeKindDraw,
-			node.DrawPhase,
-			//OnAttach
-			func(n TreeNode) {
-
-				no := n.(layoutnode.DrawModifierNode)
-				// we can now work with the layoutNode
-				no.AttachDrawModifier(func(widget LayoutWidget) layoutnode.LayoutWidget {
-					return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
-						//clip to the shape
-						macro := op.Record(gtx.Ops)
-						dimensions := widget.Layout(gtx)
-						callOp := macro.Stop()
-						// Clip Shape here
-						clipDimensions := dimensions
-						if element.clipData.ClipToBounds {
-							clipDimensions = layoutnode.LayoutDimensions{
-								Size: gtx.Constraints.Max,
-							}
+func NewClipNode(element ClipElement) *ClipNode {
+	n := &ClipNode{
+		clipData: element.clipData,
+	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindDraw,
+		node.DrawPhase,
+		//OnAttach
+		func(t TreeNode) {
+
+			no := t.(layoutnode.DrawModifierNode)
+			// we can now work with the layoutNode
+			no.AttachDrawModifier(func(widget LayoutWidget) layoutnode.LayoutWidget {
+				return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+					//clip to the shape
+					macro := op.Record(gtx.Ops)
+					dimensions := widget.Layout(gtx)
+					callOp := macro.Stop()
+					// Clip Shape here
+					clipDimensions := dimensions
+					if n.clipData.ClipToBounds {
+						clipDimensions = layoutnode.LayoutDimensions{
+							Size: gtx.Constraints.Max,
 						}
+					}
 
-						stack := ClipShape(element.clipData.Shape, gtx, clipDimensions)
+					stack := ClipShape(n.clipData.Shape, gtx, clipDimensions)
 
-						callOp.Add(gtx.Ops)
-						stack.Pop()
+					callOp.Add(gtx.Ops)
+					stack.Pop()
 
-						return dimensions
-					})
+					return dimensions
 				})
+			})
 
-			},
-		),
-		clipData: element.ClipData(),
-	}
+		},
+	)
+	return n
 }
 
 func ClipShape(shape Shape, gtx layout.Context, dimensions layoutnode.LayoutDimensions) clip.Stack {
@@ -1,5 +1,11 @@
 package padding
 
+import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+)
+
 var _ ChainNode = (*PaddingNode)(nil)
 
 // NodeKind should also implement the interface of the LayoutNode for that phase
@@ -8,3 +14,64 @@ type PaddingNode struct {
 	ChainNode
 	padding PaddingData
 }
+
+func NewPaddingNode(padding PaddingData) *PaddingNode {
+	n := &PaddingNode{
+		padding: padding,
+	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		func(t TreeNode) {
+			no := t.(layoutnode.LayoutModifierNode)
+			no.AttachLayoutModifier(func(widget layoutnode.LayoutWidget) layoutnode.LayoutWidget {
+				return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+					// Read from the node so that updates are applied
+					return paddingInset(gtx, n.padding).Layout(gtx, widget.Layout)
+				})
+			})
+		},
+	)
+	return n
+}
+
+var _ layoutnode.IntrinsicModifierNode = (*PaddingNode)(nil)
+
+// insets returns the horizontal and vertical padding in pixels.
+func (n *PaddingNode) insets(scope layoutnode.MeasureScope) (horizontal, vertical int) {
+	px := func(dp int) int {
+		if dp <= 0 {
+			return 0
+		}
+		return scope.Density().DpRoundToPx(unit.Dp(dp))
+	}
+	return px(n.padding.Start) + px(n.padding.End), px(n.padding.Top) + px(n.padding.Bottom)
+}
+
+func shrink(size, by int) int {
+	if size == unit.Infinity {
+		return size
+	}
+	return max(size-by, 0)
+}
+
+func (n *PaddingNode) MinIntrinsicWidth(scope layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	horizontal, vertical := n.insets(scope)
+	return content.MinIntrinsicWidth(shrink(height, vertical)) + horizontal
+}
+
+func (n *PaddingNode) MaxIntrinsicWidth(scope layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	horizontal, vertical := n.insets(scope)
+	return content.MaxIntrinsicWidth(shrink(height, vertical)) + horizontal
+}
+
+func (n *PaddingNode) MinIntrinsicHeight(scope layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	horizontal, vertical := n.insets(scope)
+	return content.MinIntrinsicHeight(shrink(width, horizontal)) + vertical
+}
+
+func (n *PaddingNode) MaxIntrinsicHeight(scope layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	horizontal, vertical := n.insets(scope)
+	return content.MaxIntrinsicHeight(shrink(width, horizontal)) + vertical
+}
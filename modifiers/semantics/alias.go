@@ -0,0 +1,12 @@
+package semantics
+
+import (
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+type (
+	ChainNode = node.ChainNode
+	TreeNode  = node.TreeNode
+	Modifier  = modifier.Modifier
+)
@@ -0,0 +1,27 @@
+package semantics
+
+import (
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+type SemanticsElement struct {
+	data SemanticsData
+}
+
+func (e *SemanticsElement) Create() node.Node {
+	return NewSemanticsNode(e.data)
+}
+
+func (e *SemanticsElement) Update(n node.Node) {
+	no := n.(*SemanticsNode)
+	no.data = e.data
+}
+
+func (e *SemanticsElement) Equals(other modifier.Element) bool {
+	o, ok := other.(*SemanticsElement)
+	if !ok {
+		return false
+	}
+	return e.data == o.data
+}
@@ -0,0 +1,58 @@
+package semantics
+
+import (
+	"image"
+
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+
+	"gioui.org/io/semantic"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+)
+
+type SemanticsNode struct {
+	node.ChainNode
+	data SemanticsData
+}
+
+func NewSemanticsNode(data SemanticsData) *SemanticsNode {
+	n := &SemanticsNode{
+		data: data,
+	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindSemantics,
+		node.SemanticsPhase,
+		func(t node.TreeNode) {
+			no := t.(layoutnode.LayoutModifierNode)
+			no.AttachLayoutModifier(func(widget layoutnode.LayoutWidget) layoutnode.LayoutWidget {
+				return layoutnode.NewLayoutWidget(func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+					exposeTag := n.data.TestTag != "" && testTagsExposed(gtx)
+					if n.data.ContentDescription == "" && !exposeTag {
+						return widget.Layout(gtx)
+					}
+
+					// Semantics apply to the clip area they are added in, so
+					// the content is laid out first to know its bounds.
+					macro := op.Record(gtx.Ops)
+					dims := widget.Layout(gtx)
+					call := macro.Stop()
+
+					bounds := image.Rectangle{Max: dims.Size}
+					if exposeTag {
+						defer clip.Rect(bounds).Push(gtx.Ops).Pop()
+						semantic.DescriptionOp(testTagPrefix + n.data.TestTag).Add(gtx.Ops)
+					}
+					if n.data.ContentDescription != "" {
+						defer clip.Rect(bounds).Push(gtx.Ops).Pop()
+						semantic.DescriptionOp(n.data.ContentDescription).Add(gtx.Ops)
+					}
+					call.Add(gtx.Ops)
+					return dims
+				})
+			})
+		},
+	)
+	return n
+}
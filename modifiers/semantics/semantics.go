@@ -0,0 +1,76 @@
+package semantics
+
+import (
+	"strings"
+
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+// SemanticsData describes an element to accessibility services and tests.
+type SemanticsData struct {
+	// TestTag identifies the element in tests. It is only part of the
+	// semantics when ExposeTestTags is set.
+	TestTag string
+	// ContentDescription describes the element, typically an image or an icon,
+	// to screen readers.
+	ContentDescription string
+}
+
+// TestTag tags the element so that tests can find it.
+func TestTag(tag string) modifier.Modifier {
+	return Semantics(SemanticsData{TestTag: tag})
+}
+
+// ContentDescription describes the element to screen readers, and to tests.
+func ContentDescription(description string) modifier.Modifier {
+	return Semantics(SemanticsData{ContentDescription: description})
+}
+
+// Semantics adds the semantics of data to the element. The content of the
+// element is clipped to its bounds, which are those of its semantics.
+func Semantics(data SemanticsData) modifier.Modifier {
+	return modifier.NewInspectableModifier(
+		modifier.NewModifier(
+			&SemanticsElement{
+				data: data,
+			},
+		),
+		modifier.NewInspectorInfo(
+			"semantics",
+			map[string]any{
+				"testTag":            data.TestTag,
+				"contentDescription": data.ContentDescription,
+			},
+		),
+	)
+}
+
+const (
+	exposeTestTagsKey = "semantics.exposeTestTags"
+	testTagPrefix     = "testTag:"
+)
+
+// ExposeTestTags returns gtx with the test tags of the elements it lays out
+// made part of their semantics, as the description of a node wrapping the
+// element. Test harnesses expose the test tags to find the tagged elements in
+// the semantics of a frame.
+func ExposeTestTags(gtx layoutnode.LayoutContext) layoutnode.LayoutContext {
+	values := make(map[string]any, len(gtx.Values)+1)
+	for k, v := range gtx.Values {
+		values[k] = v
+	}
+	values[exposeTestTagsKey] = true
+	gtx.Values = values
+	return gtx
+}
+
+func testTagsExposed(gtx layoutnode.LayoutContext) bool {
+	exposed, _ := gtx.Values[exposeTestTagsKey].(bool)
+	return exposed
+}
+
+// TestTagOf returns the test tag exposed by a semantics node with description.
+func TestTagOf(description string) (string, bool) {
+	return strings.CutPrefix(description, testTagPrefix)
+}
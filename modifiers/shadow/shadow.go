@@ -2,6 +2,7 @@ package shadow
 
 import (
 	"github.com/zodimo/go-compose/compose/ui/graphics"
+	"github.com/zodimo/go-compose/compose/ui/graphics/shape"
 	"github.com/zodimo/go-compose/internal/modifier"
 )
 
@@ -12,6 +13,13 @@ type ShadowData struct {
 	SpotColor    graphics.Color
 }
 
+func CompareShadow(a, b ShadowData) bool {
+	return a.Elevation == b.Elevation &&
+		shape.EqualShape(a.Shape, b.Shape) &&
+		a.AmbientColor == b.AmbientColor &&
+		a.SpotColor == b.SpotColor
+}
+
 type ShadowElement struct {
 	shadowData ShadowData
 }
@@ -27,8 +35,7 @@ func (e *ShadowElement) Update(node Node) {
 
 func (e *ShadowElement) Equals(other Element) bool {
 	if otherEle, ok := other.(*ShadowElement); ok {
-		return e.shadowData.Elevation == otherEle.shadowData.Elevation &&
-			e.shadowData.Shape == otherEle.shadowData.Shape // Pointer comparison for shape might be enough if not changed
+		return CompareShadow(e.shadowData, otherEle.shadowData)
 	}
 	return false
 }
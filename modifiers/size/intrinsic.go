@@ -0,0 +1,162 @@
+package size
+
+import (
+	"github.com/zodimo/go-compose/compose/ui/unit"
+	node "github.com/zodimo/go-compose/internal/Node"
+	"github.com/zodimo/go-compose/internal/layoutnode"
+	"github.com/zodimo/go-compose/internal/modifier"
+)
+
+// IntrinsicSize selects the minimum or maximum intrinsic size of the content.
+type IntrinsicSize int
+
+const (
+	IntrinsicSizeMin IntrinsicSize = iota
+	IntrinsicSizeMax
+)
+
+func (s IntrinsicSize) String() string {
+	if s == IntrinsicSizeMax {
+		return "Max"
+	}
+	return "Min"
+}
+
+// IntrinsicWidth sizes the width of the content to its minimum or maximum
+// intrinsic width, for example to give the items of a column the width of the
+// widest one.
+func IntrinsicWidth(size IntrinsicSize) Modifier {
+	return intrinsicSizeModifier(intrinsicSizeData{Size: size, Width: true}, "intrinsicWidth")
+}
+
+// IntrinsicHeight sizes the height of the content to its minimum or maximum
+// intrinsic height, for example to give the children of a row the height of
+// the tallest one.
+func IntrinsicHeight(size IntrinsicSize) Modifier {
+	return intrinsicSizeModifier(intrinsicSizeData{Size: size}, "intrinsicHeight")
+}
+
+func intrinsicSizeModifier(data intrinsicSizeData, name string) Modifier {
+	return modifier.NewInspectableModifier(
+		modifier.NewModifier(
+			&IntrinsicSizeElement{data: data},
+		),
+		modifier.NewInspectorInfo(
+			name,
+			map[string]any{
+				"size": data.Size,
+			},
+		),
+	)
+}
+
+type intrinsicSizeData struct {
+	Size  IntrinsicSize
+	Width bool
+}
+
+var _ Element = (*IntrinsicSizeElement)(nil)
+
+type IntrinsicSizeElement struct {
+	data intrinsicSizeData
+}
+
+func (e *IntrinsicSizeElement) Create() Node {
+	return NewIntrinsicSizeNode(e.data)
+}
+
+func (e *IntrinsicSizeElement) Update(n Node) {
+	n.(*IntrinsicSizeNode).data = e.data
+}
+
+func (e *IntrinsicSizeElement) Equals(other Element) bool {
+	o, ok := other.(*IntrinsicSizeElement)
+	return ok && o.data == e.data
+}
+
+var _ layoutnode.IntrinsicModifierNode = (*IntrinsicSizeNode)(nil)
+
+type IntrinsicSizeNode struct {
+	ChainNode
+	data intrinsicSizeData
+}
+
+func NewIntrinsicSizeNode(data intrinsicSizeData) *IntrinsicSizeNode {
+	n := &IntrinsicSizeNode{
+		data: data,
+	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		func(t TreeNode) {
+			coordinator := t.(layoutnode.NodeCoordinator)
+			coordinator.AttachLayoutModifier(func(widget LayoutWidget) LayoutWidget {
+				return layoutnode.NewLayoutWidget(func(gtx LayoutContext) layoutnode.LayoutDimensions {
+					content := coordinator.ContentIntrinsics(gtx, n)
+					c := gtx.Constraints
+					if n.data.Width {
+						width := Clamp(n.intrinsicWidth(content, maxConstraint(c.Max.Y)), c.Min.X, c.Max.X)
+						c.Min.X, c.Max.X = width, width
+					} else {
+						height := Clamp(n.intrinsicHeight(content, maxConstraint(c.Max.X)), c.Min.Y, c.Max.Y)
+						c.Min.Y, c.Max.Y = height, height
+					}
+					gtx.Constraints = c
+					return widget.Layout(gtx)
+				})
+			})
+		},
+	)
+	return n
+}
+
+// maxConstraint converts the maximum of a Gio constraint for intrinsic queries.
+func maxConstraint(max int) int {
+	if max >= unit.GioInfinity {
+		return unit.Infinity
+	}
+	return max
+}
+
+func (n *IntrinsicSizeNode) intrinsicWidth(content layoutnode.IntrinsicMeasurable, height int) int {
+	if n.data.Size == IntrinsicSizeMax {
+		return content.MaxIntrinsicWidth(height)
+	}
+	return content.MinIntrinsicWidth(height)
+}
+
+func (n *IntrinsicSizeNode) intrinsicHeight(content layoutnode.IntrinsicMeasurable, width int) int {
+	if n.data.Size == IntrinsicSizeMax {
+		return content.MaxIntrinsicHeight(width)
+	}
+	return content.MinIntrinsicHeight(width)
+}
+
+func (n *IntrinsicSizeNode) MinIntrinsicWidth(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	if n.data.Width {
+		return n.intrinsicWidth(content, height)
+	}
+	return content.MinIntrinsicWidth(height)
+}
+
+func (n *IntrinsicSizeNode) MaxIntrinsicWidth(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	if n.data.Width {
+		return n.intrinsicWidth(content, height)
+	}
+	return content.MaxIntrinsicWidth(height)
+}
+
+func (n *IntrinsicSizeNode) MinIntrinsicHeight(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	if !n.data.Width {
+		return n.intrinsicHeight(content, width)
+	}
+	return content.MinIntrinsicHeight(width)
+}
+
+func (n *IntrinsicSizeNode) MaxIntrinsicHeight(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	if !n.data.Width {
+		return n.intrinsicHeight(content, width)
+	}
+	return content.MaxIntrinsicHeight(width)
+}
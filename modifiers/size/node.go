@@ -20,88 +20,91 @@ type SizeNode struct {
 }
 
 // NewSizeNode creates a new size node
-func NewSizeNode(sizeData SizeData) ChainNode {
-	return SizeNode{
-		ChainNode: node.NewChainNode(
-			node.NewNodeID(),
-			node.NodeKindLayout,
-			node.LayoutPhase,
-			//OnAttach
-			func(n TreeNode) {
-				// how should the tree now be updated when attached
-				// tree nde is the layout tree
-
-				no := n.(layoutnode.LayoutModifierNode)
-				// we can now work with the layoutNode
-				no.AttachLayoutModifier(func(widget layoutnode.LayoutWidget) layoutnode.LayoutWidget {
-					return layoutnode.NewLayoutWidget(
-						func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
-							// 1. Calculate constraints to pass to child.
-							childConstraints := ApplySizeDataToConstraints(gtx.Constraints, sizeData)
-
-							// 2. Measure child.
-							macro := op.Record(gtx.Ops)
-							// Create a context with modified constraints for the child
-							childGtx := gtx
-							childGtx.Constraints = childConstraints
-							childDims := widget.Layout(childGtx)
-							call := macro.Stop()
-
-							// 3. Determine my size.
-							mySize := image.Point{
-								X: childDims.Size.X,
-								Y: childDims.Size.Y,
-							}
-
-							// Handle Width overrides
-							if sizeData.Width != NotSet {
-								// Fixed width overrides child measurement
-								mySize.X = sizeData.Width
-							} else if sizeData.FillMaxWidth || sizeData.FillMax {
-								// Fill behavior uses max constraints
-								mySize.X = gtx.Constraints.Max.X
-							} else {
-								// Default/Wrap behavior: respect incoming constraints
-								// If we are wrapping, we wanted min=0 for child, but our size
-								// must still respect our parent's min constraints.
-								mySize.X = Clamp(mySize.X, gtx.Constraints.Min.X, gtx.Constraints.Max.X)
-							}
-
-							// Handle Height overrides
-							if sizeData.Height != NotSet {
-								mySize.Y = sizeData.Height
-							} else if sizeData.FillMaxHeight || sizeData.FillMax {
-								mySize.Y = gtx.Constraints.Max.Y
-							} else {
-								mySize.Y = Clamp(mySize.Y, gtx.Constraints.Min.Y, gtx.Constraints.Max.Y)
-							}
-
-							// 4. Align
-							if sizeData.Alignment != nil {
-								// Calculate offset
-								offset := sizeData.Alignment.Align(childDims.Size, mySize, layoutnode.LayoutDirectionLTR)
-
-								// Apply offset
-								// We put the offset operation before replaying the child recording
-								defer op.Offset(offset).Push(gtx.Ops).Pop()
-							}
-
-							// Add the child operations
-							call.Add(gtx.Ops)
-
-							return layout.Dimensions{
-								Size: mySize,
-								// We should probably merge baselines here if needed, but keeping simple for now
-								Baseline: childDims.Baseline,
-							}
-						},
-					)
-				})
-
-			},
-		),
+func NewSizeNode(sizeData SizeData) *SizeNode {
+	n := &SizeNode{
 		size: sizeData,
 	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		//OnAttach
+		func(t TreeNode) {
+			// how should the tree now be updated when attached
+			// tree nde is the layout tree
+
+			no := t.(layoutnode.LayoutModifierNode)
+			// we can now work with the layoutNode
+			no.AttachLayoutModifier(func(widget layoutnode.LayoutWidget) layoutnode.LayoutWidget {
+				return layoutnode.NewLayoutWidget(
+					func(gtx layoutnode.LayoutContext) layoutnode.LayoutDimensions {
+						sizeData := n.size
+
+						// 1. Calculate constraints to pass to child.
+						childConstraints := ApplySizeDataToConstraints(gtx.Constraints, sizeData)
+
+						// 2. Measure child.
+						macro := op.Record(gtx.Ops)
+						// Create a context with modified constraints for the child
+						childGtx := gtx
+						childGtx.Constraints = childConstraints
+						childDims := widget.Layout(childGtx)
+						call := macro.Stop()
+
+						// 3. Determine my size.
+						mySize := image.Point{
+							X: childDims.Size.X,
+							Y: childDims.Size.Y,
+						}
+
+						// Handle Width overrides
+						if sizeData.Width != NotSet {
+							// Fixed width overrides child measurement
+							mySize.X = sizeData.Width
+						} else if sizeData.FillMaxWidth || sizeData.FillMax {
+							// Fill behavior uses max constraints
+							mySize.X = gtx.Constraints.Max.X
+						} else {
+							// Default/Wrap behavior: respect incoming constraints
+							// If we are wrapping, we wanted min=0 for child, but our size
+							// must still respect our parent's min constraints.
+							mySize.X = Clamp(mySize.X, gtx.Constraints.Min.X, gtx.Constraints.Max.X)
+						}
+
+						// Handle Height overrides
+						if sizeData.Height != NotSet {
+							mySize.Y = sizeData.Height
+						} else if sizeData.FillMaxHeight || sizeData.FillMax {
+							mySize.Y = gtx.Constraints.Max.Y
+						} else {
+							mySize.Y = Clamp(mySize.Y, gtx.Constraints.Min.Y, gtx.Constraints.Max.Y)
+						}
+
+						// 4. Align
+						if sizeData.Alignment != nil {
+							// Calculate offset
+							offset := sizeData.Alignment.Align(childDims.Size, mySize, layoutnode.LayoutDirectionLTR)
+
+							// Apply offset
+							// We put the offset operation before replaying the child recording
+							defer op.Offset(offset).Push(gtx.Ops).Pop()
+						}
+
+						// Add the child operations
+						call.Add(gtx.Ops)
+
+						return layout.Dimensions{
+							Size: mySize,
+							// We should probably merge baselines here if needed, but keeping simple for now
+							Baseline: childDims.Baseline,
+						}
+					},
+				)
+			})
+
+		},
+	)
+	return n
 }
 
 func GetSizeConstraintsAndSizeData(constraints layout.Constraints, sizeData SizeData) image.Point {
@@ -207,3 +210,50 @@ func ApplySizeDataToConstraints(constraints layout.Constraints, sizeData SizeDat
 
 	return c
 }
+
+var _ layoutnode.IntrinsicModifierNode = (*SizeNode)(nil)
+
+// The intrinsic size of a node with a fixed size is that size; fill and wrap
+// modifiers keep the intrinsic size of their content.
+
+func (n *SizeNode) MinIntrinsicWidth(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	if n.size.Width != NotSet {
+		return n.size.Width
+	}
+	return content.MinIntrinsicWidth(n.contentHeight(height))
+}
+
+func (n *SizeNode) MaxIntrinsicWidth(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, height int) int {
+	if n.size.Width != NotSet {
+		return n.size.Width
+	}
+	return content.MaxIntrinsicWidth(n.contentHeight(height))
+}
+
+func (n *SizeNode) MinIntrinsicHeight(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	if n.size.Height != NotSet {
+		return n.size.Height
+	}
+	return content.MinIntrinsicHeight(n.contentWidth(width))
+}
+
+func (n *SizeNode) MaxIntrinsicHeight(_ layoutnode.MeasureScope, content layoutnode.IntrinsicMeasurable, width int) int {
+	if n.size.Height != NotSet {
+		return n.size.Height
+	}
+	return content.MaxIntrinsicHeight(n.contentWidth(width))
+}
+
+func (n *SizeNode) contentWidth(width int) int {
+	if n.size.Width != NotSet {
+		return n.size.Width
+	}
+	return width
+}
+
+func (n *SizeNode) contentHeight(height int) int {
+	if n.size.Height != NotSet {
+		return n.size.Height
+	}
+	return height
+}
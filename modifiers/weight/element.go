@@ -23,7 +23,7 @@ func (e WeightElement) Update(node Node) {
 		panic("node cannot be nil")
 	}
 
-	bn := node.(WeightNode)
+	bn := node.(*WeightNode)
 	bn.weight = e.weight
 
 }
@@ -31,7 +31,7 @@ func (e WeightElement) Update(node Node) {
 // Equals checks if this element is equivalent to another
 // used during filter operations like Modifier.Any
 func (se WeightElement) Equals(other Element) bool {
-	if otherElement, ok := other.(WeightElement); ok {
+	if otherElement, ok := other.(*WeightElement); ok {
 		return CompareWeight(se.weight, otherElement.weight)
 	}
 	return false
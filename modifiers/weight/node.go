@@ -16,26 +16,27 @@ type WeightNode struct {
 	weight WeightData
 }
 
-func NewWeightNode(element WeightElement) ChainNode {
-	return WeightNode{
-		ChainNode: node.NewChainNode(
-			node.NewNodeID(),
-			node.NodeKindLayout,
-			node.LayoutPhase,
-			//OnAttach
-			func(n TreeNode) {
-				// how should the tree now be updated when attached
-				// tree nde is the layout tree
-
-				no := n.(layoutnode.ParentDataModifierNode)
-				// we can now work with the layoutNode
-
-				no.AttachParentDataModifier(func(store layoutnode.ElementStore) layoutnode.ElementStore {
-					return store.SetElement(WeightElementKey, element)
-				})
-
-			},
-		),
+func NewWeightNode(element WeightElement) *WeightNode {
+	n := &WeightNode{
 		weight: element.weight,
 	}
+	n.ChainNode = node.NewChainNode(
+		node.NewNodeID(),
+		node.NodeKindLayout,
+		node.LayoutPhase,
+		//OnAttach
+		func(t TreeNode) {
+			// how should the tree now be updated when attached
+			// tree nde is the layout tree
+
+			no := t.(layoutnode.ParentDataModifierNode)
+			// we can now work with the layoutNode
+
+			no.AttachParentDataModifier(func(store layoutnode.ElementStore) layoutnode.ElementStore {
+				return store.SetElement(WeightElementKey, WeightElement{weight: n.weight})
+			})
+
+		},
+	)
+	return n
 }
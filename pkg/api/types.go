@@ -40,6 +40,7 @@ type Composer interface {
 	GioLayoutNodeAwareComposer
 
 	state.SupportState
+	state.SupportSaveableState
 
 	WithComposable(composable Composable) Composer
 
@@ -52,6 +53,34 @@ type Composer interface {
 	// Control Flow
 	Key(key any, content Composable) Composable
 	Range(count int, fn func(int) Composable) Composable
+
+	// -- Positional memoization
+	// Changed reports whether value differs from the value passed at the same
+	// position of the current group in the previous frame.
+	Changed(value any) bool
+	// Skippable reuses the previous composition of content while inputs are unchanged.
+	Skippable(key string, content Composable, inputs ...any) Composable
+
+	// -- Effects
+	// SideEffect schedules effect to run once the current composition has been applied.
+	SideEffect(effect func())
+
+	// -- Subcomposition
+	// Subcomposition returns the subcomposition remembered by the current group.
+	// It composes content while the group's node is laid out, with the
+	// composition locals of the group, and is disposed with the group.
+	Subcomposition() Subcomposition
+}
+
+// Subcomposition composes content apart from the composition it belongs to,
+// typically during layout, once the content depends on a measured size.
+// Each slot is a separate composition that keeps its state between frames.
+type Subcomposition interface {
+	// Compose composes content as the slot slotID and returns the root of the
+	// nodes it emitted.
+	Compose(slotID any, content Composable) LayoutNode
+	// Dispose disposes the slot slotID, forgetting its remembered values and state.
+	Dispose(slotID any)
 }
 
 type LayoutNode = layoutnode.LayoutNode
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/zodimo/go-compose/compose/effect"
+	"github.com/zodimo/go-compose/compose/frameclock"
 	"github.com/zodimo/go-compose/pkg/api"
 	"github.com/zodimo/go-compose/state"
 	"github.com/zodimo/go-compose/store"
@@ -17,9 +18,13 @@ func CollectAsState[T any](c api.Composer, flow Flow[T], initial T) state.TypedV
 
 	// 2. Launch a side-effect to collect the flow
 	// We use the flow itself as a key so if the flow instance changes, we resubscribe
+	// The collector waits on the flow, not on the frame clock, so tests do not
+	// wait for it to finish.
 	effect.LaunchedEffect(func(ctx context.Context) {
-		flow.Collect(ctx, func(value T) {
-			stateValue.Set(value)
+		frameclock.Await(ctx, func() {
+			flow.Collect(ctx, func(value T) {
+				stateValue.Set(value)
+			})
 		})
 	}, flow)(c)
 
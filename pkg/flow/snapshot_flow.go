@@ -0,0 +1,77 @@
+package flow
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/zodimo/go-compose/state"
+)
+
+// SnapshotFlow creates a flow of the values returned by block.
+//
+// block runs when the flow is collected and again every time a state it read
+// is changed; a value is emitted only when it differs from the previous one.
+// It is the counterpart of CollectAsState: it lets state such as a text field
+// value or a scroll position go through flow operators such as Map.
+func SnapshotFlow[T any](block func() T) Flow[T] {
+	return NewFlow(func(ctx context.Context, emit func(T)) error {
+		var mu sync.Mutex
+		reads := map[state.StateObject]struct{}{}
+		dirty := false
+		wake := make(chan struct{}, 1)
+
+		unregister := state.RegisterGlobalWriteObserver(func(obj state.StateObject) {
+			mu.Lock()
+			_, read := reads[obj]
+			if read {
+				dirty = true
+			}
+			mu.Unlock()
+			if read {
+				select {
+				case wake <- struct{}{}:
+				default:
+				}
+			}
+		})
+		defer unregister()
+
+		var last T
+		emitted := false
+		for {
+			// Reads are recorded as they happen so a write racing with block
+			// still marks the flow dirty.
+			mu.Lock()
+			reads = map[state.StateObject]struct{}{}
+			dirty = false
+			mu.Unlock()
+
+			var value T
+			state.ObserveReads(func() {
+				value = block()
+			}, func(obj state.StateObject) {
+				mu.Lock()
+				reads[obj] = struct{}{}
+				mu.Unlock()
+			})
+
+			if !emitted || !reflect.DeepEqual(last, value) {
+				emit(value)
+				last, emitted = value, true
+			}
+
+			mu.Lock()
+			again := dirty
+			mu.Unlock()
+			if again {
+				continue
+			}
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-wake:
+			}
+		}
+	})
+}
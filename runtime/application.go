@@ -0,0 +1,165 @@
+package runtime
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/compose/frameclock"
+	"github.com/zodimo/go-compose/pkg/api"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+	"github.com/zodimo/go-compose/theme"
+
+	"gioui.org/app"
+	"gioui.org/op"
+)
+
+// Application hosts the windows of a program:
+//
+//	func main() {
+//		runtime.NewApplication().
+//			Window(UI, runtime.WithTitle("Demo")).
+//			Run()
+//	}
+type Application interface {
+	// Window opens a window showing content, with its own event loop.
+	Window(content api.Composable, options ...WindowOption) Application
+	// Run runs the platform event loop and must be called from the main
+	// goroutine. It exits the program once every window is closed, with status 1
+	// if one of them failed.
+	Run()
+}
+
+var _ Application = (*application)(nil)
+
+type application struct {
+	windows sync.WaitGroup
+	mu      sync.Mutex
+	failed  bool
+}
+
+func NewApplication() Application {
+	return &application{}
+}
+
+func (a *application) Window(content api.Composable, options ...WindowOption) Application {
+	a.windows.Add(1)
+	go func() {
+		defer a.windows.Done()
+		if err := RunWindow(content, options...); err != nil {
+			log.Print(err)
+			a.mu.Lock()
+			a.failed = true
+			a.mu.Unlock()
+		}
+	}()
+	return a
+}
+
+func (a *application) Run() {
+	go func() {
+		a.windows.Wait()
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		if a.failed {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}()
+	app.Main()
+}
+
+// ErrStoreWithSavedStatePath is returned by RunWindow when both a Store and a
+// SavedStatePath are set: the registry of a store supplied by the caller is
+// restored and saved by the caller.
+var ErrStoreWithSavedStatePath = errors.New("runtime: SavedStatePath is set with a Store; restore and save the registry of the store instead")
+
+// RunWindow opens a window showing content and runs its event loop on the
+// calling goroutine until the window is closed. The composition is driven by
+// state changes: a frame is only requested when state read by content changes.
+//
+// On shutdown the saveable state is saved, if a SavedStatePath is set, and the
+// composition is disposed, which cancels the effects still running.
+func RunWindow(content api.Composable, options ...WindowOption) error {
+	opts := DefaultWindowOptions()
+	for _, option := range options {
+		option(&opts)
+	}
+	if opts.Store != nil && opts.SavedStatePath != "" {
+		return ErrStoreWithSavedStatePath
+	}
+
+	window := new(app.Window)
+	window.Option(app.Title(opts.Title), app.Size(opts.Width, opts.Height))
+	window.Option(opts.AppOptions...)
+
+	ps, ownStore := opts.Store, opts.Store == nil
+	if ownStore {
+		ps = store.NewPersistentState(map[string]state.MutableValue{}, store.WithSaveableStateRegistry(restoreSavedState(opts.SavedStatePath)))
+	}
+	ps.SetOnStateChange(window.Invalidate)
+
+	// The frame clock runs on the time of the frames of the window, which
+	// invalidates itself when animations or effects wait on the clock.
+	clock := frameclock.NewBroadcastFrameClock(func(deadline time.Time) {
+		if deadline.IsZero() {
+			window.Invalidate()
+			return
+		}
+		time.AfterFunc(time.Until(deadline), window.Invalidate)
+	})
+	content = compose.CompositionLocalProvider1(frameclock.LocalFrameClock, frameclock.MonotonicFrameClock(clock), content)
+
+	rt := NewRuntime()
+	themeManager := theme.GetThemeManager()
+	var ops op.Ops
+	for {
+		switch frameEvent := window.Event().(type) {
+		case app.DestroyEvent:
+			err := frameEvent.Err
+			if opts.SavedStatePath != "" {
+				if saveErr := ps.SaveableStateRegistry().SaveFile(opts.SavedStatePath); saveErr != nil {
+					err = errors.Join(err, fmt.Errorf("save state: %w", saveErr))
+				}
+			}
+			ps.SetOnStateChange(nil)
+			rt.Dispose()
+			compose.DisposeComposition(ps)
+			if ownStore {
+				ps.Observer().Stop()
+			}
+			return err
+		case app.FrameEvent:
+			clock.SendFrame(frameEvent.Now)
+			gtx := app.NewContext(&ops, frameEvent)
+			gtx.Locale = opts.Locale
+			gtx = themeManager.Material3ThemeInit(gtx)
+			gtx = frameclock.Provide(gtx, clock)
+
+			layoutNode := compose.Compose(ps, content)
+
+			rt.Run(gtx, layoutNode).Add(gtx.Ops)
+			frameEvent.Frame(gtx.Ops)
+		}
+	}
+}
+
+// restoreSavedState reads the saveable state of a previous run. A file that
+// cannot be read is reported and replaced at shutdown, rather than keeping the
+// program from starting.
+func restoreSavedState(path string) state.SaveableStateRegistry {
+	if path == "" {
+		return state.NewSaveableStateRegistry()
+	}
+	registry, err := state.RestoreSaveableStateRegistryFile(path)
+	if err != nil {
+		log.Printf("discarding saved state: %v", err)
+		return state.NewSaveableStateRegistry()
+	}
+	return registry
+}
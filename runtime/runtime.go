@@ -1,7 +1,11 @@
 package runtime
 
-import "gioui.org/op"
+import "github.com/zodimo/go-compose/internal/frame"
 
-type Runtime interface {
-	Run(LayoutContext, LayoutNode) op.CallOp
+// Runtime lays out and draws the frames of a composition. It does not depend on
+// a window, so that compositions can be rendered without a display.
+type Runtime = frame.Runtime
+
+func NewRuntime() Runtime {
+	return frame.NewRuntime()
 }
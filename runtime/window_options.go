@@ -0,0 +1,73 @@
+package runtime
+
+import (
+	"github.com/zodimo/go-compose/state"
+
+	"gioui.org/app"
+	"gioui.org/io/system"
+	"gioui.org/unit"
+)
+
+type WindowOption func(*WindowOptions)
+
+type WindowOptions struct {
+	Title  string
+	Width  unit.Dp
+	Height unit.Dp
+	Locale system.Locale
+	// Store keeps the state of the window. By default the window creates its own.
+	Store state.PersistentState
+	// SavedStatePath is the file the saveable state of the window is restored
+	// from at start and saved to at shutdown. Empty disables saving. It is only
+	// used with the store of the window: the caller of a Store restores its
+	// registry, with store.WithSaveableStateRegistry, and saves it.
+	SavedStatePath string
+	// AppOptions are passed to the Gio window as they are.
+	AppOptions []app.Option
+}
+
+func DefaultWindowOptions() WindowOptions {
+	return WindowOptions{
+		Title:  "go-compose",
+		Width:  unit.Dp(800),
+		Height: unit.Dp(600),
+		Locale: system.Locale{Language: "en", Direction: system.LTR},
+	}
+}
+
+func WithTitle(title string) WindowOption {
+	return func(o *WindowOptions) {
+		o.Title = title
+	}
+}
+
+func WithSize(width, height unit.Dp) WindowOption {
+	return func(o *WindowOptions) {
+		o.Width = width
+		o.Height = height
+	}
+}
+
+func WithLocale(locale system.Locale) WindowOption {
+	return func(o *WindowOptions) {
+		o.Locale = locale
+	}
+}
+
+func WithStore(store state.PersistentState) WindowOption {
+	return func(o *WindowOptions) {
+		o.Store = store
+	}
+}
+
+func WithSavedStatePath(path string) WindowOption {
+	return func(o *WindowOptions) {
+		o.SavedStatePath = path
+	}
+}
+
+func WithAppOptions(options ...app.Option) WindowOption {
+	return func(o *WindowOptions) {
+		o.AppOptions = append(o.AppOptions, options...)
+	}
+}
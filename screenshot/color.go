@@ -0,0 +1,64 @@
+package screenshot
+
+import (
+	"image/color"
+	"math"
+)
+
+// rgba is a color in linear light, premultiplied by its alpha. Gio blends in
+// linear light, so the renderer does too.
+type rgba [4]float32
+
+// srgbToLinear maps the 8-bit sRGB values to linear light.
+var srgbToLinear = func() (table [256]float32) {
+	for i := range table {
+		c := float64(i) / 255
+		if c <= 0.04045 {
+			table[i] = float32(c / 12.92)
+		} else {
+			table[i] = float32(math.Pow((c+0.055)/1.055, 2.4))
+		}
+	}
+	return table
+}()
+
+// linearToSRGB maps a value in linear light to 8-bit sRGB.
+func linearToSRGB(c float32) uint8 {
+	switch {
+	case c <= 0:
+		return 0
+	case c >= 1:
+		return 255
+	case c <= 0.0031308:
+		c *= 12.92
+	default:
+		c = float32(1.055*math.Pow(float64(c), 1/2.4) - 0.055)
+	}
+	return uint8(c*255 + .5)
+}
+
+// linearFromNRGBA converts a color of a ColorOp or LinearGradientOp.
+func linearFromNRGBA(c color.NRGBA) rgba {
+	a := float32(c.A) / 255
+	return rgba{srgbToLinear[c.R] * a, srgbToLinear[c.G] * a, srgbToLinear[c.B] * a, a}
+}
+
+// linearFromRGBA converts a pixel of an ImageOp. Gio samples images as sRGB
+// textures, converting their premultiplied values as they are.
+func linearFromRGBA(r, g, b, a uint8) rgba {
+	return rgba{srgbToLinear[r], srgbToLinear[g], srgbToLinear[b], float32(a) / 255}
+}
+
+func (c rgba) scale(s float32) rgba {
+	return rgba{c[0] * s, c[1] * s, c[2] * s, c[3] * s}
+}
+
+// lerp returns the color at t between c and d.
+func (c rgba) lerp(d rgba, t float32) rgba {
+	return rgba{
+		c[0] + (d[0]-c[0])*t,
+		c[1] + (d[1]-c[1])*t,
+		c[2] + (d[2]-c[2])*t,
+		c[3] + (d[3]-c[3])*t,
+	}
+}
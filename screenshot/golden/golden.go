@@ -0,0 +1,209 @@
+// Package golden compares screenshots with golden images checked in with the
+// tests:
+//
+//	func TestCounter(t *testing.T) {
+//		rule := composetest.NewComposeTestRule(t)
+//		rule.SetContent(Counter())
+//		golden.Assert(t, "counter", rule.CaptureToImage())
+//	}
+//
+// The golden images are PNG files in the testdata directory of the package,
+// written by running the tests of the package with the -update flag:
+//
+//	go test ./cmd/demo/chip -update
+//
+// When a screenshot differs from its golden image, the screenshot and an image
+// of the differences are written to the failures directory of testdata.
+package golden
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "write the screenshots of golden.Assert as golden images")
+
+// GoldenOption is a functional option for configuring Assert.
+type GoldenOption func(*GoldenOptions)
+
+type GoldenOptions struct {
+	// Dir is the directory of the golden images.
+	Dir string
+	// Tolerance is the largest difference between a channel of a pixel and
+	// that of the golden image for the pixels to match, for screenshots that
+	// vary slightly between machines.
+	Tolerance uint8
+	// MaxDiffPixels is the number of pixels that may differ.
+	MaxDiffPixels int
+}
+
+func DefaultGoldenOptions() GoldenOptions {
+	return GoldenOptions{
+		Dir: "testdata",
+	}
+}
+
+func WithDir(dir string) GoldenOption {
+	return func(o *GoldenOptions) {
+		o.Dir = dir
+	}
+}
+
+func WithTolerance(tolerance uint8) GoldenOption {
+	return func(o *GoldenOptions) {
+		o.Tolerance = tolerance
+	}
+}
+
+func WithMaxDiffPixels(pixels int) GoldenOption {
+	return func(o *GoldenOptions) {
+		o.MaxDiffPixels = pixels
+	}
+}
+
+// Assert compares img with the golden image name, failing the test when they
+// differ. With the -update flag, img is written as the golden image instead.
+func Assert(t testing.TB, name string, img image.Image, options ...GoldenOption) {
+	t.Helper()
+	opts := DefaultGoldenOptions()
+	for _, option := range options {
+		if option == nil {
+			continue
+		}
+		option(&opts)
+	}
+
+	path := filepath.Join(opts.Dir, name+".png")
+	if *update {
+		if err := writePNG(path, img); err != nil {
+			t.Fatalf("golden: %v", err)
+		}
+		t.Logf("golden: wrote %s", path)
+		return
+	}
+
+	want, err := readPNG(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden: no golden image %s; run the test with -update to write it", path)
+	}
+	if err != nil {
+		t.Fatalf("golden: %v", err)
+	}
+
+	// The golden image went through PNG, which stores colors unpremultiplied,
+	// so img does too before they are compared.
+	got, err := roundTrip(img)
+	if err != nil {
+		t.Fatalf("golden: %v", err)
+	}
+
+	failures := filepath.Join(opts.Dir, "failures")
+	actualPath := filepath.Join(failures, name+".png")
+	diffPath := filepath.Join(failures, name+".diff.png")
+	diff, count := Compare(want, got, opts.Tolerance)
+	if count <= opts.MaxDiffPixels {
+		// The images of an earlier failure are stale.
+		os.Remove(actualPath)
+		os.Remove(diffPath)
+		os.Remove(failures)
+		return
+	}
+	if err := writePNG(actualPath, img); err != nil {
+		t.Errorf("golden: %v", err)
+	}
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Errorf("golden: %v", err)
+	}
+	if want.Bounds().Size() != img.Bounds().Size() {
+		t.Fatalf("golden: %s is %v, want %v; wrote %s", name, img.Bounds().Size(), want.Bounds().Size(), actualPath)
+	}
+	t.Fatalf("golden: %s differs from %s in %d pixels; wrote %s and %s", name, path, count, actualPath, diffPath)
+}
+
+// Compare returns an image of the differences between want and got, and the
+// number of pixels that differ by more than tolerance in a channel. The
+// differing pixels are red, over a faded copy of want. Pixels outside either
+// image differ.
+func Compare(want, got image.Image, tolerance uint8) (*image.RGBA, int) {
+	wb, gb := want.Bounds(), got.Bounds()
+	size := image.Pt(max(wb.Dx(), gb.Dx()), max(wb.Dy(), gb.Dy()))
+	diff := image.NewRGBA(image.Rectangle{Max: size})
+	count := 0
+	for y := range size.Y {
+		for x := range size.X {
+			wp, gp := image.Pt(x, y).Add(wb.Min), image.Pt(x, y).Add(gb.Min)
+			if !wp.In(wb) || !gp.In(gb) {
+				diff.SetRGBA(x, y, color.RGBA{R: 0xff, A: 0xff})
+				count++
+				continue
+			}
+			w := color.RGBAModel.Convert(want.At(wp.X, wp.Y)).(color.RGBA)
+			g := color.RGBAModel.Convert(got.At(gp.X, gp.Y)).(color.RGBA)
+			if differs(w, g, tolerance) {
+				diff.SetRGBA(x, y, color.RGBA{R: 0xff, A: 0xff})
+				count++
+				continue
+			}
+			// A faded gray of the pixel, for context.
+			l := uint8((uint16(w.R) + uint16(w.G) + uint16(w.B)) / 3 / 4)
+			diff.SetRGBA(x, y, color.RGBA{R: 0xc0 + l, G: 0xc0 + l, B: 0xc0 + l, A: 0xff})
+		}
+	}
+	return diff, count
+}
+
+func differs(a, b color.RGBA, tolerance uint8) bool {
+	channel := func(x, y uint8) bool {
+		if x > y {
+			x, y = y, x
+		}
+		return y-x > tolerance
+	}
+	return channel(a.R, b.R) || channel(a.G, b.G) || channel(a.B, b.B) || channel(a.A, b.A)
+}
+
+// roundTrip returns img as encoded and decoded by PNG.
+func roundTrip(img image.Image) (image.Image, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, err
+	}
+	return png.Decode(&buf)
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return img, nil
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+	return f.Close()
+}
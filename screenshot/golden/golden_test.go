@@ -0,0 +1,107 @@
+package golden
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func square(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := range 4 {
+		for x := range 4 {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestCompareTolerance(t *testing.T) {
+	want := square(color.RGBA{R: 100, A: 255})
+	got := square(color.RGBA{R: 100, A: 255})
+	got.SetRGBA(1, 1, color.RGBA{R: 103, A: 255})
+	got.SetRGBA(2, 2, color.RGBA{R: 110, A: 255})
+
+	if _, count := Compare(want, got, 0); count != 2 {
+		t.Errorf("%d pixels differ without tolerance, want 2", count)
+	}
+	diff, count := Compare(want, got, 5)
+	if count != 1 {
+		t.Errorf("%d pixels differ with a tolerance of 5, want 1", count)
+	}
+	if c := diff.RGBAAt(2, 2); c != (color.RGBA{R: 0xff, A: 0xff}) {
+		t.Errorf("differing pixel is %v in the diff, want red", c)
+	}
+	if _, count := Compare(want, image.NewRGBA(image.Rect(0, 0, 4, 5)), 255); count != 4 {
+		t.Errorf("%d pixels differ with an image a row larger, want 4", count)
+	}
+}
+
+// fatalTB records the failures of Assert, which stop it as t.Fatalf would.
+type fatalTB struct {
+	testing.TB
+	failures []string
+}
+
+type fatal struct{}
+
+func (t *fatalTB) Helper() {}
+
+func (t *fatalTB) Logf(string, ...any) {}
+
+func (t *fatalTB) Errorf(format string, args ...any) {
+	t.failures = append(t.failures, fmt.Sprintf(format, args...))
+}
+
+func (t *fatalTB) Fatalf(format string, args ...any) {
+	t.Errorf(format, args...)
+	panic(fatal{})
+}
+
+func (t *fatalTB) assert(name string, img image.Image, options ...GoldenOption) {
+	defer func() {
+		if r := recover(); r != nil && r != (fatal{}) {
+			panic(r)
+		}
+	}()
+	Assert(t, name, img, options...)
+}
+
+func TestAssertUpdatesAndWritesFailures(t *testing.T) {
+	dir := t.TempDir()
+	tb := &fatalTB{TB: t}
+
+	tb.assert("square", square(color.RGBA{G: 255, A: 255}), WithDir(dir))
+	if len(tb.failures) != 1 {
+		t.Fatalf("missing golden image reported %d failures, want 1", len(tb.failures))
+	}
+
+	*update = true
+	tb.assert("square", square(color.RGBA{G: 255, A: 255}), WithDir(dir))
+	*update = false
+	tb.assert("square", square(color.RGBA{G: 255, A: 255}), WithDir(dir))
+	if len(tb.failures) != 1 {
+		t.Fatalf("matching golden image reported failures: %q", tb.failures[1:])
+	}
+
+	tb.assert("square", square(color.RGBA{B: 255, A: 255}), WithDir(dir))
+	if len(tb.failures) != 2 {
+		t.Fatalf("differing image reported %d failures, want 1", len(tb.failures)-1)
+	}
+	for _, name := range []string{"square.png", "square.diff.png"} {
+		if _, err := os.Stat(filepath.Join(dir, "failures", name)); err != nil {
+			t.Errorf("failure image not written: %v", err)
+		}
+	}
+
+	tb.assert("square", square(color.RGBA{B: 255, A: 255}), WithDir(dir), WithMaxDiffPixels(16))
+	if len(tb.failures) != 2 {
+		t.Fatalf("image within MaxDiffPixels reported failures: %q", tb.failures[2:])
+	}
+	if _, err := os.Stat(filepath.Join(dir, "failures", "square.png")); !os.IsNotExist(err) {
+		t.Errorf("stale failure image kept: %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package screenshot
+
+import (
+	"encoding/binary"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"unsafe"
+
+	"gioui.org/op"
+)
+
+// Gio keeps the encoding of its operations internal. The reader below
+// mirrors the encoding of gioui.org v0.9.0, the version in go.mod, and
+// checks the layout of the operation lists it reads, so that an upgrade of
+// Gio that changes them fails loudly rather than drawing garbage.
+
+// gioVersion is the version of gioui.org whose encoding the reader mirrors.
+// Upgrading Gio requires checking the encoding, opType and opProps below
+// against the new version, then updating gioVersion.
+const gioVersion = "v0.9.0"
+
+// checkGioVersion fails with a clear message when the binary is built with
+// another version of gioui.org than gioVersion. Binaries without build
+// information are not checked.
+var checkGioVersion = sync.OnceValue(func() error {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != "gioui.org" {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		if dep.Version != gioVersion {
+			return fmt.Errorf("screenshot: built with gioui.org %s, but the operation reader of screenshot/ops.go mirrors %s; update it for the new version", dep.Version, gioVersion)
+		}
+	}
+	return nil
+})
+
+// opType is the type of an encoded operation, the first byte of its data.
+type opType byte
+
+const (
+	typeMacro opType = iota + 200
+	typeCall
+	typeDefer
+	typeTransform
+	typePopTransform
+	typePushOpacity
+	typePopOpacity
+	typeImage
+	typePaint
+	typeColor
+	typeLinearGradient
+	typePass
+	typePopPass
+	typeInput
+	typeKeyInputHint
+	typeSave
+	typeLoad
+	typeAux
+	typeClip
+	typePopClip
+	typeCursor
+	typePath
+	typeStroke
+	typeSemanticLabel
+	typeSemanticDesc
+	typeSemanticClass
+	typeSemanticSelected
+	typeSemanticEnabled
+	typeActionInput
+)
+
+// opProps holds the size of the data and the number of references of each
+// type of operation.
+var opProps = map[opType]struct{ size, refs uint32 }{
+	typeMacro:            {1 + 4 + 4, 0},
+	typeCall:             {1 + 4 + 4 + 4 + 4, 1},
+	typeDefer:            {1, 0},
+	typeTransform:        {1 + 1 + 4*6, 0},
+	typePopTransform:     {1, 0},
+	typePushOpacity:      {1 + 4, 0},
+	typePopOpacity:       {1, 0},
+	typeImage:            {1 + 1, 2},
+	typePaint:            {1, 0},
+	typeColor:            {1 + 4, 0},
+	typeLinearGradient:   {1 + 8*2 + 4*2, 0},
+	typePass:             {1, 0},
+	typePopPass:          {1, 0},
+	typeInput:            {1, 1},
+	typeKeyInputHint:     {1 + 1, 1},
+	typeSave:             {1 + 4, 0},
+	typeLoad:             {1 + 4, 0},
+	typeAux:              {1, 0},
+	typeClip:             {1 + 4*4 + 1 + 1, 0},
+	typePopClip:          {1, 0},
+	typeCursor:           {2, 0},
+	typePath:             {8 + 1, 0},
+	typeStroke:           {1 + 4, 0},
+	typeSemanticLabel:    {1, 1},
+	typeSemanticDesc:     {1, 1},
+	typeSemanticClass:    {2, 0},
+	typeSemanticSelected: {2, 0},
+	typeSemanticEnabled:  {2, 0},
+	typeActionInput:      {1 + 1, 0},
+}
+
+// opList is the encoded operations of an operation list.
+type opList struct {
+	data []byte
+	refs []any
+}
+
+var (
+	byteSliceType = reflect.TypeOf([]byte(nil))
+	anySliceType  = reflect.TypeOf([]any(nil))
+)
+
+// listOf returns the encoded operations of internal, the internal operation
+// list of an op.Ops, as referenced by the operations calling its macros.
+func listOf(internal any) opList {
+	v := reflect.ValueOf(internal)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		panic(fmt.Sprintf("screenshot: unsupported operation list %T", internal))
+	}
+	v = v.Elem()
+	data, refs := v.FieldByName("data"), v.FieldByName("refs")
+	if !data.IsValid() || data.Type() != byteSliceType || !refs.IsValid() || refs.Type() != anySliceType {
+		panic(fmt.Sprintf("screenshot: unsupported operation list %v, from another version of Gio", v.Type()))
+	}
+	return opList{
+		data: *(*[]byte)(unsafe.Pointer(data.UnsafeAddr())),
+		refs: *(*[]any)(unsafe.Pointer(refs.UnsafeAddr())),
+	}
+}
+
+// pc is the position of an operation in an operation list.
+type pc struct {
+	data, refs uint32
+}
+
+// encodedOp is an operation read by an opReader.
+type encodedOp struct {
+	data []byte
+	refs []any
+}
+
+func (o encodedOp) typ() opType {
+	return opType(o.data[0])
+}
+
+// opReader reads the operations of a call, following the macros it calls and
+// reading the deferred ones at the end, as Gio does when drawing a frame.
+type opReader struct {
+	list  opList
+	pc    pc
+	end   pc
+	stack []readerFrame
+	// deferred holds the calls deferred with op.Defer, read once the others
+	// are.
+	deferred []readerFrame
+}
+
+// readerFrame is a range of operations of a list: a macro to read, or the
+// rest of the operations to read at its end.
+type readerFrame struct {
+	list    opList
+	pc, end pc
+}
+
+func newOpReader(call op.CallOp) *opReader {
+	if err := checkGioVersion(); err != nil {
+		panic(err)
+	}
+	var ops op.Ops
+	call.Add(&ops)
+	list := listOf(&ops.Internal)
+	return &opReader{list: list, end: pc{uint32(len(list.data)), uint32(len(list.refs))}}
+}
+
+func (r *opReader) decode() (encodedOp, bool) {
+	deferring := false
+	for {
+		if r.pc == r.end {
+			if n := len(r.stack); n > 0 {
+				frame := r.stack[n-1]
+				r.stack = r.stack[:n-1]
+				r.list, r.pc, r.end = frame.list, frame.pc, frame.end
+				continue
+			}
+			if len(r.deferred) == 0 {
+				return encodedOp{}, false
+			}
+			call := r.deferred[0]
+			r.deferred = r.deferred[1:]
+			r.list, r.pc, r.end = call.list, call.pc, call.end
+			continue
+		}
+
+		t := opType(r.list.data[r.pc.data])
+		props, ok := opProps[t]
+		if !ok {
+			panic(fmt.Sprintf("screenshot: unknown operation %d, from another version of Gio", t))
+		}
+		o := encodedOp{
+			data: r.list.data[r.pc.data : r.pc.data+props.size],
+			refs: r.list.refs[r.pc.refs : r.pc.refs+props.refs],
+		}
+		next := pc{r.pc.data + props.size, r.pc.refs + props.refs}
+
+		switch t {
+		case typeDefer:
+			deferring = true
+			r.pc = next
+			continue
+		case typeAux:
+			// The data of an auxiliary operation is the rest of the macro
+			// holding it.
+			o.data = r.list.data[r.pc.data:r.end.data]
+			next = r.end
+		case typeMacro:
+			// A macro is skipped where it is recorded, and read where it is
+			// called.
+			end := pc{le.Uint32(o.data[1:]), le.Uint32(o.data[5:])}
+			if end == (pc{}) {
+				end = r.end
+			}
+			r.pc = end
+			continue
+		case typeCall:
+			frame := readerFrame{
+				list: listOf(o.refs[0]),
+				pc:   pc{le.Uint32(o.data[1:]), le.Uint32(o.data[5:])},
+				end:  pc{le.Uint32(o.data[9:]), le.Uint32(o.data[13:])},
+			}
+			r.pc = next
+			if deferring {
+				deferring = false
+				r.deferred = append(r.deferred, frame)
+				continue
+			}
+			r.stack = append(r.stack, readerFrame{list: r.list, pc: r.pc, end: r.end})
+			r.list, r.pc, r.end = frame.list, frame.pc, frame.end
+			continue
+		}
+		r.pc = next
+		return o, true
+	}
+}
+
+var le = binary.LittleEndian
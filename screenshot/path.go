@@ -0,0 +1,239 @@
+package screenshot
+
+import (
+	"image"
+	"image/draw"
+	"math"
+
+	"gioui.org/f32"
+	"golang.org/x/image/vector"
+)
+
+// The commands of a path, as encoded by clip.Path: the contour of the
+// command, then the command itself.
+const (
+	commandSize = 4 + 9*4
+
+	commandLine  = 1
+	commandQuad  = 2
+	commandCubic = 3
+	commandGap   = 11
+)
+
+// segment is a line, a quadratic or a cubic Bézier curve, by its points.
+type segment struct {
+	pts [4]f32.Point
+	n   int
+}
+
+func (s segment) from() f32.Point { return s.pts[0] }
+func (s segment) to() f32.Point   { return s.pts[s.n-1] }
+
+// contour is a connected sequence of segments.
+type contour []segment
+
+// decodePath returns the contours of the commands of a clip.Path. The gaps
+// closing contours that were left open are kept when filling, and dropped when
+// stroking.
+func decodePath(data []byte, gaps bool) []contour {
+	var contours []contour
+	current := ^uint32(0)
+	for ; len(data) >= commandSize; data = data[commandSize:] {
+		id := le.Uint32(data)
+		var cmd [9]float32
+		for i := range cmd {
+			cmd[i] = math.Float32frombits(le.Uint32(data[4+4*i:]))
+		}
+		var s segment
+		switch le.Uint32(data[4:]) {
+		case commandLine:
+			s.n = 2
+		case commandGap:
+			if !gaps {
+				continue
+			}
+			s.n = 2
+		case commandQuad:
+			s.n = 3
+		case commandCubic:
+			s.n = 4
+		default:
+			continue
+		}
+		for i := range s.n {
+			s.pts[i] = f32.Pt(cmd[1+2*i], cmd[2+2*i])
+		}
+		if id != current || len(contours) == 0 {
+			contours = append(contours, nil)
+			current = id
+		}
+		contours[len(contours)-1] = append(contours[len(contours)-1], s)
+	}
+	return contours
+}
+
+// rectPath returns the contour of r.
+func rectPath(r image.Rectangle) []contour {
+	lo, hi := f32.Pt(float32(r.Min.X), float32(r.Min.Y)), f32.Pt(float32(r.Max.X), float32(r.Max.Y))
+	return []contour{polygon(lo, f32.Pt(hi.X, lo.Y), hi, f32.Pt(lo.X, hi.Y))}
+}
+
+// polygon returns the closed contour through pts.
+func polygon(pts ...f32.Point) contour {
+	c := make(contour, len(pts))
+	for i, p := range pts {
+		c[i] = segment{pts: [4]f32.Point{p, pts[(i+1)%len(pts)]}, n: 2}
+	}
+	return c
+}
+
+// strokePath returns the outline of the contours stroked with width, with the
+// round joins and caps of Gio: a rectangle along each segment, once flattened,
+// and a disc at each of their ends. All of them turn the same way, for their
+// overlaps to be filled once.
+func strokePath(contours []contour, width float32, scale float32) []contour {
+	hw := width / 2
+	var outline []contour
+	for _, c := range contours {
+		var points []f32.Point
+		for _, s := range c {
+			if len(points) == 0 || points[len(points)-1] != s.from() {
+				points = append(points, s.from())
+			}
+			points = append(points, flatten(s, scale)...)
+		}
+		for i, p := range points {
+			outline = append(outline, disc(p, hw))
+			if i == 0 {
+				continue
+			}
+			from := points[i-1]
+			d := p.Sub(from)
+			length := float32(math.Hypot(float64(d.X), float64(d.Y)))
+			if length == 0 {
+				continue
+			}
+			n := f32.Pt(-d.Y, d.X).Mul(hw / length)
+			outline = append(outline, polygon(from.Sub(n), p.Sub(n), p.Add(n), from.Add(n)))
+		}
+	}
+	return outline
+}
+
+// flatten returns the points approximating s after its first one, about a
+// pixel apart once scaled.
+func flatten(s segment, scale float32) []f32.Point {
+	if s.n == 2 {
+		return []f32.Point{s.to()}
+	}
+	var length float32
+	for i := 1; i < s.n; i++ {
+		d := s.pts[i].Sub(s.pts[i-1])
+		length += float32(math.Hypot(float64(d.X), float64(d.Y)))
+	}
+	steps := min(max(int(length*scale), 1), 256)
+	points := make([]f32.Point, steps)
+	for i := range steps {
+		points[i] = s.at(float32(i+1) / float32(steps))
+	}
+	return points
+}
+
+// at returns the point of s at t.
+func (s segment) at(t float32) f32.Point {
+	u := 1 - t
+	p := s.pts
+	switch s.n {
+	case 3:
+		return p[0].Mul(u * u).Add(p[1].Mul(2 * u * t)).Add(p[2].Mul(t * t))
+	case 4:
+		return p[0].Mul(u * u * u).Add(p[1].Mul(3 * u * u * t)).Add(p[2].Mul(3 * u * t * t)).Add(p[3].Mul(t * t * t))
+	}
+	return p[0].Mul(u).Add(p[1].Mul(t))
+}
+
+// disc returns the circle of radius r around c, made of cubic curves, turning
+// as the rectangles of strokePath.
+func disc(c f32.Point, r float32) contour {
+	const k = 0.5522848
+	pt := func(x, y float32) f32.Point { return c.Add(f32.Pt(x*r, y*r)) }
+	return contour{
+		{pts: [4]f32.Point{pt(1, 0), pt(1, k), pt(k, 1), pt(0, 1)}, n: 4},
+		{pts: [4]f32.Point{pt(0, 1), pt(-k, 1), pt(-1, k), pt(-1, 0)}, n: 4},
+		{pts: [4]f32.Point{pt(-1, 0), pt(-1, -k), pt(-k, -1), pt(0, -1)}, n: 4},
+		{pts: [4]f32.Point{pt(0, -1), pt(k, -1), pt(1, -k), pt(1, 0)}, n: 4},
+	}
+}
+
+// rasterizer computes the coverage of paths, with the non-zero winding rule.
+type rasterizer struct {
+	z *vector.Rasterizer
+}
+
+// coverage returns the coverage of the contours transformed by t, within
+// viewport. The mask is nil when the contours cover nothing.
+func (r *rasterizer) coverage(contours []contour, t f32.Affine2D, viewport image.Rectangle) *image.Alpha {
+	inf := float32(math.Inf(1))
+	lo, hi := f32.Pt(inf, inf), f32.Pt(-inf, -inf)
+	transformed := make([]contour, len(contours))
+	for i, c := range contours {
+		transformed[i] = make(contour, len(c))
+		for j, s := range c {
+			for k := range s.n {
+				p := t.Transform(s.pts[k])
+				s.pts[k] = p
+				lo.X, lo.Y = min(lo.X, p.X), min(lo.Y, p.Y)
+				hi.X, hi.Y = max(hi.X, p.X), max(hi.Y, p.Y)
+			}
+			transformed[i][j] = s
+		}
+	}
+	bounds := image.Rect(
+		int(math.Floor(float64(lo.X))), int(math.Floor(float64(lo.Y))),
+		int(math.Ceil(float64(hi.X))), int(math.Ceil(float64(hi.Y))),
+	).Intersect(viewport)
+	if bounds.Empty() {
+		return nil
+	}
+
+	size := bounds.Size()
+	if r.z == nil {
+		r.z = vector.NewRasterizer(size.X, size.Y)
+	} else {
+		r.z.Reset(size.X, size.Y)
+	}
+	r.z.DrawOp = draw.Src
+	origin := f32.Pt(float32(bounds.Min.X), float32(bounds.Min.Y))
+	for _, c := range transformed {
+		for i, s := range c {
+			p := s.pts
+			for k := range s.n {
+				p[k] = p[k].Sub(origin)
+			}
+			if i == 0 || c[i-1].to() != s.from() {
+				if i > 0 {
+					r.z.ClosePath()
+				}
+				r.z.MoveTo(p[0].X, p[0].Y)
+			}
+			switch s.n {
+			case 2:
+				r.z.LineTo(p[1].X, p[1].Y)
+			case 3:
+				r.z.QuadTo(p[1].X, p[1].Y, p[2].X, p[2].Y)
+			case 4:
+				r.z.CubeTo(p[1].X, p[1].Y, p[2].X, p[2].Y, p[3].X, p[3].Y)
+			}
+		}
+		if len(c) > 0 {
+			r.z.ClosePath()
+		}
+	}
+
+	// The rasterizer draws at the origin, and the mask is moved to its bounds
+	// once drawn.
+	mask := image.NewAlpha(image.Rectangle{Max: size})
+	r.z.Draw(mask, mask.Rect, image.Opaque, image.Point{})
+	mask.Rect = bounds
+	return mask
+}
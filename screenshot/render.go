@@ -0,0 +1,376 @@
+// Package screenshot draws Gio operations on the CPU, for screenshots in tests
+// on machines without a GPU.
+package screenshot
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+)
+
+// Render draws call into an image of width by height pixels, as a window of
+// that size would show it, starting from a transparent image.
+//
+// The drawing is close to that of Gio on a GPU, blending in linear light,
+// though not identical to it: edges are antialiased differently, and strokes
+// are approximated. Screenshots taken with Render are to be compared with
+// other screenshots taken with Render.
+func Render(width, height int, call op.CallOp) *image.RGBA {
+	r := newRenderer(image.Rect(0, 0, width, height))
+	r.render(newOpReader(call))
+	return r.image()
+}
+
+type materialKind uint8
+
+const (
+	materialColor materialKind = iota
+	materialLinearGradient
+	materialImage
+)
+
+// material is what a PaintOp paints with, as set by the last ColorOp,
+// LinearGradientOp or ImageOp.
+type material struct {
+	kind materialKind
+	// color is the color of materialColor.
+	color rgba
+	// The stops and colors of materialLinearGradient, in the space of the
+	// operation setting it.
+	stop1, stop2   f32.Point
+	color1, color2 rgba
+	// The image of materialImage, sampled with its filter.
+	image   *image.RGBA
+	nearest bool
+}
+
+// clipArea is the area painted in: the intersection of the clip areas pushed.
+type clipArea struct {
+	parent *clipArea
+	// bounds is the bounds of the area within the viewport.
+	bounds image.Rectangle
+	// mask is the coverage of the area within bounds; nil when it is covered
+	// entirely.
+	mask *image.Alpha
+}
+
+func (c *clipArea) coverage(x, y int) float32 {
+	if c.mask == nil {
+		return 1
+	}
+	return float32(c.mask.Pix[c.mask.PixOffset(x, y)]) / 255
+}
+
+// layer is an image being painted, in linear light. Each opacity pushed
+// paints in a layer of its own, drawn over the layer below with the opacity
+// when popped.
+type layer struct {
+	pix     []rgba
+	opacity float32
+}
+
+type renderer struct {
+	viewport image.Rectangle
+	raster   rasterizer
+	layers   []*layer
+
+	transform  f32.Affine2D
+	transforms []f32.Affine2D
+	// saved holds the transforms saved by op.Defer, by their id.
+	saved    map[uint32]f32.Affine2D
+	clip     *clipArea
+	material material
+
+	// The path and width of the stroke of the next clip area.
+	path   []byte
+	stroke float32
+}
+
+func newRenderer(viewport image.Rectangle) *renderer {
+	r := &renderer{
+		viewport: viewport,
+		saved:    map[uint32]f32.Affine2D{},
+	}
+	r.layers = []*layer{r.newLayer(1)}
+	r.reset()
+	return r
+}
+
+func (r *renderer) newLayer(opacity float32) *layer {
+	return &layer{pix: make([]rgba, r.viewport.Dx()*r.viewport.Dy()), opacity: opacity}
+}
+
+// reset resets the state of the operations but the transform stack, as Gio
+// does when loading the state saved by op.Defer.
+func (r *renderer) reset() {
+	r.transform = f32.AffineId()
+	r.clip = &clipArea{bounds: r.viewport}
+	r.material = material{color: linearFromNRGBA(color.NRGBA{A: 0xff})}
+	r.path, r.stroke = nil, 0
+}
+
+func (r *renderer) render(reader *opReader) {
+	for o, ok := reader.decode(); ok; o, ok = reader.decode() {
+		data := o.data
+		switch o.typ() {
+		case typeTransform:
+			t := f32.NewAffine2D(
+				float(data[2:]), float(data[6:]), float(data[10:]),
+				float(data[14:]), float(data[18:]), float(data[22:]),
+			)
+			if data[1] != 0 {
+				r.transforms = append(r.transforms, r.transform)
+			}
+			r.transform = r.transform.Mul(t)
+		case typePopTransform:
+			n := len(r.transforms) - 1
+			r.transform, r.transforms = r.transforms[n], r.transforms[:n]
+		case typePushOpacity:
+			r.layers = append(r.layers, r.newLayer(float(data[1:])))
+		case typePopOpacity:
+			r.popLayer()
+		case typeSave:
+			r.saved[le.Uint32(data[1:])] = r.transform
+		case typeLoad:
+			r.reset()
+			r.transform = r.saved[le.Uint32(data[1:])]
+		case typePath:
+			// The path is the auxiliary data that follows.
+			if aux, ok := reader.decode(); ok {
+				r.path = aux.data[1:]
+			}
+		case typeStroke:
+			r.stroke = float(data[1:])
+		case typeClip:
+			bounds := image.Rect(
+				int(int32(le.Uint32(data[1:]))), int(int32(le.Uint32(data[5:]))),
+				int(int32(le.Uint32(data[9:]))), int(int32(le.Uint32(data[13:]))),
+			)
+			r.pushClip(bounds)
+			r.path, r.stroke = nil, 0
+		case typePopClip:
+			r.clip = r.clip.parent
+		case typeColor:
+			r.material = material{
+				kind:  materialColor,
+				color: linearFromNRGBA(color.NRGBA{R: data[1], G: data[2], B: data[3], A: data[4]}),
+			}
+		case typeLinearGradient:
+			r.material = material{
+				kind:   materialLinearGradient,
+				stop1:  f32.Pt(float(data[1:]), float(data[5:])),
+				stop2:  f32.Pt(float(data[9:]), float(data[13:])),
+				color1: linearFromNRGBA(color.NRGBA{R: data[17], G: data[18], B: data[19], A: data[20]}),
+				color2: linearFromNRGBA(color.NRGBA{R: data[21], G: data[22], B: data[23], A: data[24]}),
+			}
+			// The stops are in the space of the operation, and painted in
+			// the space of the window.
+			r.material.stop1 = r.transform.Transform(r.material.stop1)
+			r.material.stop2 = r.transform.Transform(r.material.stop2)
+		case typeImage:
+			src, _ := o.refs[0].(*image.RGBA)
+			if src == nil || o.refs[1] == nil {
+				continue
+			}
+			r.material = material{kind: materialImage, image: src, nearest: data[1] == 1}
+		case typePaint:
+			r.paint()
+		}
+	}
+}
+
+func float(data []byte) float32 {
+	return math.Float32frombits(le.Uint32(data))
+}
+
+// pushClip pushes the clip area of the path and stroke given before it, or of
+// bounds when there is no path.
+func (r *renderer) pushClip(bounds image.Rectangle) {
+	area := &clipArea{parent: r.clip}
+	switch {
+	case r.path != nil && r.stroke > 0:
+		scale := float32(math.Sqrt(math.Abs(float64(determinant(r.transform)))))
+		area.mask = r.raster.coverage(strokePath(decodePath(r.path, false), r.stroke, scale), r.transform, r.viewport)
+	case r.path != nil:
+		area.mask = r.raster.coverage(decodePath(r.path, true), r.transform, r.viewport)
+	default:
+		if rect, ok := pixelRect(bounds, r.transform); ok {
+			area.bounds = rect.Intersect(r.viewport)
+			break
+		}
+		area.mask = r.raster.coverage(rectPath(bounds), r.transform, r.viewport)
+	}
+	if area.mask != nil {
+		area.bounds = area.mask.Rect
+	}
+	r.clip = area.intersect()
+}
+
+// intersect returns the intersection of the area c with its parent.
+func (c *clipArea) intersect() *clipArea {
+	parent := c.parent
+	bounds := c.bounds.Intersect(parent.bounds)
+	area := &clipArea{parent: parent, bounds: bounds}
+	if (c.mask == nil && parent.mask == nil) || bounds.Empty() {
+		return area
+	}
+	area.mask = image.NewAlpha(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			area.mask.Pix[area.mask.PixOffset(x, y)] = uint8(c.coverage(x, y)*parent.coverage(x, y)*255 + .5)
+		}
+	}
+	return area
+}
+
+// pixelRect returns the rectangle of bounds transformed by t, when it is a
+// rectangle of whole pixels.
+func pixelRect(bounds image.Rectangle, t f32.Affine2D) (image.Rectangle, bool) {
+	_, hx, _, hy, _, _ := t.Elems()
+	if hx != 0 || hy != 0 {
+		return image.Rectangle{}, false
+	}
+	lo := t.Transform(f32.Pt(float32(bounds.Min.X), float32(bounds.Min.Y)))
+	hi := t.Transform(f32.Pt(float32(bounds.Max.X), float32(bounds.Max.Y)))
+	for _, v := range []float32{lo.X, lo.Y, hi.X, hi.Y} {
+		if v != float32(math.Round(float64(v))) {
+			return image.Rectangle{}, false
+		}
+	}
+	return image.Rect(int(lo.X), int(lo.Y), int(hi.X), int(hi.Y)), true
+}
+
+func determinant(t f32.Affine2D) float32 {
+	a, b, _, d, e, _ := t.Elems()
+	return a*e - b*d
+}
+
+// paint paints the clip area with the material.
+func (r *renderer) paint() {
+	area := r.clip
+	bounds := area.bounds
+	m := r.material
+	var inverse f32.Affine2D
+	if m.kind == materialImage {
+		// An image is painted within its bounds only.
+		size := m.image.Rect.Size()
+		corners := []f32.Point{{}, {X: float32(size.X)}, {Y: float32(size.Y)}, {X: float32(size.X), Y: float32(size.Y)}}
+		inf := float32(math.Inf(1))
+		lo, hi := f32.Pt(inf, inf), f32.Pt(-inf, -inf)
+		for _, c := range corners {
+			p := r.transform.Transform(c)
+			lo.X, lo.Y = min(lo.X, p.X), min(lo.Y, p.Y)
+			hi.X, hi.Y = max(hi.X, p.X), max(hi.Y, p.Y)
+		}
+		bounds = bounds.Intersect(image.Rect(
+			int(math.Floor(float64(lo.X))), int(math.Floor(float64(lo.Y))),
+			int(math.Ceil(float64(hi.X))), int(math.Ceil(float64(hi.Y))),
+		))
+		inverse = r.transform.Invert()
+	}
+	if bounds.Empty() {
+		return
+	}
+
+	dst := r.layers[len(r.layers)-1]
+	stride := r.viewport.Dx()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			coverage := area.coverage(x, y)
+			if coverage == 0 {
+				continue
+			}
+			center := f32.Pt(float32(x)+.5, float32(y)+.5)
+			var src rgba
+			switch m.kind {
+			case materialColor:
+				src = m.color
+			case materialLinearGradient:
+				src = m.gradientAt(center)
+			case materialImage:
+				var inside bool
+				src, inside = m.sample(inverse.Transform(center))
+				if !inside {
+					continue
+				}
+			}
+			src = src.scale(coverage)
+			i := (y-r.viewport.Min.Y)*stride + x - r.viewport.Min.X
+			dst.pix[i] = over(src, dst.pix[i])
+		}
+	}
+}
+
+// popLayer draws the top layer over the one below, with its opacity.
+func (r *renderer) popLayer() {
+	n := len(r.layers) - 1
+	top, below := r.layers[n], r.layers[n-1]
+	r.layers = r.layers[:n]
+	for i, c := range top.pix {
+		if c[3] != 0 {
+			below.pix[i] = over(c.scale(top.opacity), below.pix[i])
+		}
+	}
+}
+
+// over returns src drawn over dst.
+func over(src, dst rgba) rgba {
+	k := 1 - src[3]
+	return rgba{src[0] + dst[0]*k, src[1] + dst[1]*k, src[2] + dst[2]*k, src[3] + dst[3]*k}
+}
+
+// gradientAt returns the color of a linear gradient at p, in the space of the
+// window.
+func (m material) gradientAt(p f32.Point) rgba {
+	d := m.stop2.Sub(m.stop1)
+	length := d.X*d.X + d.Y*d.Y
+	if length == 0 {
+		return m.color2
+	}
+	v := p.Sub(m.stop1)
+	t := min(max((v.X*d.X+v.Y*d.Y)/length, 0), 1)
+	return m.color1.lerp(m.color2, t)
+}
+
+// sample returns the color of the image at p, in the space of the image, and
+// whether p is within it.
+func (m material) sample(p f32.Point) (rgba, bool) {
+	size := m.image.Rect.Size()
+	if p.X < 0 || p.Y < 0 || p.X >= float32(size.X) || p.Y >= float32(size.Y) {
+		return rgba{}, false
+	}
+	pixel := func(x, y int) rgba {
+		x, y = min(max(x, 0), size.X-1), min(max(y, 0), size.Y-1)
+		i := m.image.PixOffset(m.image.Rect.Min.X+x, m.image.Rect.Min.Y+y)
+		pix := m.image.Pix[i : i+4]
+		return linearFromRGBA(pix[0], pix[1], pix[2], pix[3])
+	}
+	if m.nearest {
+		return pixel(int(p.X), int(p.Y)), true
+	}
+	// Bilinear filtering, between the centers of the pixels around p.
+	x, y := p.X-.5, p.Y-.5
+	x0, y0 := float32(math.Floor(float64(x))), float32(math.Floor(float64(y)))
+	fx, fy := x-x0, y-y0
+	ix, iy := int(x0), int(y0)
+	top := pixel(ix, iy).lerp(pixel(ix+1, iy), fx)
+	bottom := pixel(ix, iy+1).lerp(pixel(ix+1, iy+1), fx)
+	return top.lerp(bottom, fy), true
+}
+
+// image returns the bottom layer in sRGB, as Gio stores a frame.
+func (r *renderer) image() *image.RGBA {
+	for len(r.layers) > 1 {
+		r.popLayer()
+	}
+	img := image.NewRGBA(r.viewport)
+	for i, c := range r.layers[0].pix {
+		pix := img.Pix[4*i : 4*i+4]
+		pix[0], pix[1], pix[2] = linearToSRGB(c[0]), linearToSRGB(c[1]), linearToSRGB(c[2])
+		pix[3] = uint8(min(max(c[3], 0), 1)*255 + .5)
+	}
+	return img
+}
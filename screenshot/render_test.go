@@ -0,0 +1,127 @@
+package screenshot
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"testing"
+
+	"github.com/zodimo/go-compose/screenshot/golden"
+
+	"gioui.org/f32"
+	"gioui.org/op"
+	"gioui.org/op/clip"
+	"gioui.org/op/paint"
+)
+
+var (
+	red   = color.NRGBA{R: 0xff, A: 0xff}
+	green = color.NRGBA{G: 0xff, A: 0xff}
+	blue  = color.NRGBA{B: 0xff, A: 0xff}
+)
+
+// record records the operations added by draw.
+func record(draw func(ops *op.Ops)) op.CallOp {
+	ops := new(op.Ops)
+	macro := op.Record(ops)
+	draw(ops)
+	return macro.Stop()
+}
+
+func assertPixel(t *testing.T, img *image.RGBA, x, y int, want color.RGBA) {
+	t.Helper()
+	if got := img.RGBAAt(x, y); got != want {
+		t.Errorf("pixel (%d, %d) is %v, want %v", x, y, got, want)
+	}
+}
+
+func TestRenderClipsTransformsAndNestedMacros(t *testing.T) {
+	img := Render(40, 40, record(func(ops *op.Ops) {
+		paint.FillShape(ops, red, clip.Rect{Max: image.Pt(20, 20)}.Op())
+
+		inner := record(func(ops *op.Ops) {
+			paint.FillShape(ops, blue, clip.Rect{Max: image.Pt(10, 10)}.Op())
+		})
+		defer op.Offset(image.Pt(20, 20)).Push(ops).Pop()
+		inner.Add(ops)
+	}))
+
+	assertPixel(t, img, 5, 5, color.RGBA{R: 0xff, A: 0xff})
+	assertPixel(t, img, 25, 25, color.RGBA{B: 0xff, A: 0xff})
+	assertPixel(t, img, 25, 5, color.RGBA{})
+	assertPixel(t, img, 35, 35, color.RGBA{})
+}
+
+func TestRenderPathsAndStrokes(t *testing.T) {
+	img := Render(60, 30, record(func(ops *op.Ops) {
+		paint.FillShape(ops, green, clip.Ellipse{Max: image.Pt(30, 30)}.Op(ops))
+		var p clip.Path
+		p.Begin(ops)
+		p.MoveTo(f32.Pt(35, 15))
+		p.LineTo(f32.Pt(55, 15))
+		paint.FillShape(ops, blue, clip.Stroke{Path: p.End(), Width: 4}.Op())
+	}))
+
+	assertPixel(t, img, 15, 15, color.RGBA{G: 0xff, A: 0xff})
+	// The corners of the bounds of the ellipse are outside it.
+	assertPixel(t, img, 1, 1, color.RGBA{})
+	assertPixel(t, img, 45, 14, color.RGBA{B: 0xff, A: 0xff})
+	assertPixel(t, img, 45, 20, color.RGBA{})
+}
+
+func TestRenderOpacityAndDefer(t *testing.T) {
+	img := Render(20, 10, record(func(ops *op.Ops) {
+		op.Defer(ops, record(func(ops *op.Ops) {
+			paint.FillShape(ops, blue, clip.Rect{Max: image.Pt(10, 10)}.Op())
+		}))
+		// Painted first, though added after the deferred operations.
+		paint.FillShape(ops, red, clip.Rect{Max: image.Pt(20, 10)}.Op())
+
+		opacity := paint.PushOpacity(ops, 0.5)
+		paint.FillShape(ops, green, clip.Rect{Min: image.Pt(10, 0), Max: image.Pt(20, 10)}.Op())
+		opacity.Pop()
+	}))
+
+	assertPixel(t, img, 5, 5, color.RGBA{B: 0xff, A: 0xff})
+	// Half green over red, blended in linear light.
+	assertPixel(t, img, 15, 5, color.RGBA{R: 0xbc, G: 0xbc, A: 0xff})
+}
+
+func TestRenderImages(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	src.SetRGBA(0, 0, color.RGBA{R: 0xff, A: 0xff})
+	src.SetRGBA(1, 1, color.RGBA{B: 0xff, A: 0xff})
+	imageOp := paint.NewImageOp(src)
+	imageOp.Filter = paint.FilterNearest
+
+	img := Render(20, 20, record(func(ops *op.Ops) {
+		defer op.Affine(f32.AffineId().Scale(f32.Point{}, f32.Pt(5, 5))).Push(ops).Pop()
+		imageOp.Add(ops)
+		paint.PaintOp{}.Add(ops)
+	}))
+
+	assertPixel(t, img, 2, 2, color.RGBA{R: 0xff, A: 0xff})
+	assertPixel(t, img, 7, 7, color.RGBA{B: 0xff, A: 0xff})
+	assertPixel(t, img, 7, 2, color.RGBA{})
+	// The image is painted within its bounds only.
+	assertPixel(t, img, 15, 15, color.RGBA{})
+}
+
+func TestRenderGolden(t *testing.T) {
+	img := Render(120, 60, record(func(ops *op.Ops) {
+		paint.LinearGradientOp{
+			Stop1: f32.Pt(0, 0), Color1: red,
+			Stop2: f32.Pt(120, 0), Color2: blue,
+		}.Add(ops)
+		paint.PaintOp{}.Add(ops)
+
+		rrect := clip.RRect{Rect: image.Rect(10, 10, 110, 50), SE: 20, SW: 20, NW: 20, NE: 20}
+		paint.FillShape(ops, color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xc0}, rrect.Op(ops))
+		paint.FillShape(ops, green, clip.Stroke{Path: rrect.Path(ops), Width: 3}.Op())
+
+		defer op.Affine(f32.AffineId().Rotate(f32.Pt(60, 30), math.Pi/4)).Push(ops).Pop()
+		paint.FillShape(ops, blue, clip.Rect{Min: image.Pt(50, 20), Max: image.Pt(70, 40)}.Op())
+	}))
+
+	golden.Assert(t, "shapes", img)
+}
@@ -0,0 +1,19 @@
+package state
+
+// Batch runs block in a mutable snapshot and applies all of its writes at once.
+// The composition sees either none or all of them, and a host waiting for
+// changes is invalidated once instead of once per write.
+//
+// Batch returns ErrSnapshotApplyConflict, discarding the writes of block, when
+// another writer changed one of the same values in the meantime. Called inside
+// an entered MutableSnapshot, block simply joins that snapshot.
+func Batch(block func()) error {
+	if current := CurrentSnapshot(); current != nil && !current.ReadOnly() {
+		block()
+		return nil
+	}
+	snapshot := TakeMutableSnapshot()
+	defer snapshot.Dispose()
+	snapshot.Enter(block)
+	return snapshot.Apply()
+}
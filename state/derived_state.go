@@ -0,0 +1,70 @@
+package state
+
+import "sync"
+
+var _ TypedValue[int] = (*derivedState[int])(nil)
+
+// derivedState caches the result of calc together with the values of the
+// StateObjects calc read, and only calls calc again once one of them changed.
+//
+// Get reports reads of those dependencies, so a composition that reads a
+// derived state is invalidated whenever its dependencies change.
+type derivedState[T any] struct {
+	mu    sync.Mutex
+	calc  func() T
+	value T
+	valid bool
+	deps  map[StateObject]any
+}
+
+func newDerivedState[T any](calc func() T) *derivedState[T] {
+	return &derivedState[T]{calc: calc}
+}
+
+func (d *derivedState[T]) Get() T {
+	d.mu.Lock()
+	value, deps, valid := d.value, d.deps, d.valid
+	d.mu.Unlock()
+
+	if valid && d.depsUnchanged(deps) {
+		return value
+	}
+
+	deps = map[StateObject]any{}
+	ObserveReads(func() {
+		value = d.calc()
+	}, func(obj StateObject) {
+		if _, seen := deps[obj]; !seen {
+			deps[obj] = snapshots.peek(obj)
+		}
+	})
+
+	d.mu.Lock()
+	d.value, d.deps, d.valid = value, deps, true
+	d.mu.Unlock()
+	return value
+}
+
+// depsUnchanged reads every dependency again, which also reports the reads to
+// the observers of the caller.
+func (d *derivedState[T]) depsUnchanged(deps map[StateObject]any) bool {
+	unchanged := true
+	for obj, seen := range deps {
+		current := snapshots.read(obj)
+		if unchanged && !obj.stateRecords().equal(seen, current) {
+			unchanged = false
+		}
+	}
+	return unchanged
+}
+
+// DerivedStateOf returns a value computed by calc from other state, remembered
+// in the calling composable. calc runs again only when a state it read changed,
+// which makes it cheap to read a derived value such as "is the list scrolled"
+// on every frame. The calc of the first composition is kept, so it should only
+// capture state values, not plain ones that change between compositions.
+func DerivedStateOf[T any](c SupportState, calc func() T) TypedValue[T] {
+	return RememberUnsafe(c, "derived_state", func() *derivedState[T] {
+		return newDerivedState(calc)
+	})
+}
@@ -0,0 +1,77 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/state"
+)
+
+// rememberer is the minimal SupportState a single composable needs.
+type rememberer map[string]any
+
+func (r rememberer) Remember(key string, calc func() any) any {
+	if v, ok := r[key]; ok {
+		return v
+	}
+	v := calc()
+	r[key] = v
+	return v
+}
+
+func (r rememberer) State(key string, initial func() any) state.MutableValue {
+	return r.Remember(key, func() any { return state.NewSnapshotValue(initial(), nil) }).(state.MutableValue)
+}
+
+func TestDerivedStateRecomputesOnlyWhenDependenciesChange(t *testing.T) {
+	items := state.NewSnapshotValue([]string{"a"}, nil)
+	unrelated := state.NewSnapshotValue(0, nil)
+
+	calculations := 0
+	c := rememberer{}
+	count := state.DerivedStateOf(c, func() int {
+		calculations++
+		return len(items.Get().([]string))
+	})
+
+	if got := count.Get(); got != 1 {
+		t.Errorf("Expected 1, got %d", got)
+	}
+	count.Get()
+	unrelated.Set(1)
+	count.Get()
+	if calculations != 1 {
+		t.Errorf("Expected a single calculation, got %d", calculations)
+	}
+
+	items.Set([]string{"a", "b"})
+	if got := count.Get(); got != 2 {
+		t.Errorf("Expected 2 after a dependency changed, got %d", got)
+	}
+	if calculations != 2 {
+		t.Errorf("Expected 2 calculations, got %d", calculations)
+	}
+
+	if again := state.DerivedStateOf(c, func() int { return 0 }); again != count {
+		t.Error("Expected the derived state to be remembered")
+	}
+}
+
+func TestDerivedStateReportsDependencyReads(t *testing.T) {
+	value := state.NewSnapshotValue(1, nil)
+	doubled := state.DerivedStateOf(rememberer{}, func() int { return value.Get().(int) * 2 })
+	doubled.Get()
+
+	observer := state.NewStateObserver(nil)
+	observer.Start()
+	defer observer.Stop()
+
+	observer.Observe(func() any { return "scope" })
+	doubled.Get()
+	observer.StopObserving()
+
+	value.Set(2)
+	state.SendApplyNotifications()
+	if !observer.IsInvalid("scope") {
+		t.Error("Expected a scope reading a cached derived state to depend on its inputs")
+	}
+}
@@ -0,0 +1,109 @@
+package state
+
+import (
+	"bytes"
+	"maps"
+	"runtime"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// goroutineID returns the id of the calling goroutine. It is only used to find
+// what a goroutine entered, which is why the lookup is skipped entirely while
+// no goroutine entered anything.
+func goroutineID() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	// "goroutine 123 [running]: ..."
+	field := bytes.TrimPrefix(buf[:n], []byte("goroutine "))
+	if i := bytes.IndexByte(field, ' '); i > 0 {
+		field = field[:i]
+	}
+	id, err := strconv.ParseInt(string(field), 10, 64)
+	if err != nil {
+		panic("state: cannot parse goroutine id: " + err.Error())
+	}
+	return id
+}
+
+// goroutineState is what a goroutine entered: the snapshot it reads and writes
+// in, and the observers of its own reads. It is replaced, never changed.
+type goroutineState struct {
+	snapshot      Snapshot
+	readObservers []*readObserver
+}
+
+// goroutineRegistry holds the goroutineState of the goroutines that entered a
+// snapshot or observe their reads. The map is replaced on every change, so
+// lookups take no lock, and is nil while no goroutine entered anything, so
+// reads and writes only look the calling goroutine up while one did.
+type goroutineRegistry struct {
+	mu     sync.Mutex
+	states atomic.Pointer[map[int64]*goroutineState]
+}
+
+// current returns what the calling goroutine entered, or nil.
+func (r *goroutineRegistry) current() *goroutineState {
+	states := r.states.Load()
+	if states == nil {
+		return nil
+	}
+	return (*states)[goroutineID()]
+}
+
+// update replaces the state of the goroutine id by a copy changed by change.
+func (r *goroutineRegistry) update(id int64, change func(state *goroutineState)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	next := map[int64]*goroutineState{}
+	if current := r.states.Load(); current != nil {
+		maps.Copy(next, *current)
+	}
+	state := &goroutineState{}
+	if previous, ok := next[id]; ok {
+		state.snapshot, state.readObservers = previous.snapshot, slices.Clone(previous.readObservers)
+	}
+	change(state)
+	if state.snapshot == nil && len(state.readObservers) == 0 {
+		delete(next, id)
+	} else {
+		next[id] = state
+	}
+	if len(next) == 0 {
+		r.states.Store(nil)
+	} else {
+		r.states.Store(&next)
+	}
+}
+
+// enter makes snapshot the current snapshot of the calling goroutine until
+// leave is called.
+func (r *goroutineRegistry) enter(snapshot Snapshot) (leave func()) {
+	id := goroutineID()
+	var previous Snapshot
+	r.update(id, func(state *goroutineState) {
+		previous, state.snapshot = state.snapshot, snapshot
+	})
+	return func() {
+		r.update(id, func(state *goroutineState) {
+			state.snapshot = previous
+		})
+	}
+}
+
+// observeReads calls fn for every StateObject read by the calling goroutine
+// until stop is called.
+func (r *goroutineRegistry) observeReads(fn func(StateObject)) (stop func()) {
+	id := goroutineID()
+	observer := &readObserver{fn: fn}
+	r.update(id, func(state *goroutineState) {
+		state.readObservers = append(state.readObservers, observer)
+	})
+	return func() {
+		r.update(id, func(state *goroutineState) {
+			state.readObservers = slices.DeleteFunc(state.readObservers, func(o *readObserver) bool { return o == observer })
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package state
+
+import "strings"
+
+// globalKeyPrefix marks state keys that opt out of composition scoping.
+const globalKeyPrefix = "global:"
+
+// GlobalKey marks key as global: SupportState.State then shares the value with
+// every other call using the same key, anywhere in the composition, and keeps
+// it after the caller leaves the composition.
+//
+// Keys that are not global are scoped to the position of the caller in the
+// composition and are removed together with it.
+func GlobalKey(key string) string {
+	if IsGlobalKey(key) {
+		return key
+	}
+	return globalKeyPrefix + key
+}
+
+// IsGlobalKey reports whether key was created by GlobalKey.
+func IsGlobalKey(key string) bool {
+	return strings.HasPrefix(key, globalKeyPrefix)
+}
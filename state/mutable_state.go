@@ -0,0 +1,124 @@
+package state
+
+import "reflect"
+
+// SnapshotMutationPolicy decides whether writing a new value to a MutableState
+// is a change. Writes that are not a change leave the readers of the state valid.
+type SnapshotMutationPolicy[T any] interface {
+	Equivalent(a, b T) bool
+}
+
+type policyFunc[T any] func(a, b T) bool
+
+func (f policyFunc[T]) Equivalent(a, b T) bool {
+	return f(a, b)
+}
+
+// StructuralEqualityPolicy treats deeply equal values as the same, like
+// reflect.DeepEqual. It is the default policy.
+func StructuralEqualityPolicy[T any]() SnapshotMutationPolicy[T] {
+	return policyFunc[T](func(a, b T) bool {
+		return reflect.DeepEqual(a, b)
+	})
+}
+
+// ReferentialEqualityPolicy treats values as the same only when they are ==,
+// so pointers to mutated objects still count as unchanged. Values that cannot
+// be compared with == always count as a change.
+func ReferentialEqualityPolicy[T any]() SnapshotMutationPolicy[T] {
+	return policyFunc[T](func(a, b T) bool {
+		return identical(a, b)
+	})
+}
+
+// NeverEqualPolicy treats every write as a change, even of the same value.
+func NeverEqualPolicy[T any]() SnapshotMutationPolicy[T] {
+	return policyFunc[T](func(a, b T) bool {
+		return false
+	})
+}
+
+func identical(a, b any) (same bool) {
+	defer func() {
+		if recover() != nil {
+			same = false
+		}
+	}()
+	return a == b
+}
+
+// MutableState is a typed value whose history is kept by the snapshot system.
+type MutableState[T any] interface {
+	TypedMutableValue[T]
+	StateObject
+}
+
+var _ MutableState[any] = (*mutableState[any])(nil)
+
+type mutableState[T any] struct {
+	chain recordChain
+}
+
+// NewMutableState creates a MutableState outside of a composition, for instance
+// as a field of an object shared by several composables. A nil policy means
+// StructuralEqualityPolicy.
+func NewMutableState[T any](initial T, policy SnapshotMutationPolicy[T]) MutableState[T] {
+	if policy == nil {
+		policy = StructuralEqualityPolicy[T]()
+	}
+	return &mutableState[T]{
+		chain: recordChain{
+			head: &stateRecord{value: initial},
+			equal: func(a, b any) bool {
+				ta, _ := a.(T)
+				tb, _ := b.(T)
+				return policy.Equivalent(ta, tb)
+			},
+		},
+	}
+}
+
+// MutableStateOf returns a MutableState remembered at the call site, created
+// with initial on the first composition. A nil policy means
+// StructuralEqualityPolicy.
+func MutableStateOf[T any](c SupportState, initial T, policy SnapshotMutationPolicy[T]) MutableState[T] {
+	return RememberUnsafe(c, "mutable_state", func() MutableState[T] {
+		return NewMutableState(initial, policy)
+	})
+}
+
+func (s *mutableState[T]) stateRecords() *recordChain {
+	return &s.chain
+}
+
+func (s *mutableState[T]) Get() T {
+	value, _ := snapshots.read(s).(T)
+	return value
+}
+
+func (s *mutableState[T]) Set(value T) {
+	snapshots.write(s, value)
+}
+
+func (s *mutableState[T]) Unwrap() MutableValue {
+	return untypedState[T]{s}
+}
+
+// untypedState is the MutableValue view of a MutableState.
+type untypedState[T any] struct {
+	state *mutableState[T]
+}
+
+func (u untypedState[T]) Get() any {
+	return u.state.Get()
+}
+
+func (u untypedState[T]) Set(value any) {
+	u.state.Set(value.(T))
+}
+
+// Typed gives a MutableValue holding values of type T a typed view.
+// Get panics if the value is of another type.
+func Typed[T any](value MutableValue) TypedMutableValue[T] {
+	return &typedMutableValue[T]{value: value}
+}
@@ -0,0 +1,50 @@
+package state_test
+
+import (
+	"testing"
+
+	"github.com/zodimo/go-compose/state"
+)
+
+type item struct{ Name string }
+
+func TestMutationPolicies(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  state.SnapshotMutationPolicy[*item]
+		changed bool
+	}{
+		{"structural", state.StructuralEqualityPolicy[*item](), false},
+		{"referential", state.ReferentialEqualityPolicy[*item](), true},
+		{"never equal", state.NeverEqualPolicy[*item](), true},
+	}
+	for _, tt := range tests {
+		value := state.NewMutableState(&item{Name: "a"}, tt.policy)
+
+		observer := state.NewStateObserver(nil)
+		observer.Start()
+		observer.Observe(func() any { return "scope" })
+		value.Get()
+		observer.StopObserving()
+
+		value.Set(&item{Name: "a"})
+		state.SendApplyNotifications()
+		if got := observer.IsInvalid("scope"); got != tt.changed {
+			t.Errorf("%s: Expected an equal copy to be a change: %v, got %v", tt.name, tt.changed, got)
+		}
+		observer.Stop()
+	}
+}
+
+func TestMutableStateOfIsRemembered(t *testing.T) {
+	c := rememberer{}
+	count := state.MutableStateOf(c, 0, nil)
+	count.Set(count.Get() + 1)
+
+	if again := state.MutableStateOf(c, 0, nil); again.Get() != 1 {
+		t.Errorf("Expected the remembered state, got %d", again.Get())
+	}
+	if count.Unwrap().Get() != 1 {
+		t.Errorf("Expected the untyped view to share the value")
+	}
+}
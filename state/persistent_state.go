@@ -3,4 +3,18 @@ package state
 type PersistentState interface {
 	GetState(key string, initial func() any) MutableValue
 	SetOnStateChange(callback func())
+
+	// GetSaveableState is GetState for a value that is also registered with the
+	// SaveableStateRegistry under key. A value restored by the registry takes the
+	// place of initial.
+	GetSaveableState(key string, initial func() any, saver Saver[any]) MutableValue
+
+	// RemoveState drops the value stored under key, if any.
+	RemoveState(key string)
+
+	// SaveableStateRegistry holds the values of RememberSaveable.
+	SaveableStateRegistry() SaveableStateRegistry
+
+	// Observer tracks the state reads of the composition that uses this store.
+	Observer() *StateObserver
 }
@@ -0,0 +1,16 @@
+package state
+
+// RememberObserver is implemented by values stored with SupportState.Remember that
+// need to know when they enter and leave the composition.
+//
+// Both callbacks run on the composing goroutine after the composition has been
+// applied: first OnForgotten for every value that left, in the reverse order in
+// which the values were remembered, then OnRemembered for every new value in
+// order, and finally the side effects of the composition.
+type RememberObserver interface {
+	// OnRemembered is called once the value is part of an applied composition.
+	OnRemembered()
+	// OnForgotten is called once the value is no longer remembered, because its
+	// group left the composition or stopped asking for it.
+	OnForgotten()
+}
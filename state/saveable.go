@@ -0,0 +1,42 @@
+package state
+
+import "fmt"
+
+type SupportSaveableState interface {
+	// SaveableState is State for values that are also kept by the
+	// SaveableStateRegistry, so they survive a process restart.
+	SaveableState(key string, initial func() any, saver Saver[any]) MutableValue
+}
+
+// RememberSaveable returns a value like SupportState.State that is also written
+// to the SaveableStateRegistry of the PersistentState and restored from it after
+// a restart. The value is saved with saver at the moment the registry is saved.
+//
+// The key is scoped to the caller like a State key, so the value is restored
+// into the same place of the composition it was saved from.
+func RememberSaveable[T any](c SupportSaveableState, key string, initial func() T, saver Saver[T]) TypedMutableValue[T] {
+	value := c.SaveableState(key, func() any { return initial() }, anySaver(saver))
+	if _, ok := value.Get().(T); !ok {
+		var zero T
+		panic(fmt.Errorf("value is not of type %T", zero))
+	}
+	return &typedMutableValue[T]{value: value}
+}
+
+var _ TypedMutableValue[any] = (*typedMutableValue[any])(nil)
+
+type typedMutableValue[T any] struct {
+	value MutableValue
+}
+
+func (v *typedMutableValue[T]) Get() T {
+	return v.value.Get().(T)
+}
+
+func (v *typedMutableValue[T]) Set(value T) {
+	v.value.Set(value)
+}
+
+func (v *typedMutableValue[T]) Unwrap() MutableValue {
+	return v.value
+}
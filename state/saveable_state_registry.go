@@ -0,0 +1,154 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// SaveableStateRegistry keeps the values of RememberSaveable across process
+// restarts. Values are registered under their state key while they are in the
+// composition; Save writes them out, and a registry restored from that output
+// hands each value back once, when its state is created again.
+type SaveableStateRegistry interface {
+	// Consume returns the data restored for key. It only does so once, so a value
+	// created again later in the same process starts from its initial value.
+	Consume(key string) ([]byte, bool)
+	// Register adds provider as the source of the data saved for key.
+	// The returned function unregisters it.
+	Register(key string, provider func() ([]byte, error)) (unregister func())
+	// Save writes the registered values, and the restored values that were not
+	// consumed yet, to w.
+	Save(w io.Writer) error
+	// SaveFile writes the registry to path, replacing the file atomically.
+	SaveFile(path string) error
+}
+
+// savedRegistry is the serialised form of a SaveableStateRegistry.
+type savedRegistry struct {
+	Version int               `json:"version"`
+	Values  map[string][]byte `json:"values"`
+}
+
+const savedRegistryVersion = 1
+
+var _ SaveableStateRegistry = (*saveableStateRegistry)(nil)
+
+type saveableStateRegistry struct {
+	mu        sync.Mutex
+	restored  map[string][]byte
+	providers map[string]*saveableProvider
+}
+
+type saveableProvider struct {
+	provide func() ([]byte, error)
+}
+
+// NewSaveableStateRegistry creates an empty registry.
+func NewSaveableStateRegistry() SaveableStateRegistry {
+	return newSaveableStateRegistry(nil)
+}
+
+// RestoreSaveableStateRegistry creates a registry from data written by Save.
+func RestoreSaveableStateRegistry(r io.Reader) (SaveableStateRegistry, error) {
+	var saved savedRegistry
+	if err := json.NewDecoder(r).Decode(&saved); err != nil {
+		return nil, fmt.Errorf("restore saveable state: %w", err)
+	}
+	if saved.Version != savedRegistryVersion {
+		return nil, fmt.Errorf("restore saveable state: unsupported version %d", saved.Version)
+	}
+	return newSaveableStateRegistry(saved.Values), nil
+}
+
+// RestoreSaveableStateRegistryFile creates a registry from a file written by
+// SaveFile. A missing file gives an empty registry, as on the first start.
+func RestoreSaveableStateRegistryFile(path string) (SaveableStateRegistry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewSaveableStateRegistry(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return RestoreSaveableStateRegistry(f)
+}
+
+func newSaveableStateRegistry(restored map[string][]byte) *saveableStateRegistry {
+	if restored == nil {
+		restored = map[string][]byte{}
+	}
+	return &saveableStateRegistry{
+		restored:  restored,
+		providers: map[string]*saveableProvider{},
+	}
+}
+
+func (r *saveableStateRegistry) Consume(key string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.restored[key]
+	delete(r.restored, key)
+	return data, ok
+}
+
+func (r *saveableStateRegistry) Register(key string, provider func() ([]byte, error)) func() {
+	p := &saveableProvider{provide: provider}
+	r.mu.Lock()
+	r.providers[key] = p
+	r.mu.Unlock()
+
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		if r.providers[key] == p {
+			delete(r.providers, key)
+		}
+	}
+}
+
+func (r *saveableStateRegistry) Save(w io.Writer) error {
+	r.mu.Lock()
+	values := make(map[string][]byte, len(r.restored)+len(r.providers))
+	for key, data := range r.restored {
+		values[key] = data
+	}
+	providers := make(map[string]*saveableProvider, len(r.providers))
+	for key, p := range r.providers {
+		providers[key] = p
+	}
+	r.mu.Unlock()
+
+	// Providers read state, so they are called without holding the lock.
+	for key, p := range providers {
+		data, err := p.provide()
+		if err != nil {
+			return fmt.Errorf("save state %q: %w", key, err)
+		}
+		values[key] = data
+	}
+	return json.NewEncoder(w).Encode(savedRegistry{Version: savedRegistryVersion, Values: values})
+}
+
+func (r *saveableStateRegistry) SaveFile(path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := r.Save(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,82 @@
+package state_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/zodimo/go-compose/state"
+)
+
+type tab struct {
+	Index int
+	Title string
+}
+
+func TestSaversRoundTrip(t *testing.T) {
+	for name, saver := range map[string]state.Saver[tab]{
+		"json": state.JSONSaver[tab](),
+		"gob":  state.GobSaver[tab](),
+	} {
+		data, err := saver.Save(tab{Index: 2, Title: "Inbox"})
+		if err != nil {
+			t.Fatalf("%s: Save failed: %v", name, err)
+		}
+		restored, err := saver.Restore(data)
+		if err != nil {
+			t.Fatalf("%s: Restore failed: %v", name, err)
+		}
+		if restored != (tab{Index: 2, Title: "Inbox"}) {
+			t.Errorf("%s: Expected the saved value back, got %+v", name, restored)
+		}
+	}
+}
+
+func TestSaveableStateRegistryRestoresOnce(t *testing.T) {
+	registry := state.NewSaveableStateRegistry()
+	current := "draft"
+	registry.Register("text", func() ([]byte, error) { return []byte(current), nil })
+	unregister := registry.Register("gone", func() ([]byte, error) { return []byte("x"), nil })
+	unregister()
+
+	current = "final"
+	var buf bytes.Buffer
+	if err := registry.Save(&buf); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	restored, err := state.RestoreSaveableStateRegistry(&buf)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if data, ok := restored.Consume("text"); !ok || string(data) != "final" {
+		t.Errorf("Expected the value at save time, got %q", data)
+	}
+	if _, ok := restored.Consume("text"); ok {
+		t.Error("Expected a restored value to be consumed only once")
+	}
+	if _, ok := restored.Consume("gone"); ok {
+		t.Error("Expected an unregistered value not to be saved")
+	}
+}
+
+func TestSaveableStateRegistryFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	registry, err := state.RestoreSaveableStateRegistryFile(path)
+	if err != nil {
+		t.Fatalf("Expected a missing file to give an empty registry, got %v", err)
+	}
+	registry.Register("tab", func() ([]byte, error) { return []byte("1"), nil })
+	if err := registry.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile failed: %v", err)
+	}
+
+	restored, err := state.RestoreSaveableStateRegistryFile(path)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if data, ok := restored.Consume("tab"); !ok || string(data) != "1" {
+		t.Errorf("Expected the saved value back, got %q", data)
+	}
+}
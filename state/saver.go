@@ -0,0 +1,85 @@
+package state
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// Saver converts a value to bytes and back so that it can outlive the process.
+type Saver[T any] interface {
+	Save(value T) ([]byte, error)
+	Restore(data []byte) (T, error)
+}
+
+var _ Saver[any] = (*saverFuncs[any])(nil)
+
+type saverFuncs[T any] struct {
+	save    func(T) ([]byte, error)
+	restore func([]byte) (T, error)
+}
+
+func (s *saverFuncs[T]) Save(value T) ([]byte, error) {
+	return s.save(value)
+}
+
+func (s *saverFuncs[T]) Restore(data []byte) (T, error) {
+	return s.restore(data)
+}
+
+// NewSaver creates a Saver from a pair of functions.
+func NewSaver[T any](save func(T) ([]byte, error), restore func([]byte) (T, error)) Saver[T] {
+	return &saverFuncs[T]{save: save, restore: restore}
+}
+
+// JSONSaver saves values with encoding/json. It suits plain data such as
+// numbers, strings and structs with exported fields.
+func JSONSaver[T any]() Saver[T] {
+	return NewSaver(
+		func(value T) ([]byte, error) {
+			return json.Marshal(value)
+		},
+		func(data []byte) (T, error) {
+			var value T
+			err := json.Unmarshal(data, &value)
+			return value, err
+		},
+	)
+}
+
+// GobSaver saves values with encoding/gob. Interface values must have their
+// concrete types registered with gob.Register.
+func GobSaver[T any]() Saver[T] {
+	return NewSaver(
+		func(value T) ([]byte, error) {
+			var buf bytes.Buffer
+			if err := gob.NewEncoder(&buf).Encode(&value); err != nil {
+				return nil, err
+			}
+			return buf.Bytes(), nil
+		},
+		func(data []byte) (T, error) {
+			var value T
+			err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value)
+			return value, err
+		},
+	)
+}
+
+// anySaver lets a Saver[T] handle the untyped values kept by a PersistentState.
+func anySaver[T any](saver Saver[T]) Saver[any] {
+	return NewSaver(
+		func(value any) ([]byte, error) {
+			typed, ok := value.(T)
+			if !ok {
+				var zero T
+				return nil, fmt.Errorf("value is not of type %T", zero)
+			}
+			return saver.Save(typed)
+		},
+		func(data []byte) (any, error) {
+			return saver.Restore(data)
+		},
+	)
+}
@@ -0,0 +1,488 @@
+package state
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// SnapshotID identifies a snapshot and every state record written inside it.
+type SnapshotID int64
+
+// ErrSnapshotApplyConflict is returned by MutableSnapshot.Apply when a value written
+// in the snapshot was also changed, to a different value, after the snapshot was taken.
+var ErrSnapshotApplyConflict = errors.New("snapshot apply conflict")
+
+// ErrSnapshotDisposed is returned when a snapshot is used after Apply or Dispose.
+var ErrSnapshotDisposed = errors.New("snapshot already disposed")
+
+// Snapshot is an isolated, consistent view of every StateObject.
+//
+// Reads and writes made without an entered snapshot go to the global snapshot.
+// Writes in the global snapshot are visible immediately, but are only reported to
+// apply observers by SendApplyNotifications, which the composer calls once per frame.
+type Snapshot interface {
+	ID() SnapshotID
+	ReadOnly() bool
+
+	// Enter runs block with this snapshot as the current snapshot of the calling goroutine.
+	Enter(block func())
+
+	// Dispose releases the snapshot; records only it could see become collectable.
+	Dispose()
+}
+
+// MutableSnapshot isolates writes until Apply publishes them to the global snapshot
+// as one atomic change.
+type MutableSnapshot interface {
+	Snapshot
+	Apply() error
+}
+
+// StateObject is implemented by values whose history is kept by the snapshot system.
+type StateObject interface {
+	stateRecords() *recordChain
+}
+
+// recordChain is the version history of a StateObject, newest record first.
+type recordChain struct {
+	head  *stateRecord
+	equal func(a, b any) bool
+}
+
+// stateRecord is one version of a StateObject. Records with snapshotID 0 hold the
+// initial value and are visible to every snapshot.
+type stateRecord struct {
+	snapshotID SnapshotID
+	value      any
+	next       *stateRecord
+}
+
+// snapshotView is the visibility rule shared by all snapshot kinds: a record is
+// visible when it was written at or before id and its writer was not still open
+// when the view was created.
+type snapshotView struct {
+	id      SnapshotID
+	invalid map[SnapshotID]struct{}
+}
+
+func (v *snapshotView) canSee(id SnapshotID) bool {
+	if id > v.id {
+		return false
+	}
+	_, invalid := v.invalid[id]
+	return !invalid
+}
+
+func (v *snapshotView) readable(chain *recordChain) *stateRecord {
+	var found *stateRecord
+	for r := chain.head; r != nil; r = r.next {
+		if v.canSee(r.snapshotID) && (found == nil || r.snapshotID > found.snapshotID) {
+			found = r
+		}
+	}
+	return found
+}
+
+// snapshotSystem is the process-wide bookkeeping behind every snapshot.
+// All record chains are guarded by its mutex.
+type snapshotSystem struct {
+	mu        sync.Mutex
+	nextID    SnapshotID
+	global    *snapshotView
+	open      map[SnapshotID]*snapshotView
+	modified  map[StateObject]struct{}
+	observers observerRegistry
+
+	goroutines goroutineRegistry
+}
+
+var snapshots = newSnapshotSystem()
+
+func newSnapshotSystem() *snapshotSystem {
+	s := &snapshotSystem{
+		nextID:   1,
+		open:     map[SnapshotID]*snapshotView{},
+		modified: map[StateObject]struct{}{},
+	}
+	s.global = s.newViewLocked()
+	return s
+}
+
+// newViewLocked allocates a view that cannot see anything still open.
+func (s *snapshotSystem) newViewLocked() *snapshotView {
+	invalid := make(map[SnapshotID]struct{}, len(s.open))
+	for id := range s.open {
+		invalid[id] = struct{}{}
+	}
+	view := &snapshotView{id: s.nextID, invalid: invalid}
+	s.nextID++
+	s.open[view.id] = view
+	return view
+}
+
+// advanceGlobalLocked closes the current global snapshot so that views created
+// afterwards see all of its writes.
+func (s *snapshotSystem) advanceGlobalLocked() {
+	delete(s.open, s.global.id)
+	s.global = s.newViewLocked()
+}
+
+func (s *snapshotSystem) current() Snapshot {
+	if g := s.goroutines.current(); g != nil {
+		return g.snapshot
+	}
+	return nil
+}
+
+func (s *snapshotSystem) read(obj StateObject) any {
+	g := s.goroutines.current()
+	value := s.peekIn(g, obj)
+	s.observers.notifyRead(obj)
+	if g != nil {
+		for _, o := range g.readObservers {
+			o.fn(obj)
+		}
+	}
+	return value
+}
+
+// peek returns the value of obj in the current snapshot without reporting the read.
+func (s *snapshotSystem) peek(obj StateObject) any {
+	return s.peekIn(s.goroutines.current(), obj)
+}
+
+// peekIn returns the value of obj in the snapshot entered by g.
+func (s *snapshotSystem) peekIn(g *goroutineState, obj StateObject) any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	view := s.global
+	if g != nil && g.snapshot != nil {
+		view = g.snapshot.(viewSnapshot).view()
+	}
+	record := view.readable(obj.stateRecords())
+	if record == nil {
+		return nil
+	}
+	return record.value
+}
+
+// write stores value for obj in the current snapshot and reports whether it
+// differed from the previous value under the object's equality policy.
+func (s *snapshotSystem) write(obj StateObject, value any) bool {
+	current := s.current()
+	if current != nil {
+		if current.ReadOnly() {
+			panic("state: cannot write in a read-only snapshot")
+		}
+		return current.(*mutableSnapshot).write(obj, value)
+	}
+
+	s.mu.Lock()
+	changed := s.writeGlobalLocked(obj, value)
+	s.mu.Unlock()
+
+	if changed {
+		s.observers.notifyWrite([]StateObject{obj})
+	}
+	return changed
+}
+
+func (s *snapshotSystem) writeGlobalLocked(obj StateObject, value any) bool {
+	chain := obj.stateRecords()
+	if previous := s.global.readable(chain); previous != nil && chain.equal(previous.value, value) {
+		return false
+	}
+	writeRecordLocked(chain, s.global.id, value)
+	s.modified[obj] = struct{}{}
+	s.pruneLocked(chain)
+	return true
+}
+
+func writeRecordLocked(chain *recordChain, id SnapshotID, value any) {
+	for r := chain.head; r != nil; r = r.next {
+		if r.snapshotID == id {
+			r.value = value
+			return
+		}
+	}
+	chain.head = &stateRecord{snapshotID: id, value: value, next: chain.head}
+}
+
+// pruneLocked drops records that no open view can read any more.
+func (s *snapshotSystem) pruneLocked(chain *recordChain) {
+	needed := map[*stateRecord]struct{}{}
+	for _, view := range s.open {
+		if r := view.readable(chain); r != nil {
+			needed[r] = struct{}{}
+		}
+	}
+	var kept *stateRecord
+	for r := chain.head; r != nil; r = r.next {
+		_, isNeeded := needed[r]
+		_, isOpen := s.open[r.snapshotID]
+		if isNeeded || isOpen {
+			if kept == nil {
+				chain.head = r
+			} else {
+				kept.next = r
+			}
+			kept = r
+		}
+	}
+	if kept != nil {
+		kept.next = nil
+	}
+}
+
+// enter makes snapshot the current snapshot of the calling goroutine until
+// leave is called.
+func (s *snapshotSystem) enter(snapshot Snapshot) (leave func()) {
+	return s.goroutines.enter(snapshot)
+}
+
+type viewSnapshot interface {
+	view() *snapshotView
+	// enterUntil enters the snapshot until leave is called; it panics when
+	// the snapshot is disposed.
+	enterUntil() (leave func())
+}
+
+var _ Snapshot = (*readonlySnapshot)(nil)
+
+type readonlySnapshot struct {
+	v        *snapshotView
+	disposed atomic.Bool
+}
+
+func (rs *readonlySnapshot) view() *snapshotView { return rs.v }
+func (rs *readonlySnapshot) ID() SnapshotID      { return rs.v.id }
+func (rs *readonlySnapshot) ReadOnly() bool      { return true }
+
+func (rs *readonlySnapshot) Enter(block func()) {
+	leave := rs.enterUntil()
+	defer leave()
+	block()
+}
+
+func (rs *readonlySnapshot) enterUntil() func() {
+	if rs.disposed.Load() {
+		panic(ErrSnapshotDisposed)
+	}
+	return snapshots.enter(rs)
+}
+
+func (rs *readonlySnapshot) Dispose() {
+	if rs.disposed.Swap(true) {
+		return
+	}
+	snapshots.mu.Lock()
+	delete(snapshots.open, rs.v.id)
+	snapshots.mu.Unlock()
+}
+
+var _ MutableSnapshot = (*mutableSnapshot)(nil)
+
+type mutableSnapshot struct {
+	v        *snapshotView
+	modified map[StateObject]struct{}
+	disposed bool
+}
+
+func (ms *mutableSnapshot) view() *snapshotView { return ms.v }
+func (ms *mutableSnapshot) ID() SnapshotID      { return ms.v.id }
+func (ms *mutableSnapshot) ReadOnly() bool      { return false }
+
+func (ms *mutableSnapshot) Enter(block func()) {
+	leave := ms.enterUntil()
+	defer leave()
+	block()
+}
+
+func (ms *mutableSnapshot) enterUntil() func() {
+	snapshots.mu.Lock()
+	disposed := ms.disposed
+	snapshots.mu.Unlock()
+	if disposed {
+		panic(ErrSnapshotDisposed)
+	}
+	return snapshots.enter(ms)
+}
+
+func (ms *mutableSnapshot) write(obj StateObject, value any) bool {
+	s := snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if ms.disposed {
+		panic(ErrSnapshotDisposed)
+	}
+	chain := obj.stateRecords()
+	if previous := ms.v.readable(chain); previous != nil && chain.equal(previous.value, value) {
+		return false
+	}
+	writeRecordLocked(chain, ms.v.id, value)
+	ms.modified[obj] = struct{}{}
+	return true
+}
+
+// Apply publishes every write of the snapshot to the global snapshot. It fails
+// with ErrSnapshotApplyConflict, leaving the global state untouched, when another
+// writer changed one of the same objects to a different value in the meantime.
+func (ms *mutableSnapshot) Apply() error {
+	s := snapshots
+	s.mu.Lock()
+	if ms.disposed {
+		s.mu.Unlock()
+		return ErrSnapshotDisposed
+	}
+
+	base := &snapshotView{id: ms.v.id - 1, invalid: ms.v.invalid}
+	for obj := range ms.modified {
+		chain := obj.stateRecords()
+		current := s.global.readable(chain)
+		if current == base.readable(chain) {
+			continue
+		}
+		mine := ms.v.readable(chain)
+		if current == nil || !chain.equal(current.value, mine.value) {
+			s.mu.Unlock()
+			return ErrSnapshotApplyConflict
+		}
+	}
+
+	changed := make([]StateObject, 0, len(ms.modified))
+	for obj := range ms.modified {
+		chain := obj.stateRecords()
+		if s.writeGlobalLocked(obj, ms.v.readable(chain).value) {
+			changed = append(changed, obj)
+		}
+	}
+	ms.disposeLocked()
+	s.mu.Unlock()
+
+	if len(changed) > 0 {
+		s.observers.notifyWrite(changed)
+	}
+	return nil
+}
+
+func (ms *mutableSnapshot) Dispose() {
+	snapshots.mu.Lock()
+	defer snapshots.mu.Unlock()
+	ms.disposeLocked()
+}
+
+func (ms *mutableSnapshot) disposeLocked() {
+	if ms.disposed {
+		return
+	}
+	ms.disposed = true
+	delete(snapshots.open, ms.v.id)
+	for obj := range ms.modified {
+		chain := obj.stateRecords()
+		removeRecordLocked(chain, ms.v.id)
+		snapshots.pruneLocked(chain)
+	}
+}
+
+func removeRecordLocked(chain *recordChain, id SnapshotID) {
+	var previous *stateRecord
+	for r := chain.head; r != nil; r = r.next {
+		if r.snapshotID == id {
+			if previous == nil {
+				chain.head = r.next
+			} else {
+				previous.next = r.next
+			}
+			return
+		}
+		previous = r
+	}
+}
+
+// TakeSnapshot returns a read-only snapshot of the current global state.
+// Later writes, from any goroutine, are invisible inside it.
+func TakeSnapshot() Snapshot {
+	s := snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advanceGlobalLocked()
+	return &readonlySnapshot{v: s.newViewLocked()}
+}
+
+// TakeMutableSnapshot returns a snapshot whose writes stay private until Apply.
+// It is intended for goroutines that update several values and want them to
+// become visible to the composition together.
+func TakeMutableSnapshot() MutableSnapshot {
+	s := snapshots
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.advanceGlobalLocked()
+	return &mutableSnapshot{
+		v:        s.newViewLocked(),
+		modified: map[StateObject]struct{}{},
+	}
+}
+
+// EnterSnapshot makes snapshot the current snapshot of the calling goroutine
+// until leave is called, on the same goroutine. It is Enter for work that does
+// not fit in a block, as a composition that starts in NewComposer and ends in
+// Build.
+func EnterSnapshot(snapshot Snapshot) (leave func()) {
+	return snapshot.(viewSnapshot).enterUntil()
+}
+
+// CurrentSnapshot returns the snapshot entered by the calling goroutine, or nil
+// when it reads and writes the global snapshot.
+func CurrentSnapshot() Snapshot {
+	return snapshots.current()
+}
+
+// SendApplyNotifications reports every global write since the previous call to the
+// registered apply observers as a single change set. It marks a frame boundary.
+func SendApplyNotifications() {
+	s := snapshots
+	s.mu.Lock()
+	if len(s.modified) == 0 {
+		s.mu.Unlock()
+		return
+	}
+	changed := make([]StateObject, 0, len(s.modified))
+	for obj := range s.modified {
+		changed = append(changed, obj)
+	}
+	s.modified = map[StateObject]struct{}{}
+	s.advanceGlobalLocked()
+	s.mu.Unlock()
+
+	s.observers.notifyApply(changed)
+}
+
+// RegisterApplyObserver registers fn to receive the change sets published by
+// SendApplyNotifications. The returned function unregisters it.
+func RegisterApplyObserver(fn func(changed []StateObject)) (unregister func()) {
+	return snapshots.observers.addApply(fn)
+}
+
+// RegisterGlobalWriteObserver registers fn to be called after every effective write
+// to the global snapshot, from whichever goroutine made it.
+func RegisterGlobalWriteObserver(fn func(obj StateObject)) (unregister func()) {
+	return snapshots.observers.addWrite(func(objs []StateObject) {
+		for _, obj := range objs {
+			fn(obj)
+		}
+	})
+}
+
+// RegisterReadObserver registers fn to be called on every StateObject read.
+func RegisterReadObserver(fn func(obj StateObject)) (unregister func()) {
+	return snapshots.observers.addRead(fn)
+}
+
+// ObserveReads runs block and calls onRead for every StateObject it reads on the
+// calling goroutine. Reads made by other goroutines in the meantime are ignored.
+func ObserveReads(block func(), onRead func(obj StateObject)) {
+	stop := snapshots.goroutines.observeReads(onRead)
+	defer stop()
+	block()
+}
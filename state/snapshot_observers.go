@@ -0,0 +1,84 @@
+package state
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// observerRegistry holds the snapshot observers. Lists are replaced on every
+// change so notifications can iterate them without holding a lock.
+type observerRegistry struct {
+	mu    sync.Mutex
+	read  atomic.Pointer[[]*readObserver]
+	write atomic.Pointer[[]*writeObserver]
+	apply atomic.Pointer[[]*applyObserver]
+}
+
+type readObserver struct{ fn func(StateObject) }
+type writeObserver struct{ fn func([]StateObject) }
+type applyObserver struct{ fn func([]StateObject) }
+
+func (r *observerRegistry) addRead(fn func(StateObject)) func() {
+	return register(&r.mu, &r.read, &readObserver{fn: fn})
+}
+
+func (r *observerRegistry) addWrite(fn func([]StateObject)) func() {
+	return register(&r.mu, &r.write, &writeObserver{fn: fn})
+}
+
+func (r *observerRegistry) addApply(fn func([]StateObject)) func() {
+	return register(&r.mu, &r.apply, &applyObserver{fn: fn})
+}
+
+func (r *observerRegistry) notifyRead(obj StateObject) {
+	if list := r.read.Load(); list != nil {
+		for _, o := range *list {
+			o.fn(obj)
+		}
+	}
+}
+
+// notifyWrite reports objects written together, by a single global write or
+// by applying a snapshot, in one call per observer.
+func (r *observerRegistry) notifyWrite(objs []StateObject) {
+	if list := r.write.Load(); list != nil {
+		for _, o := range *list {
+			o.fn(objs)
+		}
+	}
+}
+
+func (r *observerRegistry) notifyApply(changed []StateObject) {
+	if list := r.apply.Load(); list != nil {
+		for _, o := range *list {
+			o.fn(changed)
+		}
+	}
+}
+
+func register[T any](mu *sync.Mutex, list *atomic.Pointer[[]*T], observer *T) func() {
+	mu.Lock()
+	defer mu.Unlock()
+	var next []*T
+	if current := list.Load(); current != nil {
+		next = append(next, *current...)
+	}
+	next = append(next, observer)
+	list.Store(&next)
+
+	return func() {
+		mu.Lock()
+		defer mu.Unlock()
+		current := list.Load()
+		if current == nil {
+			return
+		}
+		remaining := make([]*T, 0, len(*current))
+		for _, o := range *current {
+			if o != observer {
+				remaining = append(remaining, o)
+			}
+		}
+		list.Store(&remaining)
+	}
+}
@@ -0,0 +1,131 @@
+package state_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/zodimo/go-compose/state"
+)
+
+func TestMutableSnapshotIsolation(t *testing.T) {
+	value := state.NewSnapshotValue(1, nil)
+
+	snapshot := state.TakeMutableSnapshot()
+	snapshot.Enter(func() {
+		value.Set(2)
+		if got := value.Get(); got != 2 {
+			t.Errorf("Expected snapshot to see its own write, got %v", got)
+		}
+	})
+
+	if got := value.Get(); got != 1 {
+		t.Errorf("Expected global snapshot to see 1 before apply, got %v", got)
+	}
+
+	if err := snapshot.Apply(); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if got := value.Get(); got != 2 {
+		t.Errorf("Expected 2 after apply, got %v", got)
+	}
+}
+
+func TestMutableSnapshotConflict(t *testing.T) {
+	value := state.NewSnapshotValue("initial", nil)
+
+	snapshot := state.TakeMutableSnapshot()
+	snapshot.Enter(func() {
+		value.Set("snapshot")
+	})
+	value.Set("global")
+
+	err := snapshot.Apply()
+	if !errors.Is(err, state.ErrSnapshotApplyConflict) {
+		t.Fatalf("Expected ErrSnapshotApplyConflict, got %v", err)
+	}
+	snapshot.Dispose()
+
+	if got := value.Get(); got != "global" {
+		t.Errorf("Expected conflicting apply to leave global value, got %v", got)
+	}
+}
+
+func TestReadOnlySnapshotIgnoresLaterWrites(t *testing.T) {
+	value := state.NewSnapshotValue(1, nil)
+
+	snapshot := state.TakeSnapshot()
+	defer snapshot.Dispose()
+	value.Set(2)
+
+	snapshot.Enter(func() {
+		if got := value.Get(); got != 1 {
+			t.Errorf("Expected read-only snapshot to see 1, got %v", got)
+		}
+	})
+	if got := value.Get(); got != 2 {
+		t.Errorf("Expected global snapshot to see 2, got %v", got)
+	}
+}
+
+func TestStateObserverInvalidatesReadingScopes(t *testing.T) {
+	read := state.NewSnapshotValue(0, nil)
+	unread := state.NewSnapshotValue(0, nil)
+
+	scheduled := 0
+	observer := state.NewStateObserver(func() { scheduled++ })
+	observer.Start()
+	defer observer.Stop()
+
+	observer.Observe(func() any { return "scope" })
+	read.Get()
+	observer.StopObserving()
+
+	unread.Set(1)
+	state.SendApplyNotifications()
+	if scheduled != 0 || observer.IsInvalid("scope") {
+		t.Errorf("Expected write to an unread value to be ignored")
+	}
+
+	read.Set(1)
+	if scheduled != 1 {
+		t.Errorf("Expected write to a read value to schedule a frame, got %d", scheduled)
+	}
+	if observer.IsInvalid("scope") {
+		t.Errorf("Expected scope to stay valid until the frame boundary")
+	}
+	state.SendApplyNotifications()
+	if !observer.IsInvalid("scope") {
+		t.Errorf("Expected scope to be invalid after apply")
+	}
+}
+
+func TestOtherGoroutinesReadTheGlobalSnapshotUnobserved(t *testing.T) {
+	value := state.NewSnapshotValue(1, nil)
+	observer := state.NewStateObserver(func() {})
+	observer.Start()
+	defer observer.Stop()
+
+	// This goroutine composes: it observes its reads, in a snapshot of its own.
+	observer.Observe(func() any { return "scope" })
+	snapshot := state.TakeMutableSnapshot()
+	leave := state.EnterSnapshot(snapshot)
+	value.Set(2)
+
+	// Another goroutine, as an effect, entered neither.
+	done := make(chan any)
+	go func() {
+		done <- value.Get()
+	}()
+	if got := <-done; got != 1 {
+		t.Errorf("Expected another goroutine to read the global 1, got %v", got)
+	}
+	leave()
+	observer.StopObserving()
+	snapshot.Dispose()
+
+	value.Set(3)
+	state.SendApplyNotifications()
+	if observer.IsInvalid("scope") {
+		t.Error("Expected the read of another goroutine not to be observed")
+	}
+}
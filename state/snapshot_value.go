@@ -0,0 +1,45 @@
+package state
+
+import "reflect"
+
+var _ MutableValue = (*SnapshotValue)(nil)
+var _ StateObject = (*SnapshotValue)(nil)
+
+// SnapshotValue is a MutableValue whose history is kept by the snapshot system.
+// Reads are reported to read observers, so a composition can tell which values
+// it depends on, and writes made inside an entered MutableSnapshot stay private
+// until the snapshot is applied.
+type SnapshotValue struct {
+	chain recordChain
+}
+
+// NewSnapshotValue creates a SnapshotValue. equal decides whether a Set changes
+// the value; nil means reflect.DeepEqual.
+func NewSnapshotValue(initial any, equal func(a, b any) bool) *SnapshotValue {
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+	return &SnapshotValue{
+		chain: recordChain{
+			head:  &stateRecord{value: initial},
+			equal: equal,
+		},
+	}
+}
+
+func (v *SnapshotValue) stateRecords() *recordChain {
+	return &v.chain
+}
+
+func (v *SnapshotValue) Get() any {
+	return snapshots.read(v)
+}
+
+func (v *SnapshotValue) Set(value any) {
+	v.Update(value)
+}
+
+// Update sets value and reports whether it differed from the current value.
+func (v *SnapshotValue) Update(value any) bool {
+	return snapshots.write(v, value)
+}
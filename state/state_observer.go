@@ -0,0 +1,186 @@
+package state
+
+import "sync"
+
+// StateObserver records which composition scopes read which StateObjects.
+//
+// When a change set is applied (see SendApplyNotifications) every scope that read
+// one of the changed objects becomes invalid, and only those scopes need to be
+// composed again. Global writes to an observed object call onInvalidate straight
+// away so the host can schedule the frame at which the change will be applied.
+type StateObserver struct {
+	mu           sync.Mutex
+	onInvalidate func()
+	reads        map[any]map[StateObject]struct{}
+	readers      map[StateObject]map[any]struct{}
+	invalid      map[any]struct{}
+	unregister   []func()
+	stopReads    func()
+}
+
+func NewStateObserver(onInvalidate func()) *StateObserver {
+	return &StateObserver{
+		onInvalidate: onInvalidate,
+		reads:        map[any]map[StateObject]struct{}{},
+		readers:      map[StateObject]map[any]struct{}{},
+		invalid:      map[any]struct{}{},
+	}
+}
+
+// Start subscribes the observer to global writes and applied change sets.
+func (o *StateObserver) Start() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.unregister) > 0 {
+		return
+	}
+	o.unregister = append(o.unregister,
+		snapshots.observers.addWrite(o.onWrite),
+		RegisterApplyObserver(o.onApply),
+	)
+}
+
+// Stop undoes Start and ends any active observation.
+func (o *StateObserver) Stop() {
+	o.StopObserving()
+	o.mu.Lock()
+	unregister := o.unregister
+	o.unregister = nil
+	o.mu.Unlock()
+	for _, fn := range unregister {
+		fn()
+	}
+}
+
+// Observe attributes every StateObject read by the calling goroutine to the
+// scope returned by currentScope until StopObserving is called. Reads made by
+// other goroutines in the meantime, as effects, are ignored, and currentScope
+// is only called on the calling goroutine.
+// Only one observation is active at a time; a new one replaces the previous.
+func (o *StateObserver) Observe(currentScope func() any) {
+	stop := snapshots.goroutines.observeReads(func(obj StateObject) {
+		o.recordRead(currentScope(), obj)
+	})
+	o.mu.Lock()
+	previous := o.stopReads
+	o.stopReads = stop
+	o.mu.Unlock()
+	if previous != nil {
+		previous()
+	}
+}
+
+// StopObserving ends the observation started by Observe.
+func (o *StateObserver) StopObserving() {
+	o.mu.Lock()
+	stop := o.stopReads
+	o.stopReads = nil
+	o.mu.Unlock()
+	if stop != nil {
+		stop()
+	}
+}
+
+func (o *StateObserver) recordRead(scope any, obj StateObject) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	objects, ok := o.reads[scope]
+	if !ok {
+		objects = map[StateObject]struct{}{}
+		o.reads[scope] = objects
+	}
+	objects[obj] = struct{}{}
+
+	scopes, ok := o.readers[obj]
+	if !ok {
+		scopes = map[any]struct{}{}
+		o.readers[obj] = scopes
+	}
+	scopes[scope] = struct{}{}
+}
+
+// Clear forgets the reads and the invalidation of scope, typically right before
+// the scope is composed again.
+func (o *StateObserver) Clear(scope any) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.clearLocked(scope)
+}
+
+func (o *StateObserver) clearLocked(scope any) {
+	for obj := range o.reads[scope] {
+		scopes := o.readers[obj]
+		delete(scopes, scope)
+		if len(scopes) == 0 {
+			delete(o.readers, obj)
+		}
+	}
+	delete(o.reads, scope)
+	delete(o.invalid, scope)
+}
+
+// ClearAll forgets every recorded read and invalidation.
+func (o *StateObserver) ClearAll() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.reads = map[any]map[StateObject]struct{}{}
+	o.readers = map[StateObject]map[any]struct{}{}
+	o.invalid = map[any]struct{}{}
+}
+
+// IsObserved reports whether any scope read obj.
+func (o *StateObserver) IsObserved(obj StateObject) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.readers[obj]
+	return ok
+}
+
+// IsInvalid reports whether scope read a value that has changed since.
+func (o *StateObserver) IsInvalid(scope any) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	_, ok := o.invalid[scope]
+	return ok
+}
+
+// HasInvalidations reports whether any scope is invalid.
+func (o *StateObserver) HasInvalidations() bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.invalid) > 0
+}
+
+// Invalidate marks scope invalid regardless of what it read.
+func (o *StateObserver) Invalidate(scope any) {
+	o.mu.Lock()
+	o.invalid[scope] = struct{}{}
+	o.mu.Unlock()
+	if o.onInvalidate != nil {
+		o.onInvalidate()
+	}
+}
+
+// onWrite calls onInvalidate once for objects written together, so a Batch
+// schedules a single frame.
+func (o *StateObserver) onWrite(objs []StateObject) {
+	if o.onInvalidate == nil {
+		return
+	}
+	for _, obj := range objs {
+		if o.IsObserved(obj) {
+			o.onInvalidate()
+			return
+		}
+	}
+}
+
+func (o *StateObserver) onApply(changed []StateObject) {
+	o.mu.Lock()
+	for _, obj := range changed {
+		for scope := range o.readers[obj] {
+			o.invalid[scope] = struct{}{}
+		}
+	}
+	o.mu.Unlock()
+}
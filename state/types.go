@@ -2,5 +2,5 @@ package state
 
 type SupportState interface {
 	Remember(key string, calc func() any) any          // transient state
-	State(key string, initial func() any) MutableValue // persistent state
+	State(key string, initial func() any) MutableValue // persistent state, scoped to the caller unless the key is a GlobalKey
 }
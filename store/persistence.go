@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"sync"
+	"sync/atomic"
 
 	"github.com/zodimo/go-compose/state"
 )
@@ -18,72 +19,164 @@ var _ MutableValueInterface = &MutableValueTypedWrapper[any]{}
 var _ TypedMutableValueInterface[any] = &MutableValueTypedWrapper[any]{}
 
 // MutableValue is a trivial state container.
+// Its value lives in a state.SnapshotValue, so reads are tracked by the composition
+// and writes follow the snapshot rules.
 type MutableValue struct {
-	cell           any
+	value          *state.SnapshotValue
 	changeNotifier func(any)
-	mutex          sync.Mutex
-	compare        func(any, any) bool
 }
 
 func NewMutableValue(initial any, changeNotifier func(any), compare func(any, any) bool) *MutableValue {
 	return &MutableValue{
-		cell:           initial,
+		value:          state.NewSnapshotValue(initial, compare),
 		changeNotifier: changeNotifier,
-		compare:        compare,
 	}
 }
 
 func (mv *MutableValue) Get() any {
-	return mv.cell
+	return mv.value.Get()
 }
 
 func (mv *MutableValue) Set(value any) {
-	changed := !mv.compare(mv.cell, value)
-	if changed {
-		mv.mutex.Lock()
-		defer mv.mutex.Unlock()
-		mv.cell = value
-		if mv.changeNotifier != nil {
-			mv.changeNotifier(value)
-		}
+	if mv.value.Update(value) && mv.changeNotifier != nil {
+		mv.changeNotifier(value)
 	}
 }
 
+// StateObject exposes the snapshot record chain behind the value.
+func (mv *MutableValue) StateObject() state.StateObject {
+	return mv.value
+}
+
+// PersistentState keeps state values across frames.
+// Changes only reach SetOnStateChange when a value read by the composition changes,
+// and the scopes that read it are invalidated at the next frame boundary.
+//
+// It is safe for concurrent use: values can be looked up and set from effects
+// running on other goroutines while a frame is composed. The composition reads
+// a snapshot of the values, so a value set there is seen by the next frame.
 type PersistentState struct {
+	mu            sync.Mutex
 	scopes        map[string]MutableValueInterface
-	onStateChange func()
+	onStateChange atomic.Pointer[func()]
+	observer      *state.StateObserver
+	saveable      state.SaveableStateRegistry
+	unregister    map[string]func()
+}
+
+type PersistentStateOption func(*PersistentState)
+
+// WithSaveableStateRegistry restores the values of RememberSaveable from registry
+// and registers them with it, so that saving registry saves them.
+func WithSaveableStateRegistry(registry state.SaveableStateRegistry) PersistentStateOption {
+	return func(ps *PersistentState) {
+		ps.saveable = registry
+	}
 }
 
-func NewPersistentState(scopes map[string]MutableValueInterface) PersistentStateInterface {
-	return &PersistentState{scopes: scopes}
+func NewPersistentState(scopes map[string]MutableValueInterface, options ...PersistentStateOption) PersistentStateInterface {
+	ps := &PersistentState{
+		scopes:     scopes,
+		saveable:   state.NewSaveableStateRegistry(),
+		unregister: map[string]func(){},
+	}
+	for _, option := range options {
+		option(ps)
+	}
+	ps.observer = state.NewStateObserver(func() {
+		if onStateChange := ps.onStateChange.Load(); onStateChange != nil {
+			(*onStateChange)()
+		}
+	})
+	ps.observer.Start()
+	return ps
 }
 
 func (ps *PersistentState) SetOnStateChange(callback func()) {
-	ps.onStateChange = callback
+	if callback == nil {
+		ps.onStateChange.Store(nil)
+		return
+	}
+	ps.onStateChange.Store(&callback)
+}
+
+func (ps *PersistentState) Observer() *state.StateObserver {
+	return ps.observer
+}
+
+func (ps *PersistentState) SaveableStateRegistry() state.SaveableStateRegistry {
+	return ps.saveable
 }
 
 func (ps *PersistentState) GetState(id string, initial func() any) MutableValueInterface {
+	v, _ := ps.getOrCreate(id, initial)
+	return v
+}
+
+// getOrCreate returns the value stored under id, creating it from initial if
+// needed. initial runs without the lock held, as it may use the store itself;
+// when two goroutines race to create a value the first one stored wins.
+func (ps *PersistentState) getOrCreate(id string, initial func() any) (MutableValueInterface, bool) {
+	ps.mu.Lock()
+	v, ok := ps.scopes[id]
+	ps.mu.Unlock()
+	if ok {
+		return v, false
+	}
+
+	created := NewMutableValue(initial(), nil, reflect.DeepEqual)
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
 	if v, ok := ps.scopes[id]; ok {
-		return v
+		return v, false
 	}
-	ps.scopes[id] = &MutableValue{
-		cell: initial(),
-		changeNotifier: func(any) {
-			if ps.onStateChange != nil {
-				ps.onStateChange()
+	ps.scopes[id] = created
+	return created, true
+}
+
+// GetSaveableState falls back to initial when the restored data cannot be read,
+// for instance after the type of the value changed between releases.
+func (ps *PersistentState) GetSaveableState(id string, initial func() any, saver state.Saver[any]) MutableValueInterface {
+	v, created := ps.getOrCreate(id, func() any {
+		if data, ok := ps.saveable.Consume(id); ok {
+			if restored, err := saver.Restore(data); err == nil {
+				return restored
 			}
-		},
-		compare: reflect.DeepEqual,
+		}
+		return initial()
+	})
+	if created {
+		unregister := ps.saveable.Register(id, func() ([]byte, error) {
+			return saver.Save(v.Get())
+		})
+		ps.mu.Lock()
+		ps.unregister[id] = unregister
+		ps.mu.Unlock()
+	}
+	return v
+}
+
+func (ps *PersistentState) RemoveState(id string) {
+	ps.mu.Lock()
+	delete(ps.scopes, id)
+	unregister, ok := ps.unregister[id]
+	delete(ps.unregister, id)
+	ps.mu.Unlock()
+	if ok {
+		unregister()
 	}
-	return ps.scopes[id]
 }
 
+// Deprecated: nothing creates a MutableValueTyped with the store; use
+// state.MutableState, created by state.MutableStateOf or state.NewMutableState.
 type MutableValueTyped[T any] struct {
 	cell           T
 	changeNotifier func(T)
 	compare        func(T, T) bool
 }
 
+// Deprecated: use state.NewMutableState.
 func NewMutableValueTyped[T any](initial T, changeNotifier func(T), compare func(T, T) bool) *MutableValueTyped[T] {
 	return &MutableValueTyped[T]{
 		cell:           initial,
@@ -97,7 +190,7 @@ type MutableValueTypedWrapper[T any] struct {
 }
 
 func (w *MutableValueTypedWrapper[T]) Get() T {
-	return w.mv.cell.(T)
+	return w.mv.Get().(T)
 }
 
 func (w *MutableValueTypedWrapper[T]) Set(value T) {
@@ -106,7 +199,7 @@ func (w *MutableValueTypedWrapper[T]) Set(value T) {
 
 func WrapMutableValue[T any](mv *MutableValue) (TypedMutableValueInterface[T], error) {
 
-	_, ok := mv.cell.(T)
+	_, ok := mv.Get().(T)
 	if !ok {
 		var zero T
 		return nil, fmt.Errorf("cell is not of type %T", zero)
@@ -0,0 +1,131 @@
+package store_test
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/zodimo/go-compose/compose"
+	"github.com/zodimo/go-compose/state"
+	"github.com/zodimo/go-compose/store"
+)
+
+func TestPersistentStateConcurrentAccess(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	ps.SetOnStateChange(func() {})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				shared := ps.GetState("shared", func() any { return 0 })
+				shared.Set(j)
+				shared.Get()
+				own := ps.GetState(fmt.Sprintf("own/%d/%d", i, j), func() any { return i })
+				if own.Get() != i {
+					t.Errorf("Expected %d, got %v", i, own.Get())
+				}
+				ps.RemoveState(fmt.Sprintf("own/%d/%d", i, j))
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if a, b := ps.GetState("shared", nil), ps.GetState("shared", nil); a != b {
+		t.Error("Expected concurrent lookups to agree on a single value")
+	}
+}
+
+func TestBatchInvalidatesOnce(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	first := ps.GetState("first", func() any { return 0 })
+	second := ps.GetState("second", func() any { return 0 })
+
+	var invalidations atomic.Int32
+	ps.SetOnStateChange(func() { invalidations.Add(1) })
+
+	ps.Observer().Observe(func() any { return "scope" })
+	first.Get()
+	second.Get()
+	ps.Observer().StopObserving()
+
+	done := make(chan error)
+	go func() {
+		done <- state.Batch(func() {
+			first.Set(1)
+			second.Set(2)
+		})
+	}()
+	if err := <-done; err != nil {
+		t.Fatalf("Batch failed: %v", err)
+	}
+
+	if got := invalidations.Load(); got != 1 {
+		t.Errorf("Expected a single invalidation, got %d", got)
+	}
+	if first.Get() != 1 || second.Get() != 2 {
+		t.Errorf("Expected batched writes to be visible, got %v and %v", first.Get(), second.Get())
+	}
+	state.SendApplyNotifications()
+	if !ps.Observer().IsInvalid("scope") {
+		t.Error("Expected the reading scope to be invalid at the next frame")
+	}
+}
+
+func TestPersistentStateConcurrentWithComposition(t *testing.T) {
+	ps := store.NewPersistentState(map[string]state.MutableValue{})
+	ps.SetOnStateChange(func() {})
+
+	var read any
+	frame := func(i int) {
+		c := compose.NewComposer(ps)
+		c.StartBlock("Root")
+		c.State("count", func() any { return 0 }).Get()
+		read = c.State(state.GlobalKey("shared"), func() any { return 0 }).Get()
+		if i%2 == 0 {
+			c.StartBlock("Even")
+			c.State("even", func() any { return i }).Get()
+			c.EndBlock()
+		}
+		c.EndBlock()
+		c.Build()
+	}
+	frame(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 200; j++ {
+				shared := ps.GetState(state.GlobalKey("shared"), func() any { return 0 })
+				shared.Set(j)
+				shared.Get()
+				ps.GetState(fmt.Sprintf("own/%d", i), func() any { return i }).Set(j)
+				ps.RemoveState(fmt.Sprintf("own/%d", i))
+			}
+		}(i)
+	}
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+	// Frames are composed while the writes go on, then once more after them.
+	finished := false
+	for i := 1; !finished; i++ {
+		select {
+		case <-done:
+			finished = true
+		default:
+		}
+		frame(i)
+	}
+
+	if want := ps.GetState(state.GlobalKey("shared"), nil).Get(); read != want {
+		t.Errorf("Expected the frame after the writes to read %v, got %v", want, read)
+	}
+}